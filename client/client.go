@@ -1,9 +1,10 @@
 package client
 
 import (
-	"errors"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awsretry "github.com/aws/aws-sdk-go-v2/aws/retry"
 	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/jhmachado/dynamodb/dberrors"
 	"sync"
 )
 
@@ -12,9 +13,19 @@ var singleton *Wrapper
 
 func Init(cfg aws.Config) {
 	once.Do(func() {
-		singleton = &Wrapper{
-			AWSClient: ddb.NewFromConfig(cfg),
-		}
+		singleton = New(cfg)
+	})
+}
+
+func InitWithAPI(api DynamoDBAPI) {
+	once.Do(func() {
+		singleton = NewWithAPI(api)
+	})
+}
+
+func InitWithSDKRetries(api DynamoDBAPI, sdkRetries bool) {
+	once.Do(func() {
+		singleton = NewWithSDKRetries(api, sdkRetries)
 	})
 }
 
@@ -24,13 +35,51 @@ func InitWithClientTimeout(cfg aws.Config, timeoutMs int) {
 }
 
 type Wrapper struct {
-	AWSClient  *ddb.Client
+	AWSClient  DynamoDBAPI
 	TimeoutsMs *int
+	SDKRetries bool
+}
+
+func New(cfg aws.Config) *Wrapper {
+	return &Wrapper{
+		AWSClient:  ddb.NewFromConfig(cfg),
+		SDKRetries: sdkRetries(cfg),
+	}
+}
+
+func NewWithAPI(api DynamoDBAPI) *Wrapper {
+	_, sdkClient := api.(*ddb.Client)
+	return &Wrapper{
+		AWSClient:  api,
+		SDKRetries: sdkClient,
+	}
+}
+
+func NewWithSDKRetries(api DynamoDBAPI, sdkRetries bool) *Wrapper {
+	wrapper := NewWithAPI(api)
+	wrapper.SDKRetries = sdkRetries
+	return wrapper
+}
+
+func NewWithClientTimeout(cfg aws.Config, timeoutMs int) *Wrapper {
+	wrapper := New(cfg)
+	wrapper.TimeoutsMs = &timeoutMs
+	return wrapper
 }
 
 func GetClient() (*Wrapper, error) {
 	if singleton == nil {
-		return nil, errors.New("dynamodb GetClient not initialized")
+		return nil, dberrors.ErrClientNotInitialized
 	}
 	return singleton, nil
 }
+
+func sdkRetries(cfg aws.Config) bool {
+	if cfg.Retryer != nil {
+		return cfg.Retryer().MaxAttempts() > 1
+	}
+	if cfg.RetryMaxAttempts != 0 {
+		return cfg.RetryMaxAttempts > 1
+	}
+	return awsretry.DefaultMaxAttempts > 1
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"testing"
+)
+
+func TestSDKRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      aws.Config
+		expected bool
+	}{
+		{name: "default retryer", expected: true},
+		{name: "max attempts of one", cfg: aws.Config{RetryMaxAttempts: 1}},
+		{name: "max attempts of five", cfg: aws.Config{RetryMaxAttempts: 5}, expected: true},
+		{name: "nop retryer", cfg: aws.Config{Retryer: func() aws.Retryer { return aws.NopRetryer{} }}},
+		{name: "retryer wins over max attempts", cfg: aws.Config{RetryMaxAttempts: 5, Retryer: func() aws.Retryer { return aws.NopRetryer{} }}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := New(test.cfg).SDKRetries; got != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewWithAPISDKRetries(t *testing.T) {
+	if !NewWithAPI(ddb.New(ddb.Options{})).SDKRetries {
+		t.Error("expected an SDK client to retry on its own")
+	}
+	if NewWithAPI(nil).SDKRetries {
+		t.Error("expected a custom API not to report SDK retries")
+	}
+}
+
+func TestNewWithSDKRetries(t *testing.T) {
+	sdkClient := ddb.New(ddb.Options{Retryer: aws.NopRetryer{}})
+	if NewWithSDKRetries(sdkClient, false).SDKRetries {
+		t.Error("expected an SDK client without retries to report no SDK retries")
+	}
+
+	decorated := struct{ DynamoDBAPI }{sdkClient}
+	if NewWithAPI(decorated).SDKRetries {
+		t.Error("expected a decorated API not to report SDK retries by default")
+	}
+	if !NewWithSDKRetries(decorated, true).SDKRetries {
+		t.Error("expected a decorated SDK client to report SDK retries when told so")
+	}
+}
@@ -0,0 +1,261 @@
+package dbconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/jhmachado/dynamodb/client"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+type RetryMode string
+
+const (
+	RetryModeStandard RetryMode = "standard"
+	RetryModeAdaptive RetryMode = "adaptive"
+)
+
+type Config struct {
+	Region           string
+	Endpoint         string
+	Profile          string
+	AccessKeyID      string
+	SecretAccessKey  string
+	SessionToken     string
+	RetryMode        RetryMode
+	MaxAttempts      int
+	ConnectTimeout   time.Duration
+	RequestTimeout   time.Duration
+	IdleConnTimeout  time.Duration
+	MaxIdleConns     int
+	MaxConnsPerHost  int
+	OperationTimeout time.Duration
+}
+
+type Option func(c *Config)
+
+func WithRegion(region string) Option {
+	return func(c *Config) {
+		c.Region = region
+	}
+}
+
+func WithEndpoint(endpoint string) Option {
+	return func(c *Config) {
+		c.Endpoint = endpoint
+	}
+}
+
+func WithProfile(profile string) Option {
+	return func(c *Config) {
+		c.Profile = profile
+	}
+}
+
+func WithStaticCredentials(accessKeyID, secretAccessKey, sessionToken string) Option {
+	return func(c *Config) {
+		c.AccessKeyID = accessKeyID
+		c.SecretAccessKey = secretAccessKey
+		c.SessionToken = sessionToken
+	}
+}
+
+func WithRetry(mode RetryMode, maxAttempts int) Option {
+	return func(c *Config) {
+		c.RetryMode = mode
+		c.MaxAttempts = maxAttempts
+	}
+}
+
+func WithHTTPTimeouts(connectTimeout, requestTimeout time.Duration) Option {
+	return func(c *Config) {
+		c.ConnectTimeout = connectTimeout
+		c.RequestTimeout = requestTimeout
+	}
+}
+
+func WithConnectionPool(maxIdleConns, maxConnsPerHost int, idleConnTimeout time.Duration) Option {
+	return func(c *Config) {
+		c.MaxIdleConns = maxIdleConns
+		c.MaxConnsPerHost = maxConnsPerHost
+		c.IdleConnTimeout = idleConnTimeout
+	}
+}
+
+func WithOperationTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.OperationTimeout = timeout
+	}
+}
+
+func New(options ...Option) (Config, error) {
+	var c Config
+	return c.apply(options)
+}
+
+func (c Config) apply(options []Option) (Config, error) {
+	for _, option := range options {
+		option(&c)
+	}
+
+	if err := c.Validate(); err != nil {
+		return Config{}, err
+	}
+	return c, nil
+}
+
+func (c Config) Validate() error {
+	var problems []string
+
+	if c.Endpoint != "" {
+		u, err := url.Parse(c.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("endpoint %q must be an absolute http or https URL", c.Endpoint))
+		}
+	}
+
+	if (c.AccessKeyID == "") != (c.SecretAccessKey == "") {
+		problems = append(problems, "access key id and secret access key must be set together")
+	}
+	if c.SessionToken != "" && c.AccessKeyID == "" {
+		problems = append(problems, "session token requires static credentials")
+	}
+	if c.Profile != "" && c.AccessKeyID != "" {
+		problems = append(problems, "profile and static credentials are mutually exclusive")
+	}
+
+	switch c.RetryMode {
+	case "", RetryModeStandard, RetryModeAdaptive:
+	default:
+		problems = append(problems, fmt.Sprintf("retry mode %q must be %q or %q", c.RetryMode, RetryModeStandard, RetryModeAdaptive))
+	}
+	if c.MaxAttempts < 0 {
+		problems = append(problems, "max attempts cannot be negative")
+	}
+
+	for name, d := range map[string]time.Duration{
+		"connect timeout":   c.ConnectTimeout,
+		"request timeout":   c.RequestTimeout,
+		"idle conn timeout": c.IdleConnTimeout,
+		"operation timeout": c.OperationTimeout,
+	} {
+		if d < 0 {
+			problems = append(problems, name+" cannot be negative")
+		}
+	}
+	if c.RequestTimeout > 0 && c.ConnectTimeout > c.RequestTimeout {
+		problems = append(problems, "connect timeout cannot exceed request timeout")
+	}
+
+	if c.MaxIdleConns < 0 || c.MaxConnsPerHost < 0 {
+		problems = append(problems, "connection pool sizes cannot be negative")
+	}
+	if c.MaxConnsPerHost > 0 && c.MaxIdleConns > c.MaxConnsPerHost {
+		problems = append(problems, "max idle conns cannot exceed max conns per host")
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return validationError(problems)
+}
+
+func (c Config) AWSConfig(ctx context.Context) (aws.Config, error) {
+	if err := c.Validate(); err != nil {
+		return aws.Config{}, err
+	}
+
+	var options []func(*awsconfig.LoadOptions) error
+	if c.Region != "" {
+		options = append(options, awsconfig.WithRegion(c.Region))
+	}
+	if c.Profile != "" {
+		options = append(options, awsconfig.WithSharedConfigProfile(c.Profile))
+	}
+	if c.AccessKeyID != "" {
+		options = append(options, awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(c.AccessKeyID, c.SecretAccessKey, c.SessionToken)))
+	}
+	if c.Endpoint != "" {
+		endpoint := c.Endpoint
+		options = append(options, awsconfig.WithEndpointResolverWithOptions(aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+			return aws.Endpoint{URL: endpoint}, nil
+		})))
+	}
+	if c.RetryMode != "" {
+		options = append(options, awsconfig.WithRetryMode(aws.RetryMode(c.RetryMode)))
+	}
+	if c.MaxAttempts > 0 {
+		options = append(options, awsconfig.WithRetryMaxAttempts(c.MaxAttempts))
+	}
+	if httpClient := c.httpClient(); httpClient != nil {
+		options = append(options, awsconfig.WithHTTPClient(httpClient))
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return aws.Config{}, dberrors.NewKind(dberrors.ErrValidation, "Config", "", "", err)
+	}
+	return cfg, nil
+}
+
+func (c Config) NewClient(ctx context.Context) (*client.Wrapper, error) {
+	cfg, err := c.AWSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapper := client.New(cfg)
+	if c.OperationTimeout > 0 {
+		timeoutMs := int(c.OperationTimeout / time.Millisecond)
+		wrapper.TimeoutsMs = &timeoutMs
+	}
+	return wrapper, nil
+}
+
+func (c Config) httpClient() *awshttp.BuildableClient {
+	if c.ConnectTimeout == 0 && c.RequestTimeout == 0 && c.IdleConnTimeout == 0 && c.MaxIdleConns == 0 && c.MaxConnsPerHost == 0 {
+		return nil
+	}
+
+	httpClient := awshttp.NewBuildableClient()
+	if c.RequestTimeout > 0 {
+		httpClient = httpClient.WithTimeout(c.RequestTimeout)
+	}
+	if c.ConnectTimeout > 0 {
+		httpClient = httpClient.WithDialerOptions(func(d *net.Dialer) {
+			d.Timeout = c.ConnectTimeout
+		})
+	}
+
+	return httpClient.WithTransportOptions(func(t *http.Transport) {
+		if c.ConnectTimeout > 0 {
+			t.TLSHandshakeTimeout = c.ConnectTimeout
+		}
+		if c.IdleConnTimeout > 0 {
+			t.IdleConnTimeout = c.IdleConnTimeout
+		}
+		if c.MaxIdleConns > 0 {
+			t.MaxIdleConns = c.MaxIdleConns
+			t.MaxIdleConnsPerHost = c.MaxIdleConns
+		}
+		if c.MaxConnsPerHost > 0 {
+			t.MaxConnsPerHost = c.MaxConnsPerHost
+		}
+	})
+}
+
+func validationError(problems []string) error {
+	sort.Strings(problems)
+	return dberrors.NewKind(dberrors.ErrValidation, "Config", "", "", errors.New(strings.Join(problems, "; ")))
+}
@@ -0,0 +1,137 @@
+package dbconfig
+
+import (
+	"context"
+	"errors"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr []string
+	}{
+		{name: "empty", config: Config{}},
+		{
+			name: "complete",
+			config: Config{
+				Region:          "eu-west-1",
+				Endpoint:        "http://localhost:8000",
+				AccessKeyID:     "key",
+				SecretAccessKey: "secret",
+				SessionToken:    "token",
+				RetryMode:       RetryModeAdaptive,
+				MaxAttempts:     5,
+				ConnectTimeout:  time.Second,
+				RequestTimeout:  5 * time.Second,
+				MaxIdleConns:    10,
+				MaxConnsPerHost: 10,
+			},
+		},
+		{name: "profile only", config: Config{Profile: "dev"}},
+		{name: "connect timeout without request timeout", config: Config{ConnectTimeout: time.Minute}},
+		{name: "relative endpoint", config: Config{Endpoint: "localhost:8000"}, wantErr: []string{`endpoint "localhost:8000" must be an absolute http or https URL`}},
+		{name: "unsupported endpoint scheme", config: Config{Endpoint: "ftp://localhost"}, wantErr: []string{"must be an absolute http or https URL"}},
+		{name: "access key without secret", config: Config{AccessKeyID: "key"}, wantErr: []string{"must be set together"}},
+		{name: "secret without access key", config: Config{SecretAccessKey: "secret"}, wantErr: []string{"must be set together"}},
+		{name: "session token without credentials", config: Config{SessionToken: "token"}, wantErr: []string{"session token requires static credentials"}},
+		{name: "profile and static credentials", config: Config{Profile: "dev", AccessKeyID: "key", SecretAccessKey: "secret"}, wantErr: []string{"mutually exclusive"}},
+		{name: "unknown retry mode", config: Config{RetryMode: "legacy"}, wantErr: []string{`retry mode "legacy"`}},
+		{name: "negative max attempts", config: Config{MaxAttempts: -1}, wantErr: []string{"max attempts cannot be negative"}},
+		{name: "negative timeout", config: Config{IdleConnTimeout: -time.Second}, wantErr: []string{"idle conn timeout cannot be negative"}},
+		{name: "connect timeout exceeds request timeout", config: Config{ConnectTimeout: time.Minute, RequestTimeout: time.Second}, wantErr: []string{"connect timeout cannot exceed request timeout"}},
+		{name: "negative pool size", config: Config{MaxConnsPerHost: -1}, wantErr: []string{"connection pool sizes cannot be negative"}},
+		{name: "idle conns exceed conns per host", config: Config{MaxIdleConns: 20, MaxConnsPerHost: 10}, wantErr: []string{"max idle conns cannot exceed max conns per host"}},
+		{
+			name:    "problems are reported together",
+			config:  Config{SessionToken: "token", MaxAttempts: -1, OperationTimeout: -time.Second},
+			wantErr: []string{"max attempts cannot be negative; operation timeout cannot be negative; session token requires static credentials"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkConfigError(t, tt.config.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	c, err := New(
+		WithRegion("eu-west-1"),
+		WithEndpoint("http://localhost:8000"),
+		WithStaticCredentials("key", "secret", ""),
+		WithRetry(RetryModeStandard, 3),
+		WithHTTPTimeouts(time.Second, 2*time.Second),
+		WithConnectionPool(4, 8, time.Minute),
+		WithOperationTimeout(3*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	want := Config{
+		Region:           "eu-west-1",
+		Endpoint:         "http://localhost:8000",
+		AccessKeyID:      "key",
+		SecretAccessKey:  "secret",
+		RetryMode:        RetryModeStandard,
+		MaxAttempts:      3,
+		ConnectTimeout:   time.Second,
+		RequestTimeout:   2 * time.Second,
+		IdleConnTimeout:  time.Minute,
+		MaxIdleConns:     4,
+		MaxConnsPerHost:  8,
+		OperationTimeout: 3 * time.Second,
+	}
+	if c != want {
+		t.Errorf("New() = %+v, want %+v", c, want)
+	}
+
+	if _, err := New(WithProfile("dev"), WithStaticCredentials("key", "secret", "")); !errors.Is(err, dberrors.ErrValidation) {
+		t.Errorf("New() error = %v, want ErrValidation", err)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	c, err := New(WithRegion("eu-west-1"), WithEndpoint("http://localhost:8000"), WithStaticCredentials("key", "secret", ""), WithOperationTimeout(1500*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	wrapper, err := c.NewClient(context.Background())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if wrapper.TimeoutsMs == nil || *wrapper.TimeoutsMs != 1500 {
+		t.Errorf("TimeoutsMs = %v, want 1500", wrapper.TimeoutsMs)
+	}
+
+	if _, err := (Config{MaxAttempts: -1}).NewClient(context.Background()); !errors.Is(err, dberrors.ErrValidation) {
+		t.Errorf("NewClient() error = %v, want ErrValidation", err)
+	}
+}
+
+func checkConfigError(t *testing.T, err error, wantErr []string) {
+	t.Helper()
+
+	if len(wantErr) == 0 {
+		if err != nil {
+			t.Errorf("error = %v, want nil", err)
+		}
+		return
+	}
+
+	if !errors.Is(err, dberrors.ErrValidation) {
+		t.Fatalf("error = %v, want ErrValidation", err)
+	}
+	for _, want := range wantErr {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %q, want it to contain %q", err, want)
+		}
+	}
+}
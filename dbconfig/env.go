@@ -0,0 +1,97 @@
+package dbconfig
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	EnvRegion           = "DYNAMODB_REGION"
+	EnvEndpoint         = "DYNAMODB_URL"
+	EnvProfile          = "DYNAMODB_PROFILE"
+	EnvAccessKeyID      = "DYNAMODB_ACCESS_KEY_ID"
+	EnvSecretAccessKey  = "DYNAMODB_SECRET_ACCESS_KEY"
+	EnvSessionToken     = "DYNAMODB_SESSION_TOKEN"
+	EnvRetryMode        = "DYNAMODB_RETRY_MODE"
+	EnvMaxAttempts      = "DYNAMODB_MAX_ATTEMPTS"
+	EnvConnectTimeout   = "DYNAMODB_CONNECT_TIMEOUT"
+	EnvRequestTimeout   = "DYNAMODB_REQUEST_TIMEOUT"
+	EnvIdleConnTimeout  = "DYNAMODB_IDLE_CONN_TIMEOUT"
+	EnvMaxIdleConns     = "DYNAMODB_MAX_IDLE_CONNS"
+	EnvMaxConnsPerHost  = "DYNAMODB_MAX_CONNS_PER_HOST"
+	EnvOperationTimeout = "DYNAMODB_OPERATION_TIMEOUT"
+	EnvCloudDeploy      = "IS_CLOUD_DEPLOY"
+)
+
+const (
+	localAccessKeyID     = "TestKey"
+	localSecretAccessKey = "TestSecretKey"
+)
+
+func FromEnv(options ...Option) (Config, error) {
+	c, err := envConfig()
+	if err != nil {
+		return Config{}, err
+	}
+	return c.apply(options)
+}
+
+func IsCloudDeploy() bool {
+	return strings.ToUpper(os.Getenv(EnvCloudDeploy)) == "TRUE"
+}
+
+func envConfig() (Config, error) {
+	c := Config{
+		Region:          os.Getenv(EnvRegion),
+		Profile:         os.Getenv(EnvProfile),
+		AccessKeyID:     os.Getenv(EnvAccessKeyID),
+		SecretAccessKey: os.Getenv(EnvSecretAccessKey),
+		SessionToken:    os.Getenv(EnvSessionToken),
+		RetryMode:       RetryMode(os.Getenv(EnvRetryMode)),
+	}
+
+	if !IsCloudDeploy() {
+		c.Endpoint = os.Getenv(EnvEndpoint)
+		if c.Endpoint != "" && c.AccessKeyID == "" && c.Profile == "" {
+			c.AccessKeyID, c.SecretAccessKey = localAccessKeyID, localSecretAccessKey
+		}
+	}
+
+	var problems []string
+	for name, target := range map[string]*int{
+		EnvMaxAttempts:     &c.MaxAttempts,
+		EnvMaxIdleConns:    &c.MaxIdleConns,
+		EnvMaxConnsPerHost: &c.MaxConnsPerHost,
+	} {
+		if value := os.Getenv(name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s=%q is not an integer", name, value))
+			}
+			*target = n
+		}
+	}
+
+	for name, target := range map[string]*time.Duration{
+		EnvConnectTimeout:   &c.ConnectTimeout,
+		EnvRequestTimeout:   &c.RequestTimeout,
+		EnvIdleConnTimeout:  &c.IdleConnTimeout,
+		EnvOperationTimeout: &c.OperationTimeout,
+	} {
+		if value := os.Getenv(name); value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s=%q is not a duration", name, value))
+			}
+			*target = d
+		}
+	}
+
+	if len(problems) > 0 {
+		return Config{}, validationError(problems)
+	}
+	return c, nil
+}
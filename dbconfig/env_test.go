@@ -0,0 +1,132 @@
+package dbconfig
+
+import (
+	"testing"
+	"time"
+)
+
+var envNames = []string{
+	EnvRegion, EnvEndpoint, EnvProfile, EnvAccessKeyID, EnvSecretAccessKey, EnvSessionToken,
+	EnvRetryMode, EnvMaxAttempts, EnvConnectTimeout, EnvRequestTimeout, EnvIdleConnTimeout,
+	EnvMaxIdleConns, EnvMaxConnsPerHost, EnvOperationTimeout, EnvCloudDeploy,
+}
+
+func setEnv(t *testing.T, values map[string]string) {
+	t.Helper()
+
+	for _, name := range envNames {
+		t.Setenv(name, values[name])
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		options []Option
+		want    Config
+		wantErr []string
+	}{
+		{name: "empty", want: Config{}},
+		{
+			name: "all variables",
+			env: map[string]string{
+				EnvRegion:           "eu-west-1",
+				EnvAccessKeyID:      "key",
+				EnvSecretAccessKey:  "secret",
+				EnvSessionToken:     "token",
+				EnvRetryMode:        "adaptive",
+				EnvMaxAttempts:      "4",
+				EnvConnectTimeout:   "1s",
+				EnvRequestTimeout:   "5s",
+				EnvIdleConnTimeout:  "1m",
+				EnvMaxIdleConns:     "2",
+				EnvMaxConnsPerHost:  "6",
+				EnvOperationTimeout: "250ms",
+			},
+			want: Config{
+				Region:           "eu-west-1",
+				AccessKeyID:      "key",
+				SecretAccessKey:  "secret",
+				SessionToken:     "token",
+				RetryMode:        RetryModeAdaptive,
+				MaxAttempts:      4,
+				ConnectTimeout:   time.Second,
+				RequestTimeout:   5 * time.Second,
+				IdleConnTimeout:  time.Minute,
+				MaxIdleConns:     2,
+				MaxConnsPerHost:  6,
+				OperationTimeout: 250 * time.Millisecond,
+			},
+		},
+		{
+			name:    "options override the environment",
+			env:     map[string]string{EnvRegion: "eu-west-1", EnvMaxAttempts: "4", EnvRetryMode: "adaptive"},
+			options: []Option{WithRegion("us-east-2"), WithRetry(RetryModeStandard, 2)},
+			want:    Config{Region: "us-east-2", RetryMode: RetryModeStandard, MaxAttempts: 2},
+		},
+		{
+			name: "local endpoint defaults credentials",
+			env:  map[string]string{EnvEndpoint: "http://localhost:8000"},
+			want: Config{Endpoint: "http://localhost:8000", AccessKeyID: localAccessKeyID, SecretAccessKey: localSecretAccessKey},
+		},
+		{
+			name: "local endpoint keeps explicit credentials",
+			env:  map[string]string{EnvEndpoint: "http://localhost:8000", EnvAccessKeyID: "key", EnvSecretAccessKey: "secret"},
+			want: Config{Endpoint: "http://localhost:8000", AccessKeyID: "key", SecretAccessKey: "secret"},
+		},
+		{
+			name: "local endpoint keeps profile",
+			env:  map[string]string{EnvEndpoint: "http://localhost:8000", EnvProfile: "dev"},
+			want: Config{Endpoint: "http://localhost:8000", Profile: "dev"},
+		},
+		{
+			name: "cloud deploy ignores endpoint",
+			env:  map[string]string{EnvEndpoint: "http://localhost:8000", EnvCloudDeploy: "true"},
+			want: Config{},
+		},
+		{
+			name:    "option endpoint applies in cloud deploy",
+			env:     map[string]string{EnvCloudDeploy: "TRUE"},
+			options: []Option{WithEndpoint("https://dynamodb.eu-west-1.amazonaws.com")},
+			want:    Config{Endpoint: "https://dynamodb.eu-west-1.amazonaws.com"},
+		},
+		{
+			name:    "malformed values",
+			env:     map[string]string{EnvMaxAttempts: "three", EnvRequestTimeout: "5"},
+			wantErr: []string{`DYNAMODB_MAX_ATTEMPTS="three" is not an integer`, `DYNAMODB_REQUEST_TIMEOUT="5" is not a duration`},
+		},
+		{
+			name:    "combined values are validated",
+			env:     map[string]string{EnvConnectTimeout: "10s", EnvRequestTimeout: "1s"},
+			wantErr: []string{"connect timeout cannot exceed request timeout"},
+		},
+		{
+			name:    "options are validated",
+			env:     map[string]string{EnvProfile: "dev"},
+			options: []Option{WithStaticCredentials("key", "secret", "")},
+			wantErr: []string{"mutually exclusive"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+
+			got, err := FromEnv(tt.options...)
+			checkConfigError(t, err, tt.wantErr)
+			if len(tt.wantErr) == 0 && got != tt.want {
+				t.Errorf("FromEnv() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCloudDeploy(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "false": false, "true": true, "TRUE": true, "True": true, "1": false} {
+		t.Setenv(EnvCloudDeploy, value)
+		if got := IsCloudDeploy(); got != want {
+			t.Errorf("IsCloudDeploy() with %q = %v, want %v", value, got, want)
+		}
+	}
+}
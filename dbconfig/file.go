@@ -0,0 +1,108 @@
+package dbconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type fileConfig struct {
+	Region           string `json:"region" yaml:"region"`
+	Endpoint         string `json:"endpoint" yaml:"endpoint"`
+	Profile          string `json:"profile" yaml:"profile"`
+	AccessKeyID      string `json:"access_key_id" yaml:"access_key_id"`
+	SecretAccessKey  string `json:"secret_access_key" yaml:"secret_access_key"`
+	SessionToken     string `json:"session_token" yaml:"session_token"`
+	RetryMode        string `json:"retry_mode" yaml:"retry_mode"`
+	MaxAttempts      int    `json:"max_attempts" yaml:"max_attempts"`
+	ConnectTimeout   string `json:"connect_timeout" yaml:"connect_timeout"`
+	RequestTimeout   string `json:"request_timeout" yaml:"request_timeout"`
+	IdleConnTimeout  string `json:"idle_conn_timeout" yaml:"idle_conn_timeout"`
+	MaxIdleConns     int    `json:"max_idle_conns" yaml:"max_idle_conns"`
+	MaxConnsPerHost  int    `json:"max_conns_per_host" yaml:"max_conns_per_host"`
+	OperationTimeout string `json:"operation_timeout" yaml:"operation_timeout"`
+}
+
+func FromFile(path string, options ...Option) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, dberrors.New("Config", "", "", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FromJSON(data, options...)
+	case ".yaml", ".yml":
+		return FromYAML(data, options...)
+	default:
+		return Config{}, dberrors.NewKind(dberrors.ErrValidation, "Config", "", "", fmt.Errorf("unsupported config file extension %q", filepath.Ext(path)))
+	}
+}
+
+func FromJSON(data []byte, options ...Option) (Config, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var fc fileConfig
+	if err := decoder.Decode(&fc); err != nil {
+		return Config{}, dberrors.NewKind(dberrors.ErrValidation, "Config", "", "", err)
+	}
+	return fc.config(options)
+}
+
+func FromYAML(data []byte, options ...Option) (Config, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var fc fileConfig
+	if err := decoder.Decode(&fc); err != nil {
+		return Config{}, dberrors.NewKind(dberrors.ErrValidation, "Config", "", "", err)
+	}
+	return fc.config(options)
+}
+
+func (fc fileConfig) config(options []Option) (Config, error) {
+	c := Config{
+		Region:          fc.Region,
+		Endpoint:        fc.Endpoint,
+		Profile:         fc.Profile,
+		AccessKeyID:     fc.AccessKeyID,
+		SecretAccessKey: fc.SecretAccessKey,
+		SessionToken:    fc.SessionToken,
+		RetryMode:       RetryMode(fc.RetryMode),
+		MaxAttempts:     fc.MaxAttempts,
+		MaxIdleConns:    fc.MaxIdleConns,
+		MaxConnsPerHost: fc.MaxConnsPerHost,
+	}
+
+	var problems []string
+	for name, field := range map[string]struct {
+		value  string
+		target *time.Duration
+	}{
+		"connect_timeout":   {fc.ConnectTimeout, &c.ConnectTimeout},
+		"request_timeout":   {fc.RequestTimeout, &c.RequestTimeout},
+		"idle_conn_timeout": {fc.IdleConnTimeout, &c.IdleConnTimeout},
+		"operation_timeout": {fc.OperationTimeout, &c.OperationTimeout},
+	} {
+		if field.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(field.value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s %q is not a duration", name, field.value))
+		}
+		*field.target = d
+	}
+
+	if len(problems) > 0 {
+		return Config{}, validationError(problems)
+	}
+	return c.apply(options)
+}
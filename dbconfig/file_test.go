@@ -0,0 +1,137 @@
+package dbconfig
+
+import (
+	"errors"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const jsonConfig = `{
+	"region": "eu-west-1",
+	"endpoint": "http://localhost:8000",
+	"access_key_id": "key",
+	"secret_access_key": "secret",
+	"retry_mode": "standard",
+	"max_attempts": 3,
+	"connect_timeout": "1s",
+	"request_timeout": "10s",
+	"max_idle_conns": 4,
+	"max_conns_per_host": 8,
+	"operation_timeout": "2s"
+}`
+
+const yamlConfig = `region: eu-west-1
+endpoint: http://localhost:8000
+access_key_id: key
+secret_access_key: secret
+retry_mode: standard
+max_attempts: 3
+connect_timeout: 1s
+request_timeout: 10s
+max_idle_conns: 4
+max_conns_per_host: 8
+operation_timeout: 2s
+`
+
+var fileWant = Config{
+	Region:           "eu-west-1",
+	Endpoint:         "http://localhost:8000",
+	AccessKeyID:      "key",
+	SecretAccessKey:  "secret",
+	RetryMode:        RetryModeStandard,
+	MaxAttempts:      3,
+	ConnectTimeout:   time.Second,
+	RequestTimeout:   10 * time.Second,
+	MaxIdleConns:     4,
+	MaxConnsPerHost:  8,
+	OperationTimeout: 2 * time.Second,
+}
+
+func TestFromJSONAndYAML(t *testing.T) {
+	loaders := map[string]func([]byte, ...Option) (Config, error){"json": FromJSON, "yaml": FromYAML}
+
+	tests := []struct {
+		name    string
+		data    map[string]string
+		options []Option
+		want    Config
+		wantErr []string
+	}{
+		{name: "complete", data: map[string]string{"json": jsonConfig, "yaml": yamlConfig}, want: fileWant},
+		{
+			name:    "options override the file",
+			data:    map[string]string{"json": jsonConfig, "yaml": yamlConfig},
+			options: []Option{WithRegion("us-east-2"), WithOperationTimeout(0)},
+			want: func() Config {
+				c := fileWant
+				c.Region = "us-east-2"
+				c.OperationTimeout = 0
+				return c
+			}(),
+		},
+		{
+			name:    "unknown field",
+			data:    map[string]string{"json": `{"regoin": "eu-west-1"}`, "yaml": "regoin: eu-west-1\n"},
+			wantErr: []string{"regoin"},
+		},
+		{
+			name:    "malformed duration",
+			data:    map[string]string{"json": `{"request_timeout": "ten"}`, "yaml": "request_timeout: ten\n"},
+			wantErr: []string{`request_timeout "ten" is not a duration`},
+		},
+		{
+			name:    "invalid combination",
+			data:    map[string]string{"json": `{"session_token": "token"}`, "yaml": "session_token: token\n"},
+			wantErr: []string{"session token requires static credentials"},
+		},
+	}
+
+	for _, tt := range tests {
+		for format, load := range loaders {
+			t.Run(tt.name+"/"+format, func(t *testing.T) {
+				got, err := load([]byte(tt.data[format]), tt.options...)
+				checkConfigError(t, err, tt.wantErr)
+				if len(tt.wantErr) == 0 && got != tt.want {
+					t.Errorf("%s loader = %+v, want %+v", format, got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestFromFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		return path
+	}
+
+	for _, path := range []string{write("config.json", jsonConfig), write("config.YAML", yamlConfig), write("config.yml", yamlConfig)} {
+		got, err := FromFile(path)
+		if err != nil {
+			t.Errorf("FromFile(%s) error = %v", filepath.Base(path), err)
+			continue
+		}
+		if got != fileWant {
+			t.Errorf("FromFile(%s) = %+v, want %+v", filepath.Base(path), got, fileWant)
+		}
+	}
+
+	if _, err := FromFile(write("config.toml", "")); !errors.Is(err, dberrors.ErrValidation) {
+		t.Errorf("FromFile(config.toml) error = %v, want ErrValidation", err)
+	}
+
+	_, err := FromFile(filepath.Join(dir, "missing.json"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("FromFile(missing.json) error = %v, want os.ErrNotExist", err)
+	}
+	if errors.Is(err, dberrors.ErrValidation) {
+		t.Errorf("FromFile(missing.json) error = %v, should not be a validation error", err)
+	}
+}
@@ -0,0 +1,140 @@
+package dberrors
+
+import (
+	"errors"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	"strings"
+)
+
+var (
+	ErrClientNotInitialized    = errors.New("dynamodb client not initialized")
+	ErrConditionalCheckFailed  = errors.New("conditional check failed")
+	ErrThrottled               = errors.New("request throttled")
+	ErrTableNotFound           = errors.New("table or index not found")
+	ErrValidation              = errors.New("validation failed")
+	ErrTransactionCanceled     = errors.New("transaction canceled")
+	ErrTransactionConflict     = errors.New("transaction conflict")
+	ErrItemCollectionSizeLimit = errors.New("item collection size limit exceeded")
+	ErrInternal                = errors.New("internal server error")
+	ErrMarshal                 = errors.New("failed to marshal value")
+	ErrUnmarshal               = errors.New("failed to unmarshal item")
+	ErrUnprocessed             = errors.New("items left unprocessed")
+	ErrTableInUse              = errors.New("table or index in use")
+	ErrWaitTimeout             = errors.New("timed out waiting for table or index state")
+	ErrDuplicateItem           = errors.New("duplicate item")
+)
+
+var codeToSentinel = map[string]error{
+	"ConditionalCheckFailedException":          ErrConditionalCheckFailed,
+	"ProvisionedThroughputExceededException":   ErrThrottled,
+	"RequestLimitExceeded":                     ErrThrottled,
+	"ThrottlingException":                      ErrThrottled,
+	"Throttling":                               ErrThrottled,
+	"ResourceNotFoundException":                ErrTableNotFound,
+	"ResourceInUseException":                   ErrTableInUse,
+	"ValidationException":                      ErrValidation,
+	"TransactionCanceledException":             ErrTransactionCanceled,
+	"TransactionConflictException":             ErrTransactionConflict,
+	"ItemCollectionSizeLimitExceededException": ErrItemCollectionSizeLimit,
+	"DuplicateItemException":                   ErrDuplicateItem,
+	"InternalServerError":                      ErrInternal,
+	"ConditionalCheckFailed":                   ErrConditionalCheckFailed,
+	"TransactionConflict":                      ErrTransactionConflict,
+	"ThrottlingError":                          ErrThrottled,
+	"ProvisionedThroughputExceeded":            ErrThrottled,
+	"ValidationError":                          ErrValidation,
+	"ResourceNotFound":                         ErrTableNotFound,
+	"ItemCollectionSizeLimitExceeded":          ErrItemCollectionSizeLimit,
+	"DuplicateItem":                            ErrDuplicateItem,
+}
+
+type Error struct {
+	Op        string
+	Table     string
+	Key       string
+	Code      string
+	RequestID string
+	Kind      error
+	Err       error
+}
+
+func New(op, table, key string, err error) *Error {
+	e := &Error{
+		Op:    op,
+		Table: table,
+		Key:   key,
+		Err:   err,
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		e.Code = apiErr.ErrorCode()
+	}
+
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) {
+		e.RequestID = respErr.ServiceRequestID()
+	}
+
+	return e
+}
+
+func NewKind(kind error, op, table, key string, err error) *Error {
+	e := New(op, table, key, err)
+	e.Kind = kind
+	return e
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString("dynamodb " + e.Op)
+
+	if e.Table != "" {
+		b.WriteString(" on " + e.Table)
+	}
+	if e.Key != "" {
+		b.WriteString(" for key " + e.Key)
+	}
+	if e.Code != "" {
+		b.WriteString(" [" + e.Code + "]")
+	}
+	if e.RequestID != "" {
+		b.WriteString(" (request id: " + e.RequestID + ")")
+	}
+	if e.Kind != nil {
+		b.WriteString(": " + e.Kind.Error())
+	}
+	if e.Err != nil {
+		b.WriteString(": " + e.Err.Error())
+	}
+
+	return b.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	if e.Kind != nil && e.Kind == target {
+		return true
+	}
+
+	sentinel, ok := codeToSentinel[e.Code]
+	return ok && sentinel == target
+}
+
+func Code(err error) string {
+	var e *Error
+	if errors.As(err, &e) && e.Code != "" {
+		return e.Code
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+
+	return ""
+}
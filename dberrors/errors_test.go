@@ -0,0 +1,149 @@
+package dberrors
+
+import (
+	"errors"
+	"fmt"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"net/http"
+	"testing"
+)
+
+var sentinels = []error{
+	ErrClientNotInitialized, ErrConditionalCheckFailed, ErrThrottled, ErrTableNotFound, ErrValidation,
+	ErrTransactionCanceled, ErrTransactionConflict, ErrItemCollectionSizeLimit, ErrInternal, ErrMarshal,
+	ErrUnmarshal, ErrUnprocessed, ErrTableInUse, ErrWaitTimeout, ErrDuplicateItem,
+}
+
+func apiError(code string) error {
+	return &smithy.GenericAPIError{Code: code, Message: "failed"}
+}
+
+func responseError(requestID string, err error) error {
+	return &awshttp.ResponseError{
+		ResponseError: &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: 400}},
+			Err:      err,
+		},
+		RequestID: requestID,
+	}
+}
+
+func TestCodeToSentinel(t *testing.T) {
+	for code, want := range codeToSentinel {
+		t.Run(code, func(t *testing.T) {
+			err := New("PutItem", "orders", "a", apiError(code))
+			if err.Code != code {
+				t.Errorf("Code = %q, want %q", err.Code, code)
+			}
+
+			for _, sentinel := range sentinels {
+				if got := errors.Is(err, sentinel); got != (sentinel == want) {
+					t.Errorf("errors.Is(%v) = %v, want %v", sentinel, got, sentinel == want)
+				}
+			}
+		})
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	cause := errors.New("boom")
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{name: "kind", err: NewKind(ErrValidation, "Query", "orders", "", cause), target: ErrValidation, want: true},
+		{name: "kind does not match other sentinels", err: NewKind(ErrValidation, "Query", "orders", "", cause), target: ErrThrottled, want: false},
+		{name: "code", err: New("Query", "orders", "", apiError("ThrottlingException")), target: ErrThrottled, want: true},
+		{name: "kind and code", err: NewKind(ErrUnprocessed, "BatchWriteItem", "orders", "", apiError("ProvisionedThroughputExceededException")), target: ErrThrottled, want: true},
+		{name: "unknown code", err: New("Query", "orders", "", apiError("SomethingElse")), target: ErrValidation, want: false},
+		{name: "wrapped cause", err: New("Query", "orders", "", cause), target: cause, want: true},
+		{name: "wrapped error", err: fmt.Errorf("outer: %w", New("Query", "orders", "", apiError("ResourceNotFoundException"))), target: ErrTableNotFound, want: true},
+		{name: "nested sentinel", err: New("Query", "orders", "", ErrClientNotInitialized), target: ErrClientNotInitialized, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorUnwrap(t *testing.T) {
+	cause := apiError("ValidationException")
+	err := New("PutItem", "orders", "a", cause)
+
+	if got := err.Unwrap(); got != cause {
+		t.Errorf("Unwrap() = %v, want %v", got, cause)
+	}
+
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "ValidationException" {
+		t.Errorf("errors.As() APIError = %v", apiErr)
+	}
+}
+
+func TestRequestIDAndCode(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      string
+		wantRequestID string
+		wantMessage   string
+	}{
+		{
+			name:        "plain error",
+			err:         errors.New("boom"),
+			wantMessage: "dynamodb GetItem on orders for key a: boom",
+		},
+		{
+			name:        "api error",
+			err:         apiError("ConditionalCheckFailedException"),
+			wantCode:    "ConditionalCheckFailedException",
+			wantMessage: "dynamodb GetItem on orders for key a [ConditionalCheckFailedException]: api error ConditionalCheckFailedException: failed",
+		},
+		{
+			name:          "response error",
+			err:           responseError("req-1", apiError("ThrottlingException")),
+			wantCode:      "ThrottlingException",
+			wantRequestID: "req-1",
+		},
+		{
+			name:          "wrapped response error",
+			err:           fmt.Errorf("operation error: %w", responseError("req-2", apiError("ResourceNotFoundException"))),
+			wantCode:      "ResourceNotFoundException",
+			wantRequestID: "req-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New("GetItem", "orders", "a", tt.err)
+			if err.Code != tt.wantCode {
+				t.Errorf("Code = %q, want %q", err.Code, tt.wantCode)
+			}
+			if err.RequestID != tt.wantRequestID {
+				t.Errorf("RequestID = %q, want %q", err.RequestID, tt.wantRequestID)
+			}
+			if got := Code(err); got != tt.wantCode {
+				t.Errorf("Code() = %q, want %q", got, tt.wantCode)
+			}
+			if tt.wantMessage != "" && err.Error() != tt.wantMessage {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.wantMessage)
+			}
+		})
+	}
+
+	if got := Code(apiError("Throttling")); got != "Throttling" {
+		t.Errorf("Code() on a bare API error = %q, want Throttling", got)
+	}
+	if got := Code(errors.New("boom")); got != "" {
+		t.Errorf("Code() on a plain error = %q, want empty", got)
+	}
+}
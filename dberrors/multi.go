@@ -0,0 +1,57 @@
+package dberrors
+
+import (
+	"errors"
+	"strings"
+)
+
+type MultiError struct {
+	Summary string
+	Errors  []error
+}
+
+func Join(errs ...error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+
+	if len(nonNil) == 0 {
+		return nil
+	}
+
+	return &MultiError{Errors: nonNil}
+}
+
+func (m *MultiError) Error() string {
+	msgs := make([]string, 0, len(m.Errors))
+	for _, err := range m.Errors {
+		msgs = append(msgs, err.Error())
+	}
+
+	if m.Summary == "" {
+		return "[" + strings.Join(msgs, ", ") + "]"
+	}
+
+	return m.Summary + " errors: [" + strings.Join(msgs, ", ") + "]"
+}
+
+func (m *MultiError) Is(target error) bool {
+	for _, err := range m.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MultiError) As(target interface{}) bool {
+	for _, err := range m.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
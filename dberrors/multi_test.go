@@ -0,0 +1,79 @@
+package dberrors
+
+import (
+	"errors"
+	"github.com/aws/smithy-go"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	if err := Join(nil, nil); err != nil {
+		t.Errorf("Join(nil, nil) = %v, want nil", err)
+	}
+
+	first, second := errors.New("first"), errors.New("second")
+	err := Join(first, nil, second)
+	if want := "[first, second]"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	summarized := &MultiError{Summary: "bulk write failed", Errors: []error{first}}
+	if want := "bulk write failed errors: [first]"; summarized.Error() != want {
+		t.Errorf("Error() = %q, want %q", summarized.Error(), want)
+	}
+}
+
+func TestMultiErrorIs(t *testing.T) {
+	cause := errors.New("boom")
+	multi := &MultiError{Errors: []error{
+		cause,
+		New("BatchWriteItem", "orders", "", apiError("ProvisionedThroughputExceededException")),
+		NewKind(ErrMarshal, "BatchWriteItem", "orders", "", errors.New("bad item")),
+	}}
+
+	tests := []struct {
+		name   string
+		target error
+		want   bool
+	}{
+		{name: "plain member", target: cause, want: true},
+		{name: "code of a member", target: ErrThrottled, want: true},
+		{name: "kind of a member", target: ErrMarshal, want: true},
+		{name: "absent sentinel", target: ErrValidation, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(multi, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	wrapped := &Error{Op: "BatchWriteItem", Kind: ErrUnprocessed, Err: multi}
+	if !errors.Is(wrapped, ErrUnprocessed) || !errors.Is(wrapped, ErrThrottled) {
+		t.Error("errors.Is() through a wrapping Error did not reach the kind and the members")
+	}
+}
+
+func TestMultiErrorAs(t *testing.T) {
+	multi := &MultiError{Errors: []error{
+		errors.New("boom"),
+		New("BatchWriteItem", "orders", "", apiError("ValidationException")),
+	}}
+
+	var dbErr *Error
+	if !errors.As(multi, &dbErr) || dbErr.Code != "ValidationException" {
+		t.Errorf("errors.As() *Error = %v", dbErr)
+	}
+
+	var apiErr smithy.APIError
+	if !errors.As(multi, &apiErr) || apiErr.ErrorCode() != "ValidationException" {
+		t.Errorf("errors.As() APIError = %v", apiErr)
+	}
+
+	var txErr *TransactionCanceledError
+	if errors.As(multi, &txErr) {
+		t.Errorf("errors.As() found a TransactionCanceledError in %v", multi)
+	}
+}
@@ -0,0 +1,75 @@
+package dberrors
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"strings"
+)
+
+type CancellationReason struct {
+	Index     int
+	Operation string
+	Code      string
+	Message   string
+}
+
+type TransactionCanceledError struct {
+	Reasons []CancellationReason
+	Err     error
+}
+
+func NewTransactionCanceledError(canceled *types.TransactionCanceledException, operations []string) *TransactionCanceledError {
+	txErr := &TransactionCanceledError{Err: canceled}
+
+	for i, reason := range canceled.CancellationReasons {
+		cancellationReason := CancellationReason{Index: i}
+		if i < len(operations) {
+			cancellationReason.Operation = operations[i]
+		}
+		if reason.Code != nil {
+			cancellationReason.Code = *reason.Code
+		}
+		if reason.Message != nil {
+			cancellationReason.Message = *reason.Message
+		}
+
+		txErr.Reasons = append(txErr.Reasons, cancellationReason)
+	}
+
+	return txErr
+}
+
+func (e *TransactionCanceledError) Error() string {
+	var arr []string
+	for _, reason := range e.Reasons {
+		if reason.Code == "" || reason.Code == "None" {
+			continue
+		}
+
+		msg := fmt.Sprintf("#%d %s: %s", reason.Index, reason.Operation, reason.Code)
+		if reason.Message != "" {
+			msg = fmt.Sprintf("%s (%s)", msg, reason.Message)
+		}
+		arr = append(arr, msg)
+	}
+
+	return "transaction canceled: [" + strings.Join(arr, ", ") + "]"
+}
+
+func (e *TransactionCanceledError) Unwrap() error {
+	return e.Err
+}
+
+func (e *TransactionCanceledError) Is(target error) bool {
+	if target == ErrTransactionCanceled {
+		return true
+	}
+
+	for _, reason := range e.Reasons {
+		if sentinel, ok := codeToSentinel[reason.Code]; ok && sentinel == target {
+			return true
+		}
+	}
+
+	return false
+}
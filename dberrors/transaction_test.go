@@ -0,0 +1,75 @@
+package dberrors
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"reflect"
+	"testing"
+)
+
+func TestNewTransactionCanceledError(t *testing.T) {
+	canceled := &types.TransactionCanceledException{
+		Message: aws.String("Transaction cancelled"),
+		CancellationReasons: []types.CancellationReason{
+			{Code: aws.String("None")},
+			{Code: aws.String("ConditionalCheckFailed"), Message: aws.String("The conditional request failed")},
+			{Code: aws.String("TransactionConflict")},
+		},
+	}
+
+	err := NewTransactionCanceledError(canceled, []string{"PUT orders a", "UPDATE orders b"})
+
+	want := []CancellationReason{
+		{Index: 0, Operation: "PUT orders a", Code: "None"},
+		{Index: 1, Operation: "UPDATE orders b", Code: "ConditionalCheckFailed", Message: "The conditional request failed"},
+		{Index: 2, Code: "TransactionConflict"},
+	}
+	if !reflect.DeepEqual(err.Reasons, want) {
+		t.Errorf("Reasons = %+v, want %+v", err.Reasons, want)
+	}
+
+	wantMessage := "transaction canceled: [#1 UPDATE orders b: ConditionalCheckFailed (The conditional request failed), #2 : TransactionConflict]"
+	if err.Error() != wantMessage {
+		t.Errorf("Error() = %q, want %q", err.Error(), wantMessage)
+	}
+
+	var unwrapped *types.TransactionCanceledException
+	if !errors.As(err, &unwrapped) || unwrapped != canceled {
+		t.Errorf("errors.As() = %v, want the original exception", unwrapped)
+	}
+}
+
+func TestTransactionCanceledErrorIs(t *testing.T) {
+	reasons := func(codes ...string) *TransactionCanceledError {
+		canceled := &types.TransactionCanceledException{}
+		for _, code := range codes {
+			canceled.CancellationReasons = append(canceled.CancellationReasons, types.CancellationReason{Code: aws.String(code)})
+		}
+		return NewTransactionCanceledError(canceled, nil)
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{name: "always canceled", err: reasons(), target: ErrTransactionCanceled, want: true},
+		{name: "condition reason", err: reasons("None", "ConditionalCheckFailed"), target: ErrConditionalCheckFailed, want: true},
+		{name: "conflict reason", err: reasons("TransactionConflict"), target: ErrTransactionConflict, want: true},
+		{name: "throttling reason", err: reasons("ThrottlingError"), target: ErrThrottled, want: true},
+		{name: "validation reason", err: reasons("ValidationError"), target: ErrValidation, want: true},
+		{name: "absent reason", err: reasons("None", "ConditionalCheckFailed"), target: ErrTransactionConflict, want: false},
+		{name: "wrapped in Error", err: New("TransactWriteItems", "", "", reasons("ConditionalCheckFailed")), target: ErrConditionalCheckFailed, want: true},
+		{name: "code of the wrapping Error", err: New("TransactWriteItems", "", "", &types.TransactionCanceledException{}), target: ErrTransactionCanceled, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package dynamodbtest
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/table"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type number string
+
+type set struct {
+	Type   string
+	Values []string
+}
+
+func AssertItemExists(t testing.TB, tbl table.Table, key table.PrimaryKey) bool {
+	t.Helper()
+
+	if getRawItem(t, tbl, key) == nil {
+		t.Errorf("dynamodbtest: expected item %s to exist in table %s", table.FormatPrimaryKey(key, &tbl.KeySchema), tbl.TableName)
+		return false
+	}
+	return true
+}
+
+func AssertItemNotExists(t testing.TB, tbl table.Table, key table.PrimaryKey) bool {
+	t.Helper()
+
+	if item := getRawItem(t, tbl, key); item != nil {
+		t.Errorf("dynamodbtest: expected item %s not to exist in table %s, found: %v", table.FormatPrimaryKey(key, &tbl.KeySchema), tbl.TableName, normalizeItem(item))
+		return false
+	}
+	return true
+}
+
+func AssertItem(t testing.TB, tbl table.Table, key table.PrimaryKey, expected interface{}) bool {
+	t.Helper()
+
+	formattedKey := table.FormatPrimaryKey(key, &tbl.KeySchema)
+	actual := getRawItem(t, tbl, key)
+	if actual == nil {
+		t.Errorf("dynamodbtest: expected item %s to exist in table %s", formattedKey, tbl.TableName)
+		return false
+	}
+
+	want := normalizeItem(marshalExpected(t, expected))
+	got := normalizeItem(actual)
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("dynamodbtest: item %s in table %s does not match\n  expected: %v\n  actual:   %v", formattedKey, tbl.TableName, want, got)
+		return false
+	}
+	return true
+}
+
+func AssertTableContains(t testing.TB, tbl table.Table, expected ...interface{}) bool {
+	t.Helper()
+
+	want := make(map[string]map[string]interface{})
+	for _, item := range expected {
+		normalized := normalizeItem(marshalExpected(t, item))
+		want[itemIdentity(normalized, tbl.KeySchema)] = normalized
+	}
+
+	got := make(map[string]map[string]interface{})
+	for _, item := range scanRawItems(t, tbl) {
+		normalized := normalizeItem(item)
+		got[itemIdentity(normalized, tbl.KeySchema)] = normalized
+	}
+
+	var problems []string
+	for _, id := range sortedKeys(want) {
+		actual, ok := got[id]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("  missing %s: %v", id, want[id]))
+		case !reflect.DeepEqual(want[id], actual):
+			problems = append(problems, fmt.Sprintf("  mismatch %s\n    expected: %v\n    actual:   %v", id, want[id], actual))
+		}
+	}
+
+	for _, id := range sortedKeys(got) {
+		if _, ok := want[id]; !ok {
+			problems = append(problems, fmt.Sprintf("  unexpected %s: %v", id, got[id]))
+		}
+	}
+
+	if len(problems) > 0 {
+		t.Errorf("dynamodbtest: table %s does not contain exactly the expected %d items:\n%s", tbl.TableName, len(expected), strings.Join(problems, "\n"))
+		return false
+	}
+	return true
+}
+
+func getRawItem(t testing.TB, tbl table.Table, key table.PrimaryKey) map[string]types.AttributeValue {
+	t.Helper()
+
+	wrapper, err := tbl.GetClient()
+	if err != nil {
+		t.Fatalf("dynamodbtest: %v", err)
+	}
+
+	avs, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		t.Fatalf("dynamodbtest: failed to marshal key %s: %v", table.FormatPrimaryKey(key, &tbl.KeySchema), err)
+	}
+
+	out, err := wrapper.AWSClient.GetItem(context.Background(), &ddb.GetItemInput{
+		TableName:      aws.String(tbl.TableName),
+		Key:            avs,
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("dynamodbtest: failed to get item %s from table %s: %v", table.FormatPrimaryKey(key, &tbl.KeySchema), tbl.TableName, err)
+	}
+
+	return out.Item
+}
+
+func scanRawItems(t testing.TB, tbl table.Table) []map[string]types.AttributeValue {
+	t.Helper()
+
+	wrapper, err := tbl.GetClient()
+	if err != nil {
+		t.Fatalf("dynamodbtest: %v", err)
+	}
+
+	var items []map[string]types.AttributeValue
+	input := &ddb.ScanInput{
+		TableName:      aws.String(tbl.TableName),
+		ConsistentRead: aws.Bool(true),
+	}
+
+	for {
+		out, err := wrapper.AWSClient.Scan(context.Background(), input)
+		if err != nil {
+			t.Fatalf("dynamodbtest: failed to scan table %s: %v", tbl.TableName, err)
+		}
+
+		items = append(items, out.Items...)
+		if len(out.LastEvaluatedKey) == 0 {
+			return items
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+func marshalExpected(t testing.TB, expected interface{}) map[string]types.AttributeValue {
+	t.Helper()
+
+	if avs, ok := expected.(map[string]types.AttributeValue); ok {
+		return avs
+	}
+
+	avs, err := attributevalue.MarshalMap(expected)
+	if err != nil {
+		t.Fatalf("dynamodbtest: failed to marshal expected item: %v", err)
+	}
+	return avs
+}
+
+func itemIdentity(item map[string]interface{}, keySchema table.KeySchema) string {
+	if keySchema.SkName == nil {
+		return fmt.Sprintf("[%s: %v]", keySchema.PkName, item[keySchema.PkName])
+	}
+	return fmt.Sprintf("[%s: %v, %s: %v]", keySchema.PkName, item[keySchema.PkName], *keySchema.SkName, item[*keySchema.SkName])
+}
+
+func normalizeItem(item map[string]types.AttributeValue) map[string]interface{} {
+	normalized := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		normalized[k] = normalize(v)
+	}
+	return normalized
+}
+
+func normalize(av types.AttributeValue) interface{} {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return v.Value
+	case *types.AttributeValueMemberN:
+		return canonicalNumber(v.Value)
+	case *types.AttributeValueMemberB:
+		return base64.StdEncoding.EncodeToString(v.Value)
+	case *types.AttributeValueMemberBOOL:
+		return v.Value
+	case *types.AttributeValueMemberNULL:
+		return nil
+	case *types.AttributeValueMemberM:
+		return normalizeItem(v.Value)
+	case *types.AttributeValueMemberL:
+		l := make([]interface{}, 0, len(v.Value))
+		for _, e := range v.Value {
+			l = append(l, normalize(e))
+		}
+		return l
+	case *types.AttributeValueMemberSS:
+		return newSet("SS", v.Value)
+	case *types.AttributeValueMemberNS:
+		values := make([]string, 0, len(v.Value))
+		for _, n := range v.Value {
+			values = append(values, string(canonicalNumber(n)))
+		}
+		return newSet("NS", values)
+	case *types.AttributeValueMemberBS:
+		values := make([]string, 0, len(v.Value))
+		for _, b := range v.Value {
+			values = append(values, base64.StdEncoding.EncodeToString(b))
+		}
+		return newSet("BS", values)
+	default:
+		return av
+	}
+}
+
+func canonicalNumber(s string) number {
+	f, _, err := big.ParseFloat(s, 10, 256, big.ToNearestEven)
+	if err != nil {
+		return number(s)
+	}
+	return number(f.Text('g', -1))
+}
+
+func newSet(setType string, values []string) set {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return set{Type: setType, Values: sorted}
+}
+
+func sortedKeys(m map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
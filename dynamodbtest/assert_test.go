@@ -0,0 +1,217 @@
+package dynamodbtest
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/table"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type assertKey struct {
+	Pk string `dynamodbav:"pk"`
+}
+
+func (k assertKey) PK() interface{} { return k.Pk }
+func (k assertKey) SK() interface{} { return nil }
+
+type assertItem struct {
+	Pk    string    `dynamodbav:"pk"`
+	Count float64   `dynamodbav:"count"`
+	Tags  []string  `dynamodbav:"tags,stringset,omitempty"`
+	Nums  []float64 `dynamodbav:"nums,numberset,omitempty"`
+}
+
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func newAssertTable(t *testing.T) table.Table {
+	t.Helper()
+
+	tbl := NewTable(t, table.Table{TableName: "assert", KeySchema: table.KeySchema{PkName: "pk"}})
+	putRawItem(t, tbl, map[string]types.AttributeValue{
+		"pk":    &types.AttributeValueMemberS{Value: "a"},
+		"count": &types.AttributeValueMemberN{Value: "1.0"},
+		"tags":  &types.AttributeValueMemberSS{Value: []string{"y", "x"}},
+		"nums":  &types.AttributeValueMemberNS{Value: []string{"10", "2.50"}},
+	})
+	putRawItem(t, tbl, map[string]types.AttributeValue{
+		"pk":    &types.AttributeValueMemberS{Value: "b"},
+		"count": &types.AttributeValueMemberN{Value: "2"},
+	})
+	return tbl
+}
+
+func putRawItem(t *testing.T, tbl table.Table, item map[string]types.AttributeValue) {
+	t.Helper()
+
+	_, err := tbl.Client.AWSClient.PutItem(context.Background(), &ddb.PutItemInput{
+		TableName: aws.String(tbl.TableName),
+		Item:      item,
+	})
+	if err != nil {
+		t.Fatalf("PutItem() error = %v", err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		a    types.AttributeValue
+		b    types.AttributeValue
+		same bool
+	}{
+		{name: "integer and decimal", a: &types.AttributeValueMemberN{Value: "1"}, b: &types.AttributeValueMemberN{Value: "1.0"}, same: true},
+		{name: "exponent", a: &types.AttributeValueMemberN{Value: "100"}, b: &types.AttributeValueMemberN{Value: "1e2"}, same: true},
+		{name: "trailing zeros", a: &types.AttributeValueMemberN{Value: "2.5"}, b: &types.AttributeValueMemberN{Value: "2.500"}, same: true},
+		{name: "different numbers", a: &types.AttributeValueMemberN{Value: "1"}, b: &types.AttributeValueMemberN{Value: "1.01"}, same: false},
+		{name: "number and string", a: &types.AttributeValueMemberN{Value: "1"}, b: &types.AttributeValueMemberS{Value: "1"}, same: false},
+		{name: "string set order", a: &types.AttributeValueMemberSS{Value: []string{"a", "b"}}, b: &types.AttributeValueMemberSS{Value: []string{"b", "a"}}, same: true},
+		{name: "number set order and format", a: &types.AttributeValueMemberNS{Value: []string{"1", "20"}}, b: &types.AttributeValueMemberNS{Value: []string{"2e1", "1.0"}}, same: true},
+		{name: "binary set order", a: &types.AttributeValueMemberBS{Value: [][]byte{[]byte("a"), []byte("b")}}, b: &types.AttributeValueMemberBS{Value: [][]byte{[]byte("b"), []byte("a")}}, same: true},
+		{name: "string set and number set", a: &types.AttributeValueMemberSS{Value: []string{"1"}}, b: &types.AttributeValueMemberNS{Value: []string{"1"}}, same: false},
+		{name: "list order matters", a: &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberS{Value: "a"}, &types.AttributeValueMemberS{Value: "b"}}}, b: &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberS{Value: "b"}, &types.AttributeValueMemberS{Value: "a"}}}, same: false},
+		{
+			name: "nested numbers and sets",
+			a: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"n":    &types.AttributeValueMemberN{Value: "3"},
+				"list": &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberSS{Value: []string{"x", "y"}}}},
+			}},
+			b: &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"n":    &types.AttributeValueMemberN{Value: "3.00"},
+				"list": &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberSS{Value: []string{"y", "x"}}}},
+			}},
+			same: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := normalize(tt.a), normalize(tt.b)
+			if got := reflect.DeepEqual(a, b); got != tt.same {
+				t.Errorf("normalize() equal = %v, want %v (%#v vs %#v)", got, tt.same, a, b)
+			}
+		})
+	}
+}
+
+func TestAssertItem(t *testing.T) {
+	tbl := newAssertTable(t)
+
+	tests := []struct {
+		name     string
+		key      table.PrimaryKey
+		expected interface{}
+		wantErr  string
+	}{
+		{
+			name:     "equivalent numbers and sets",
+			key:      assertKey{Pk: "a"},
+			expected: assertItem{Pk: "a", Count: 1, Tags: []string{"x", "y"}, Nums: []float64{2.5, 10}},
+		},
+		{
+			name:     "different value",
+			key:      assertKey{Pk: "b"},
+			expected: assertItem{Pk: "b", Count: 3},
+			wantErr:  "does not match",
+		},
+		{
+			name:     "missing attribute",
+			key:      assertKey{Pk: "b"},
+			expected: map[string]interface{}{"pk": "b"},
+			wantErr:  "does not match",
+		},
+		{
+			name:     "missing item",
+			key:      assertKey{Pk: "c"},
+			expected: map[string]interface{}{"pk": "c"},
+			wantErr:  "to exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			ok := AssertItem(r, tbl, tt.key, tt.expected)
+			checkRecorded(t, r, ok, tt.wantErr)
+		})
+	}
+}
+
+func TestAssertTableContains(t *testing.T) {
+	tbl := newAssertTable(t)
+
+	a := map[string]types.AttributeValue{
+		"pk":    &types.AttributeValueMemberS{Value: "a"},
+		"count": &types.AttributeValueMemberN{Value: "1"},
+		"tags":  &types.AttributeValueMemberSS{Value: []string{"x", "y"}},
+		"nums":  &types.AttributeValueMemberNS{Value: []string{"10.0", "2.5"}},
+	}
+	b := assertItem{Pk: "b", Count: 2}
+
+	tests := []struct {
+		name     string
+		expected []interface{}
+		wantErr  string
+	}{
+		{name: "exact contents", expected: []interface{}{b, a}},
+		{name: "missing item", expected: []interface{}{a, b, map[string]interface{}{"pk": "c"}}, wantErr: "missing [pk: c]"},
+		{name: "unexpected item", expected: []interface{}{a}, wantErr: "unexpected [pk: b]"},
+		{name: "mismatched item", expected: []interface{}{a, assertItem{Pk: "b", Count: 20}}, wantErr: "mismatch [pk: b]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			ok := AssertTableContains(r, tbl, tt.expected...)
+			checkRecorded(t, r, ok, tt.wantErr)
+		})
+	}
+}
+
+func TestAssertItemExistence(t *testing.T) {
+	tbl := newAssertTable(t)
+	present := assertKey{Pk: "a"}
+	absent := assertKey{Pk: "c"}
+
+	r := &recorder{TB: t}
+	if !AssertItemExists(r, tbl, present) || !AssertItemNotExists(r, tbl, absent) || len(r.errors) > 0 {
+		t.Errorf("existence assertions failed unexpectedly: %v", r.errors)
+	}
+
+	r = &recorder{TB: t}
+	checkRecorded(t, r, AssertItemExists(r, tbl, absent), "to exist")
+
+	r = &recorder{TB: t}
+	checkRecorded(t, r, AssertItemNotExists(r, tbl, present), "not to exist")
+}
+
+func checkRecorded(t *testing.T, r *recorder, ok bool, wantErr string) {
+	t.Helper()
+
+	if wantErr == "" {
+		if !ok || len(r.errors) > 0 {
+			t.Errorf("assertion failed unexpectedly: %v", r.errors)
+		}
+		return
+	}
+
+	if ok {
+		t.Errorf("assertion passed, want a failure containing %q", wantErr)
+	}
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], wantErr) {
+		t.Errorf("reported errors = %q, want one containing %q", r.errors, wantErr)
+	}
+}
@@ -0,0 +1,180 @@
+package dynamodbtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/client"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/memdb"
+	"github.com/jhmachado/dynamodb/table"
+	"github.com/jhmachado/dynamodb/util"
+	"regexp"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const defaultRegion = "us-east-1"
+const maxTableCreationWait = 2 * time.Minute
+
+var tableCounter int64
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+type Index struct {
+	Name             string
+	KeySchema        table.KeySchema
+	Local            bool
+	ProjectionType   types.ProjectionType
+	NonKeyAttributes []string
+}
+
+type Option func(o *options)
+
+type options struct {
+	api            client.DynamoDBAPI
+	endpoint       string
+	indexes        []Index
+	attributeTypes map[string]types.ScalarAttributeType
+}
+
+func WithIndexes(indexes ...Index) Option {
+	return func(o *options) {
+		o.indexes = append(o.indexes, indexes...)
+	}
+}
+
+func WithAttributeType(name string, attributeType types.ScalarAttributeType) Option {
+	return func(o *options) {
+		o.attributeTypes[name] = attributeType
+	}
+}
+
+func WithMemDB(db *memdb.DB) Option {
+	return func(o *options) {
+		o.api = db
+		o.endpoint = ""
+	}
+}
+
+func WithEndpoint(url string) Option {
+	return func(o *options) {
+		o.api = nil
+		o.endpoint = url
+	}
+}
+
+func WithEndpointFromEnv() Option {
+	return func(o *options) {
+		if _, url := util.GetDynamoDBServiceEnvs(); url != "" {
+			o.api = nil
+			o.endpoint = url
+		}
+	}
+}
+
+func NewTable(t testing.TB, base table.Table, opts ...Option) table.Table {
+	t.Helper()
+
+	o := &options{attributeTypes: make(map[string]types.ScalarAttributeType)}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	api := o.api
+	if api == nil && o.endpoint != "" {
+		api = newEndpointClient(o.endpoint)
+	}
+	if api == nil {
+		api = memdb.New()
+	}
+
+	created := base
+	created.TableName = uniqueTableName(t, base.TableName)
+	created.IndexName = nil
+	created.Client = client.NewWithAPI(api)
+
+	db := table.NewDB(created.Client)
+	definition := buildTableDefinition(created.TableName, base.KeySchema, o)
+
+	ctx := context.Background()
+	if _, err := db.CreateTable(ctx, definition); err != nil {
+		t.Fatalf("dynamodbtest: failed to create table %s: %v", created.TableName, err)
+	}
+
+	t.Cleanup(func() {
+		if err := db.DeleteTable(context.Background(), created.TableName); err != nil && !errors.Is(err, dberrors.ErrTableNotFound) {
+			t.Errorf("dynamodbtest: failed to delete table %s: %v", created.TableName, err)
+		}
+	})
+
+	if err := db.WaitUntilTableActive(ctx, created.TableName, maxTableCreationWait); err != nil {
+		t.Fatalf("dynamodbtest: table %s did not become active: %v", created.TableName, err)
+	}
+
+	return created
+}
+
+func IndexTable(t table.Table, indexName string, keySchema table.KeySchema) table.Table {
+	t.IndexName = &indexName
+	t.KeySchema = keySchema
+	return t
+}
+
+func newEndpointClient(url string) *ddb.Client {
+	region, _ := util.GetDynamoDBServiceEnvs()
+	if region == "" {
+		region = defaultRegion
+	}
+
+	return ddb.NewFromConfig(aws.Config{
+		Region:      region,
+		Credentials: credentials.NewStaticCredentialsProvider("TestKey", "TestSecretKey", ""),
+	}, func(o *ddb.Options) {
+		o.EndpointResolver = ddb.EndpointResolverFromURL(url)
+	})
+}
+
+func uniqueTableName(t testing.TB, base string) string {
+	testName := invalidNameChars.ReplaceAllString(t.Name(), "-")
+	if len(testName) > 100 {
+		testName = testName[:100]
+	}
+
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 36) + strconv.FormatInt(atomic.AddInt64(&tableCounter, 1), 36)
+	return fmt.Sprintf("%s-%s-%s", base, testName, suffix)
+}
+
+func buildTableDefinition(tableName string, keySchema table.KeySchema, o *options) table.TableDefinition {
+	definition := table.TableDefinition{
+		TableName:      tableName,
+		KeySchema:      keySchema,
+		AttributeTypes: make(map[string]table.AttributeType, len(o.attributeTypes)),
+	}
+
+	for name, attributeType := range o.attributeTypes {
+		definition.AttributeTypes[name] = table.AttributeType(attributeType)
+	}
+
+	for _, index := range o.indexes {
+		indexDefinition := table.IndexDefinition{
+			IndexName:        index.Name,
+			KeySchema:        index.KeySchema,
+			Projection:       table.ProjectionType(index.ProjectionType),
+			NonKeyAttributes: index.NonKeyAttributes,
+		}
+
+		if index.Local {
+			definition.LocalIndexes = append(definition.LocalIndexes, indexDefinition)
+			continue
+		}
+		definition.GlobalIndexes = append(definition.GlobalIndexes, indexDefinition)
+	}
+
+	return definition
+}
@@ -0,0 +1,57 @@
+package dynamodbtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/jhmachado/dynamodb/table"
+	"os"
+	"testing"
+)
+
+func Seed(t testing.TB, tbl table.Table, items ...interface{}) {
+	t.Helper()
+
+	if len(items) == 0 {
+		return
+	}
+
+	report, err := table.Write(context.Background(), tbl, items, table.InputOptions{})
+	if err != nil {
+		t.Fatalf("dynamodbtest: failed to seed table %s: %v", tbl.TableName, err)
+	}
+
+	if len(report.UnwrittenItems) > 0 {
+		t.Fatalf("dynamodbtest: failed to seed table %s: %d items were not written", tbl.TableName, len(report.UnwrittenItems))
+	}
+}
+
+func SeedJSON(t testing.TB, tbl table.Table, data []byte) {
+	t.Helper()
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var items []map[string]interface{}
+	if err := decoder.Decode(&items); err != nil {
+		t.Fatalf("dynamodbtest: invalid JSON fixture for table %s: %v", tbl.TableName, err)
+	}
+
+	values := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		values = append(values, item)
+	}
+
+	Seed(t, tbl, values...)
+}
+
+func SeedJSONFile(t testing.TB, tbl table.Table, path string) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("dynamodbtest: failed to read fixture %s: %v", path, err)
+	}
+
+	SeedJSON(t, tbl, data)
+}
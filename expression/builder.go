@@ -0,0 +1,107 @@
+package expression
+
+import (
+	"strconv"
+	"strings"
+)
+
+type Builder struct {
+	names          map[string]string
+	values         map[string]interface{}
+	nameToToken    map[string]string
+	existingNames  map[string]string
+	existingValues map[string]interface{}
+	counter        int
+	partiQL        bool
+	parameters     []interface{}
+}
+
+func NewBuilder(existingNames map[string]string, existingValues map[string]interface{}) *Builder {
+	nameToToken := make(map[string]string)
+	for token, name := range existingNames {
+		nameToToken[name] = token
+	}
+
+	return &Builder{
+		names:          make(map[string]string),
+		values:         make(map[string]interface{}),
+		nameToToken:    nameToToken,
+		existingNames:  existingNames,
+		existingValues: existingValues,
+	}
+}
+
+func NewPartiQLBuilder() *Builder {
+	return &Builder{partiQL: true}
+}
+
+func (b *Builder) Condition(condition Condition) (string, error) {
+	if condition == nil {
+		return "", errEmptyCondition
+	}
+	return condition.build(b)
+}
+
+func (b *Builder) Names() map[string]string {
+	return b.names
+}
+
+func (b *Builder) Values() map[string]interface{} {
+	return b.values
+}
+
+func (b *Builder) Parameters() []interface{} {
+	return b.parameters
+}
+
+func (b *Builder) Operand(operand Operand) (string, error) {
+	return buildOperand(b, operand)
+}
+
+func (b *Builder) nameToken(name string) string {
+	if b.partiQL {
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	}
+
+	if token, ok := b.nameToToken[name]; ok {
+		return token
+	}
+
+	for {
+		token := "#n" + strconv.Itoa(b.counter)
+		b.counter++
+
+		if _, ok := b.existingNames[token]; ok {
+			continue
+		}
+		if _, ok := b.names[token]; ok {
+			continue
+		}
+
+		b.names[token] = name
+		b.nameToToken[name] = token
+		return token
+	}
+}
+
+func (b *Builder) valueToken(value interface{}) string {
+	if b.partiQL {
+		b.parameters = append(b.parameters, value)
+		return "?"
+	}
+
+	for {
+		token := ":v" + strconv.Itoa(b.counter)
+		b.counter++
+
+		if _, ok := b.existingValues[token]; ok {
+			continue
+		}
+		if _, ok := b.values[token]; ok {
+			continue
+		}
+
+		b.values[token] = value
+		return token
+	}
+}
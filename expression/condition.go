@@ -0,0 +1,236 @@
+package expression
+
+import (
+	"errors"
+	"strings"
+)
+
+var errEmptyCondition = errors.New("condition is empty")
+
+type Condition interface {
+	build(b *Builder) (string, error)
+}
+
+type comparison struct {
+	operator string
+	left     Operand
+	right    Operand
+}
+
+type between struct {
+	operand Operand
+	lower   Operand
+	upper   Operand
+}
+
+type in struct {
+	operand Operand
+	values  []Operand
+}
+
+type function struct {
+	name string
+	args []Operand
+}
+
+type logical struct {
+	operator   string
+	conditions []Condition
+}
+
+type not struct {
+	condition Condition
+}
+
+func Equal(left Operand, right interface{}) Condition {
+	return comparison{operator: "=", left: left, right: toOperand(right)}
+}
+
+func NotEqual(left Operand, right interface{}) Condition {
+	return comparison{operator: "<>", left: left, right: toOperand(right)}
+}
+
+func LessThan(left Operand, right interface{}) Condition {
+	return comparison{operator: "<", left: left, right: toOperand(right)}
+}
+
+func LessThanEqual(left Operand, right interface{}) Condition {
+	return comparison{operator: "<=", left: left, right: toOperand(right)}
+}
+
+func GreaterThan(left Operand, right interface{}) Condition {
+	return comparison{operator: ">", left: left, right: toOperand(right)}
+}
+
+func GreaterThanEqual(left Operand, right interface{}) Condition {
+	return comparison{operator: ">=", left: left, right: toOperand(right)}
+}
+
+func Between(operand Operand, lower, upper interface{}) Condition {
+	return between{operand: operand, lower: toOperand(lower), upper: toOperand(upper)}
+}
+
+func In(operand Operand, values ...interface{}) Condition {
+	operands := make([]Operand, 0, len(values))
+	for _, value := range values {
+		operands = append(operands, toOperand(value))
+	}
+	return in{operand: operand, values: operands}
+}
+
+func BeginsWith(name NameOperand, prefix string) Condition {
+	return function{name: "begins_with", args: []Operand{name, Value(prefix)}}
+}
+
+func Contains(name NameOperand, value interface{}) Condition {
+	return function{name: "contains", args: []Operand{name, toOperand(value)}}
+}
+
+func AttributeExists(name NameOperand) Condition {
+	return function{name: "attribute_exists", args: []Operand{name}}
+}
+
+func AttributeNotExists(name NameOperand) Condition {
+	return function{name: "attribute_not_exists", args: []Operand{name}}
+}
+
+func AttributeType(name NameOperand, attributeType string) Condition {
+	return function{name: "attribute_type", args: []Operand{name, Value(attributeType)}}
+}
+
+func And(conditions ...Condition) Condition {
+	return logical{operator: "AND", conditions: conditions}
+}
+
+func Or(conditions ...Condition) Condition {
+	return logical{operator: "OR", conditions: conditions}
+}
+
+func Not(condition Condition) Condition {
+	return not{condition: condition}
+}
+
+func (c comparison) build(b *Builder) (string, error) {
+	left, err := buildOperand(b, c.left)
+	if err != nil {
+		return "", err
+	}
+
+	right, err := buildOperand(b, c.right)
+	if err != nil {
+		return "", err
+	}
+
+	return left + " " + c.operator + " " + right, nil
+}
+
+func (c between) build(b *Builder) (string, error) {
+	operands, err := buildOperands(b, c.operand, c.lower, c.upper)
+	if err != nil {
+		return "", err
+	}
+
+	return operands[0] + " BETWEEN " + operands[1] + " AND " + operands[2], nil
+}
+
+func (c in) build(b *Builder) (string, error) {
+	if len(c.values) == 0 {
+		return "", errors.New("IN requires at least one value")
+	}
+
+	if len(c.values) > 100 {
+		return "", errors.New("IN supports at most 100 values")
+	}
+
+	operands, err := buildOperands(b, append([]Operand{c.operand}, c.values...)...)
+	if err != nil {
+		return "", err
+	}
+
+	return operands[0] + " IN (" + strings.Join(operands[1:], ", ") + ")", nil
+}
+
+func (c function) build(b *Builder) (string, error) {
+	args, err := buildOperands(b, c.args...)
+	if err != nil {
+		return "", err
+	}
+
+	if b.partiQL {
+		switch c.name {
+		case "attribute_exists":
+			return args[0] + " IS NOT MISSING", nil
+		case "attribute_not_exists":
+			return args[0] + " IS MISSING", nil
+		}
+	}
+
+	return c.name + "(" + strings.Join(args, ", ") + ")", nil
+}
+
+func (c logical) build(b *Builder) (string, error) {
+	if len(c.conditions) == 0 {
+		return "", errors.New(c.operator + " requires at least one condition")
+	}
+
+	parts := make([]string, 0, len(c.conditions))
+	for _, condition := range c.conditions {
+		if condition == nil {
+			return "", errEmptyCondition
+		}
+
+		part, err := condition.build(b)
+		if err != nil {
+			return "", err
+		}
+
+		if isCompound(condition) && len(c.conditions) > 1 {
+			part = "(" + part + ")"
+		}
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, " "+c.operator+" "), nil
+}
+
+func (c not) build(b *Builder) (string, error) {
+	if c.condition == nil {
+		return "", errEmptyCondition
+	}
+
+	part, err := c.condition.build(b)
+	if err != nil {
+		return "", err
+	}
+
+	return "NOT (" + part + ")", nil
+}
+
+func isCompound(condition Condition) bool {
+	switch condition.(type) {
+	case logical, not:
+		return true
+	default:
+		return false
+	}
+}
+
+func buildOperand(b *Builder, operand Operand) (string, error) {
+	if operand == nil {
+		return "", errors.New("operand is empty")
+	}
+	return operand.operand(b)
+}
+
+func buildOperands(b *Builder, operands ...Operand) ([]string, error) {
+	built := make([]string, 0, len(operands))
+	for _, operand := range operands {
+		s, err := buildOperand(b, operand)
+		if err != nil {
+			return nil, err
+		}
+		built = append(built, s)
+	}
+
+	return built, nil
+}
@@ -0,0 +1,173 @@
+package expression
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConditionBuild(t *testing.T) {
+	tests := []struct {
+		name       string
+		condition  Condition
+		want       string
+		wantNames  map[string]string
+		wantValues map[string]interface{}
+	}{
+		{
+			name:       "equal",
+			condition:  Equal(Name("status"), "open"),
+			want:       "#n0 = :v1",
+			wantNames:  map[string]string{"#n0": "status"},
+			wantValues: map[string]interface{}{":v1": "open"},
+		},
+		{
+			name:       "nested path with list index",
+			condition:  Equal(Name("profile.addresses[1].city"), "Lisbon"),
+			want:       "#n0.#n1[1].#n2 = :v3",
+			wantNames:  map[string]string{"#n0": "profile", "#n1": "addresses", "#n2": "city"},
+			wantValues: map[string]interface{}{":v3": "Lisbon"},
+		},
+		{
+			name:       "repeated name reuses its token",
+			condition:  Or(Equal(Name("a.b"), 1), Equal(Name("b"), 2)),
+			want:       "#n0.#n1 = :v2 OR #n1 = :v3",
+			wantNames:  map[string]string{"#n0": "a", "#n1": "b"},
+			wantValues: map[string]interface{}{":v2": 1, ":v3": 2},
+		},
+		{
+			name:       "between",
+			condition:  Between(Name("score"), 1, 10),
+			want:       "#n0 BETWEEN :v1 AND :v2",
+			wantNames:  map[string]string{"#n0": "score"},
+			wantValues: map[string]interface{}{":v1": 1, ":v2": 10},
+		},
+		{
+			name:       "in",
+			condition:  In(Name("status"), "open", "closed"),
+			want:       "#n0 IN (:v1, :v2)",
+			wantNames:  map[string]string{"#n0": "status"},
+			wantValues: map[string]interface{}{":v1": "open", ":v2": "closed"},
+		},
+		{
+			name:       "size compared to a value",
+			condition:  GreaterThan(Size(Name("tags")), 2),
+			want:       "size(#n0) > :v1",
+			wantNames:  map[string]string{"#n0": "tags"},
+			wantValues: map[string]interface{}{":v1": 2},
+		},
+		{
+			name:       "compare two attributes",
+			condition:  LessThan(Name("spent"), Name("budget")),
+			want:       "#n0 < #n1",
+			wantNames:  map[string]string{"#n0": "spent", "#n1": "budget"},
+			wantValues: map[string]interface{}{},
+		},
+		{
+			name:       "functions",
+			condition:  And(BeginsWith(Name("name"), "al"), Contains(Name("tags"), "red"), AttributeExists(Name("pk"))),
+			want:       "begins_with(#n0, :v1) AND contains(#n2, :v3) AND attribute_exists(#n4)",
+			wantNames:  map[string]string{"#n0": "name", "#n2": "tags", "#n4": "pk"},
+			wantValues: map[string]interface{}{":v1": "al", ":v3": "red"},
+		},
+		{
+			name:       "nested logical conditions are parenthesised",
+			condition:  And(Or(Equal(Name("a"), 1), Equal(Name("b"), 2)), Not(AttributeNotExists(Name("c")))),
+			want:       "(#n0 = :v1 OR #n2 = :v3) AND (NOT (attribute_not_exists(#n4)))",
+			wantNames:  map[string]string{"#n0": "a", "#n2": "b", "#n4": "c"},
+			wantValues: map[string]interface{}{":v1": 1, ":v3": 2},
+		},
+		{
+			name:       "single condition is not parenthesised",
+			condition:  And(Or(Equal(Name("a"), 1))),
+			want:       "#n0 = :v1",
+			wantNames:  map[string]string{"#n0": "a"},
+			wantValues: map[string]interface{}{":v1": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBuilder(nil, nil)
+			got, err := b.Condition(tt.condition)
+			if err != nil {
+				t.Fatalf("Condition() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Condition() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(b.Names(), tt.wantNames) {
+				t.Errorf("Names() = %v, want %v", b.Names(), tt.wantNames)
+			}
+			if !reflect.DeepEqual(b.Values(), tt.wantValues) {
+				t.Errorf("Values() = %v, want %v", b.Values(), tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestConditionBuildErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition Condition
+	}{
+		{name: "nil condition"},
+		{name: "empty path", condition: Equal(Name(""), 1)},
+		{name: "empty path segment", condition: Equal(Name("a..b"), 1)},
+		{name: "non numeric list index", condition: Equal(Name("a[x]"), 1)},
+		{name: "unterminated list index", condition: Equal(Name("a[1"), 1)},
+		{name: "empty IN", condition: In(Name("a"))},
+		{name: "empty AND", condition: And()},
+		{name: "nil nested condition", condition: Or(Equal(Name("a"), 1), nil)},
+		{name: "nil operand", condition: Equal(nil, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewBuilder(nil, nil).Condition(tt.condition); err == nil {
+				t.Error("Condition() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestBuilderAvoidsExistingPlaceholders(t *testing.T) {
+	existingNames := map[string]string{"#n0": "legacy", "#n2": "other"}
+	existingValues := map[string]interface{}{":v1": "taken", ":v3": "taken"}
+
+	b := NewBuilder(existingNames, existingValues)
+	got, err := b.Condition(And(Equal(Name("status"), "open"), Equal(Name("legacy"), "x"), Equal(Name("owner"), "me")))
+	if err != nil {
+		t.Fatalf("Condition() error = %v", err)
+	}
+
+	if want := "#n1 = :v2 AND #n0 = :v4 AND #n5 = :v6"; got != want {
+		t.Errorf("Condition() = %q, want %q", got, want)
+	}
+
+	for token := range b.Names() {
+		if _, ok := existingNames[token]; ok {
+			t.Errorf("name token %s collides with an existing name", token)
+		}
+	}
+	for token := range b.Values() {
+		if _, ok := existingValues[token]; ok {
+			t.Errorf("value token %s collides with an existing value", token)
+		}
+	}
+}
+
+func TestPartiQLBuilder(t *testing.T) {
+	b := NewPartiQLBuilder()
+	got, err := b.Condition(And(Equal(Name(`we"ird`), "x"), AttributeExists(Name("a")), AttributeNotExists(Name("b"))))
+	if err != nil {
+		t.Fatalf("Condition() error = %v", err)
+	}
+
+	if want := `"we""ird" = ? AND "a" IS NOT MISSING AND "b" IS MISSING`; got != want {
+		t.Errorf("Condition() = %q, want %q", got, want)
+	}
+	if want := []interface{}{"x"}; !reflect.DeepEqual(b.Parameters(), want) {
+		t.Errorf("Parameters() = %v, want %v", b.Parameters(), want)
+	}
+}
@@ -0,0 +1,110 @@
+package expression
+
+import (
+	"errors"
+	"strings"
+)
+
+type Operand interface {
+	operand(b *Builder) (string, error)
+}
+
+type NameOperand struct {
+	path string
+}
+
+type ValueOperand struct {
+	value interface{}
+}
+
+type SizeOperand struct {
+	name NameOperand
+}
+
+func Name(path string) NameOperand {
+	return NameOperand{path: path}
+}
+
+func Value(value interface{}) ValueOperand {
+	return ValueOperand{value: value}
+}
+
+func Size(name NameOperand) SizeOperand {
+	return SizeOperand{name: name}
+}
+
+func (n NameOperand) Size() SizeOperand {
+	return Size(n)
+}
+
+func (n NameOperand) operand(b *Builder) (string, error) {
+	if n.path == "" {
+		return "", errors.New("attribute path is empty")
+	}
+
+	segments := strings.Split(n.path, ".")
+	tokens := make([]string, 0, len(segments))
+
+	for _, segment := range segments {
+		name := segment
+		indexes := ""
+
+		if i := strings.Index(segment, "["); i >= 0 {
+			name = segment[:i]
+			indexes = segment[i:]
+			if err := validateIndexes(indexes); err != nil {
+				return "", errors.New("invalid attribute path, " + n.path + ": " + err.Error())
+			}
+		}
+
+		if name == "" {
+			return "", errors.New("invalid attribute path, " + n.path)
+		}
+
+		tokens = append(tokens, b.nameToken(name)+indexes)
+	}
+
+	return strings.Join(tokens, "."), nil
+}
+
+func (v ValueOperand) operand(b *Builder) (string, error) {
+	return b.valueToken(v.value), nil
+}
+
+func (s SizeOperand) operand(b *Builder) (string, error) {
+	path, err := s.name.operand(b)
+	if err != nil {
+		return "", err
+	}
+	return "size(" + path + ")", nil
+}
+
+func validateIndexes(indexes string) error {
+	for len(indexes) > 0 {
+		if indexes[0] != '[' {
+			return errors.New("unexpected character after list index")
+		}
+
+		end := strings.Index(indexes, "]")
+		if end < 2 {
+			return errors.New("malformed list index")
+		}
+
+		for _, c := range indexes[1:end] {
+			if c < '0' || c > '9' {
+				return errors.New("list index must be a number")
+			}
+		}
+
+		indexes = indexes[end+1:]
+	}
+
+	return nil
+}
+
+func toOperand(value interface{}) Operand {
+	if op, ok := value.(Operand); ok {
+		return op
+	}
+	return Value(value)
+}
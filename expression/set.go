@@ -0,0 +1,64 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"reflect"
+)
+
+type setValue struct {
+	av types.AttributeValue
+}
+
+func (s setValue) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return s.av, nil
+}
+
+func newSetValue(values interface{}) (setValue, error) {
+	v := reflect.ValueOf(values)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return setValue{}, errors.New("set values must be a slice")
+	}
+
+	if v.Len() == 0 {
+		return setValue{}, errors.New("set values must not be empty")
+	}
+
+	switch {
+	case v.Type().Elem().Kind() == reflect.String:
+		ss := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			ss = append(ss, v.Index(i).String())
+		}
+		return setValue{av: &types.AttributeValueMemberSS{Value: ss}}, nil
+
+	case v.Type().Elem().Kind() == reflect.Slice && v.Type().Elem().Elem().Kind() == reflect.Uint8:
+		bs := make([][]byte, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			bs = append(bs, v.Index(i).Bytes())
+		}
+		return setValue{av: &types.AttributeValueMemberBS{Value: bs}}, nil
+
+	case isNumberKind(v.Type().Elem().Kind()):
+		ns := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			ns = append(ns, fmt.Sprint(v.Index(i).Interface()))
+		}
+		return setValue{av: &types.AttributeValueMemberNS{Value: ns}}, nil
+
+	default:
+		return setValue{}, errors.New("set values must be strings, numbers or binary")
+	}
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
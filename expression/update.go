@@ -0,0 +1,166 @@
+package expression
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+const (
+	setClause    = "SET"
+	removeClause = "REMOVE"
+	addClause    = "ADD"
+	deleteClause = "DELETE"
+)
+
+var clauseOrder = []string{setClause, removeClause, addClause, deleteClause}
+
+type updateAction struct {
+	clause string
+	build  func(b *Builder) (string, error)
+}
+
+type Update struct {
+	actions []updateAction
+}
+
+func NewUpdate() Update {
+	return Update{}
+}
+
+func (u Update) Set(path string, value interface{}) Update {
+	return u.with(setClause, func(b *Builder) (string, error) {
+		operands, err := buildOperands(b, Name(path), toOperand(value))
+		if err != nil {
+			return "", err
+		}
+		return operands[0] + " = " + operands[1], nil
+	})
+}
+
+func (u Update) SetIfNotExists(path string, value interface{}) Update {
+	return u.with(setClause, func(b *Builder) (string, error) {
+		operands, err := buildOperands(b, Name(path), toOperand(value))
+		if err != nil {
+			return "", err
+		}
+		return operands[0] + " = if_not_exists(" + operands[0] + ", " + operands[1] + ")", nil
+	})
+}
+
+func (u Update) ListAppend(path string, values ...interface{}) Update {
+	return u.with(setClause, func(b *Builder) (string, error) {
+		list := listValues(values)
+		if len(list) == 0 {
+			return "", errors.New("list append requires at least one value")
+		}
+
+		operands, err := buildOperands(b, Name(path), Value([]interface{}{}), Value(list))
+		if err != nil {
+			return "", err
+		}
+		return operands[0] + " = list_append(if_not_exists(" + operands[0] + ", " + operands[1] + "), " + operands[2] + ")", nil
+	})
+}
+
+func (u Update) Increment(path string, by interface{}) Update {
+	return u.with(setClause, func(b *Builder) (string, error) {
+		operands, err := buildOperands(b, Name(path), Value(0), toOperand(by))
+		if err != nil {
+			return "", err
+		}
+		return operands[0] + " = if_not_exists(" + operands[0] + ", " + operands[1] + ") + " + operands[2], nil
+	})
+}
+
+func (u Update) Remove(path string) Update {
+	return u.with(removeClause, func(b *Builder) (string, error) {
+		return buildOperand(b, Name(path))
+	})
+}
+
+func (u Update) AddToSet(path string, values interface{}) Update {
+	return u.with(addClause, func(b *Builder) (string, error) {
+		return buildSetAction(b, path, values)
+	})
+}
+
+func (u Update) DeleteFromSet(path string, values interface{}) Update {
+	return u.with(deleteClause, func(b *Builder) (string, error) {
+		return buildSetAction(b, path, values)
+	})
+}
+
+func (u Update) IsEmpty() bool {
+	return len(u.actions) == 0
+}
+
+func (u Update) with(clause string, build func(b *Builder) (string, error)) Update {
+	actions := make([]updateAction, 0, len(u.actions)+1)
+	actions = append(actions, u.actions...)
+	actions = append(actions, updateAction{clause: clause, build: build})
+
+	return Update{actions: actions}
+}
+
+func (u Update) build(b *Builder) (string, error) {
+	if u.IsEmpty() {
+		return "", errors.New("update is empty")
+	}
+
+	byClause := make(map[string][]string)
+	for _, action := range u.actions {
+		part, err := action.build(b)
+		if err != nil {
+			return "", err
+		}
+		byClause[action.clause] = append(byClause[action.clause], part)
+	}
+
+	clauses := make([]string, 0, len(byClause))
+	for _, clause := range clauseOrder {
+		if parts, ok := byClause[clause]; ok {
+			clauses = append(clauses, clause+" "+strings.Join(parts, ", "))
+		}
+	}
+
+	return strings.Join(clauses, " "), nil
+}
+
+func (b *Builder) Update(update Update) (string, error) {
+	return update.build(b)
+}
+
+func listValues(values []interface{}) []interface{} {
+	if len(values) != 1 {
+		return values
+	}
+
+	v := reflect.ValueOf(values[0])
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return values
+	}
+	if v.Type().Elem().Kind() == reflect.Uint8 {
+		return values
+	}
+
+	list := make([]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		list = append(list, v.Index(i).Interface())
+	}
+	return list
+}
+
+func buildSetAction(b *Builder, path string, values interface{}) (string, error) {
+	set, err := newSetValue(values)
+	if err != nil {
+		return "", errors.New("invalid set value for " + path + ": " + err.Error())
+	}
+
+	operands, err := buildOperands(b, Name(path), Value(set))
+	if err != nil {
+		return "", err
+	}
+
+	return operands[0] + " " + operands[1], nil
+}
@@ -0,0 +1,187 @@
+package expression
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"reflect"
+	"testing"
+)
+
+func TestListAppendValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		update Update
+		want   []interface{}
+	}{
+		{
+			name:   "variadic values",
+			update: NewUpdate().ListAppend("tags", "y", "z"),
+			want:   []interface{}{"y", "z"},
+		},
+		{
+			name:   "single slice is flattened",
+			update: NewUpdate().ListAppend("tags", []string{"y", "z"}),
+			want:   []interface{}{"y", "z"},
+		},
+		{
+			name:   "single scalar",
+			update: NewUpdate().ListAppend("tags", "y"),
+			want:   []interface{}{"y"},
+		},
+		{
+			name:   "binary value is not flattened",
+			update: NewUpdate().ListAppend("blobs", []byte("ab")),
+			want:   []interface{}{[]byte("ab")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBuilder(nil, nil)
+			expr, err := b.Update(tt.update)
+			if err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			if want := "SET #n0 = list_append(if_not_exists(#n0, :v1), :v2)"; expr != want {
+				t.Errorf("Update() = %q, want %q", expr, want)
+			}
+
+			if got := b.Values()[":v2"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("appended values = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListAppendRequiresValues(t *testing.T) {
+	for _, update := range []Update{NewUpdate().ListAppend("tags"), NewUpdate().ListAppend("tags", []string{})} {
+		if _, err := NewBuilder(nil, nil).Update(update); err == nil {
+			t.Error("Update() error = nil, want error for empty list append")
+		}
+	}
+}
+
+func TestUpdateBuild(t *testing.T) {
+	tests := []struct {
+		name       string
+		update     Update
+		want       string
+		wantNames  map[string]string
+		wantValues map[string]interface{}
+	}{
+		{
+			name:       "set",
+			update:     NewUpdate().Set("name", "alpha"),
+			want:       "SET #n0 = :v1",
+			wantNames:  map[string]string{"#n0": "name"},
+			wantValues: map[string]interface{}{":v1": "alpha"},
+		},
+		{
+			name:       "set from another attribute",
+			update:     NewUpdate().Set("copy", Name("original")),
+			want:       "SET #n0 = #n1",
+			wantNames:  map[string]string{"#n0": "copy", "#n1": "original"},
+			wantValues: map[string]interface{}{},
+		},
+		{
+			name:       "set if not exists on a nested path",
+			update:     NewUpdate().SetIfNotExists("meta.created", 1),
+			want:       "SET #n0.#n1 = if_not_exists(#n0.#n1, :v2)",
+			wantNames:  map[string]string{"#n0": "meta", "#n1": "created"},
+			wantValues: map[string]interface{}{":v2": 1},
+		},
+		{
+			name:       "increment",
+			update:     NewUpdate().Increment("count", 2),
+			want:       "SET #n0 = if_not_exists(#n0, :v1) + :v2",
+			wantNames:  map[string]string{"#n0": "count"},
+			wantValues: map[string]interface{}{":v1": 0, ":v2": 2},
+		},
+		{
+			name:       "remove list element",
+			update:     NewUpdate().Remove("tags[0]"),
+			want:       "REMOVE #n0[0]",
+			wantNames:  map[string]string{"#n0": "tags"},
+			wantValues: map[string]interface{}{},
+		},
+		{
+			name:       "clauses are grouped in a fixed order",
+			update:     NewUpdate().Remove("a").AddToSet("s", []string{"x"}).Set("b", 1).DeleteFromSet("s", []string{"y"}).Set("c", 2),
+			want:       "SET #n3 = :v4, #n6 = :v7 REMOVE #n0 ADD #n1 :v2 DELETE #n1 :v5",
+			wantNames:  map[string]string{"#n0": "a", "#n1": "s", "#n3": "b", "#n6": "c"},
+			wantValues: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBuilder(nil, nil)
+			got, err := b.Update(tt.update)
+			if err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Update() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(b.Names(), tt.wantNames) {
+				t.Errorf("Names() = %v, want %v", b.Names(), tt.wantNames)
+			}
+			if tt.wantValues != nil && !reflect.DeepEqual(b.Values(), tt.wantValues) {
+				t.Errorf("Values() = %v, want %v", b.Values(), tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestSetActionValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		values interface{}
+		want   types.AttributeValue
+	}{
+		{name: "strings", values: []string{"a", "b"}, want: &types.AttributeValueMemberSS{Value: []string{"a", "b"}}},
+		{name: "numbers", values: []int{1, 2}, want: &types.AttributeValueMemberNS{Value: []string{"1", "2"}}},
+		{name: "floats", values: []float64{1.5}, want: &types.AttributeValueMemberNS{Value: []string{"1.5"}}},
+		{name: "binary", values: [][]byte{[]byte("a")}, want: &types.AttributeValueMemberBS{Value: [][]byte{[]byte("a")}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBuilder(nil, nil)
+			if _, err := b.Update(NewUpdate().AddToSet("s", tt.values)); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			set, ok := b.Values()[":v1"].(setValue)
+			if !ok {
+				t.Fatalf("set value = %#v, want a setValue", b.Values()[":v1"])
+			}
+			if !reflect.DeepEqual(set.av, tt.want) {
+				t.Errorf("set value = %#v, want %#v", set.av, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateBuildErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		update Update
+	}{
+		{name: "empty update", update: NewUpdate()},
+		{name: "empty path", update: NewUpdate().Set("", 1)},
+		{name: "invalid list index", update: NewUpdate().Remove("tags[a]")},
+		{name: "set from a scalar", update: NewUpdate().AddToSet("s", "a")},
+		{name: "empty set", update: NewUpdate().DeleteFromSet("s", []string{})},
+		{name: "set of maps", update: NewUpdate().AddToSet("s", []map[string]string{{"a": "b"}})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewBuilder(nil, nil).Update(tt.update); err == nil {
+				t.Error("Update() error = nil, want an error")
+			}
+		})
+	}
+}
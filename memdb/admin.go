@@ -0,0 +1,485 @@
+package memdb
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"sort"
+	"time"
+)
+
+func (db *DB) CreateTable(ctx context.Context, in *ddb.CreateTableInput, optFns ...func(*ddb.Options)) (*ddb.CreateTableOutput, error) {
+	if in.TableName == nil || *in.TableName == "" {
+		return nil, validationError("1 validation error detected: Value null at 'tableName' failed to satisfy constraint: Member must not be null")
+	}
+
+	t, err := newTable(in)
+	if err != nil {
+		return nil, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, ok := db.tables[t.name]; ok {
+		return nil, resourceInUse(t.name)
+	}
+	db.tables[t.name] = t
+
+	return &ddb.CreateTableOutput{TableDescription: t.describe()}, nil
+}
+
+func (db *DB) DescribeTable(ctx context.Context, in *ddb.DescribeTableInput, optFns ...func(*ddb.Options)) (*ddb.DescribeTableOutput, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(in.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ddb.DescribeTableOutput{Table: t.describe()}, nil
+}
+
+func (db *DB) DeleteTable(ctx context.Context, in *ddb.DeleteTableInput, optFns ...func(*ddb.Options)) (*ddb.DeleteTableOutput, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(in.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(db.tables, t.name)
+
+	description := t.describe()
+	description.TableStatus = types.TableStatusDeleting
+	return &ddb.DeleteTableOutput{TableDescription: description}, nil
+}
+
+func newTable(in *ddb.CreateTableInput) (*table, error) {
+	t := &table{
+		name:           *in.TableName,
+		attributeTypes: make(map[string]types.ScalarAttributeType),
+		indexes:        make(map[string]*index),
+		items:          make(map[string]map[string]types.AttributeValue),
+		billingMode:    in.BillingMode,
+		throughput:     in.ProvisionedThroughput,
+		streams:        in.StreamSpecification,
+		createdTime:    time.Now(),
+	}
+
+	if t.billingMode == "" {
+		t.billingMode = types.BillingModeProvisioned
+	}
+
+	for _, def := range in.AttributeDefinitions {
+		if def.AttributeName == nil {
+			return nil, validationError("One or more parameter values were invalid: attribute name is empty in AttributeDefinitions")
+		}
+		switch def.AttributeType {
+		case types.ScalarAttributeTypeS, types.ScalarAttributeTypeN, types.ScalarAttributeTypeB:
+		default:
+			return nil, validationError("One or more parameter values were invalid: invalid attribute type %q for attribute %s", def.AttributeType, *def.AttributeName)
+		}
+		if _, ok := t.attributeTypes[*def.AttributeName]; ok {
+			return nil, validationError("Cannot have two attributes with the same name. Attribute name: %s", *def.AttributeName)
+		}
+		t.attributeTypes[*def.AttributeName] = def.AttributeType
+	}
+
+	var err error
+	used := make(map[string]bool)
+
+	if t.hashKey, t.rangeKey, err = parseKeySchema(in.KeySchema, t.attributeTypes, used); err != nil {
+		return nil, err
+	}
+
+	if err := checkThroughput(t.billingMode, in.ProvisionedThroughput, "table"); err != nil {
+		return nil, err
+	}
+
+	for _, gsi := range in.GlobalSecondaryIndexes {
+		idx, err := newIndex(t, gsi.IndexName, gsi.KeySchema, gsi.Projection, true, used)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkThroughput(t.billingMode, gsi.ProvisionedThroughput, "index "+idx.name); err != nil {
+			return nil, err
+		}
+		idx.throughput = gsi.ProvisionedThroughput
+	}
+
+	for _, lsi := range in.LocalSecondaryIndexes {
+		idx, err := newIndex(t, lsi.IndexName, lsi.KeySchema, lsi.Projection, false, used)
+		if err != nil {
+			return nil, err
+		}
+		if t.rangeKey == "" {
+			return nil, validationError("One or more parameter values were invalid: Table KeySchema does not have a range key, which is required when specifying a LocalSecondaryIndex")
+		}
+		if idx.hashKey != t.hashKey || idx.rangeKey == "" {
+			return nil, validationError("One or more parameter values were invalid: Index KeySchema does not have the same leading hash key as table KeySchema for index: %s", idx.name)
+		}
+	}
+
+	if len(used) != len(t.attributeTypes) {
+		return nil, validationError("One or more parameter values were invalid: Number of attributes in KeySchema does not exactly match number of attributes defined in AttributeDefinitions")
+	}
+
+	return t, nil
+}
+
+func newIndex(t *table, name *string, keySchema []types.KeySchemaElement, projection *types.Projection, global bool, used map[string]bool) (*index, error) {
+	if name == nil || *name == "" {
+		return nil, validationError("One or more parameter values were invalid: index name is empty")
+	}
+
+	if _, ok := t.indexes[*name]; ok {
+		return nil, validationError("One or more parameter values were invalid: Duplicate index name: %s", *name)
+	}
+
+	if projection == nil || projection.ProjectionType == "" {
+		return nil, validationError("One or more parameter values were invalid: Projection is required for index: %s", *name)
+	}
+
+	switch projection.ProjectionType {
+	case types.ProjectionTypeAll, types.ProjectionTypeKeysOnly:
+		if len(projection.NonKeyAttributes) > 0 {
+			return nil, validationError("One or more parameter values were invalid: ProjectionType is %s, but NonKeyAttributes is specified", projection.ProjectionType)
+		}
+	case types.ProjectionTypeInclude:
+		if len(projection.NonKeyAttributes) == 0 {
+			return nil, validationError("One or more parameter values were invalid: ProjectionType is INCLUDE, but NonKeyAttributes is not specified")
+		}
+	default:
+		return nil, validationError("One or more parameter values were invalid: unknown ProjectionType %q", projection.ProjectionType)
+	}
+
+	hashKey, rangeKey, err := parseKeySchema(keySchema, t.attributeTypes, used)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &index{
+		name:        *name,
+		hashKey:     hashKey,
+		rangeKey:    rangeKey,
+		global:      global,
+		projection:  *projection,
+		createdTime: time.Now(),
+	}
+	t.indexes[idx.name] = idx
+
+	return idx, nil
+}
+
+func parseKeySchema(keySchema []types.KeySchemaElement, attributeTypes map[string]types.ScalarAttributeType, used map[string]bool) (string, string, error) {
+	if len(keySchema) == 0 || len(keySchema) > 2 {
+		return "", "", validationError("1 validation error detected: Value at 'keySchema' failed to satisfy constraint: Member must have length less than or equal to 2")
+	}
+
+	names := make([]string, 0, len(keySchema))
+	for i, element := range keySchema {
+		if element.AttributeName == nil {
+			return "", "", validationError("One or more parameter values were invalid: key attribute name is empty")
+		}
+
+		expected := types.KeyTypeHash
+		if i == 1 {
+			expected = types.KeyTypeRange
+		}
+		if element.KeyType != expected {
+			return "", "", validationError("Invalid KeySchema: The first KeySchemaElement is not a HASH key type")
+		}
+
+		if _, ok := attributeTypes[*element.AttributeName]; !ok {
+			return "", "", validationError("One or more parameter values were invalid: Some index key attributes are not defined in AttributeDefinitions. Keys: [%s]", *element.AttributeName)
+		}
+
+		used[*element.AttributeName] = true
+		names = append(names, *element.AttributeName)
+	}
+
+	if len(names) == 2 {
+		if names[0] == names[1] {
+			return "", "", validationError("Both the Hash Key and the Range Key element in the KeySchema have the same name")
+		}
+		return names[0], names[1], nil
+	}
+
+	return names[0], "", nil
+}
+
+func checkThroughput(billingMode types.BillingMode, throughput *types.ProvisionedThroughput, resource string) error {
+	if billingMode == types.BillingModePayPerRequest {
+		if throughput != nil {
+			return validationError("One or more parameter values were invalid: Neither ReadCapacityUnits nor WriteCapacityUnits can be specified when BillingMode is PAY_PER_REQUEST")
+		}
+		return nil
+	}
+
+	if throughput == nil || throughput.ReadCapacityUnits == nil || throughput.WriteCapacityUnits == nil {
+		return validationError("One or more parameter values were invalid: ReadCapacityUnits and WriteCapacityUnits must both be specified when BillingMode is PROVISIONED for %s", resource)
+	}
+
+	return nil
+}
+
+func (t *table) describe() *types.TableDescription {
+	names := make([]string, 0, len(t.attributeTypes))
+	for name := range t.attributeTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	definitions := make([]types.AttributeDefinition, 0, len(names))
+	for _, name := range names {
+		definitions = append(definitions, types.AttributeDefinition{
+			AttributeName: aws.String(name),
+			AttributeType: t.attributeTypes[name],
+		})
+	}
+
+	arn := "arn:aws:dynamodb:local:000000000000:table/" + t.name
+	description := &types.TableDescription{
+		TableName:             aws.String(t.name),
+		TableArn:              aws.String(arn),
+		TableId:               aws.String(t.name),
+		TableStatus:           types.TableStatusActive,
+		KeySchema:             keySchemaElements(t.hashKey, t.rangeKey),
+		AttributeDefinitions:  definitions,
+		CreationDateTime:      aws.Time(t.createdTime),
+		ItemCount:             aws.Int64(int64(len(t.items))),
+		TableSizeBytes:        aws.Int64(0),
+		BillingModeSummary:    &types.BillingModeSummary{BillingMode: t.billingMode},
+		ProvisionedThroughput: throughputDescription(t.throughput),
+		StreamSpecification:   t.streams,
+	}
+
+	if t.streams != nil && t.streams.StreamEnabled != nil && *t.streams.StreamEnabled {
+		label := t.createdTime.UTC().Format("2006-01-02T15:04:05.000")
+		description.LatestStreamLabel = aws.String(label)
+		description.LatestStreamArn = aws.String(arn + "/stream/" + label)
+	}
+
+	for _, idx := range t.sortedIndexes() {
+		projection := idx.projection
+		itemCount := aws.Int64(int64(len(t.view(idx))))
+		indexArn := aws.String(arn + "/index/" + idx.name)
+
+		if idx.global {
+			description.GlobalSecondaryIndexes = append(description.GlobalSecondaryIndexes, types.GlobalSecondaryIndexDescription{
+				IndexName:             aws.String(idx.name),
+				IndexArn:              indexArn,
+				IndexStatus:           types.IndexStatusActive,
+				KeySchema:             keySchemaElements(idx.hashKey, idx.rangeKey),
+				Projection:            &projection,
+				ItemCount:             itemCount,
+				IndexSizeBytes:        aws.Int64(0),
+				ProvisionedThroughput: throughputDescription(idx.throughput),
+			})
+			continue
+		}
+
+		description.LocalSecondaryIndexes = append(description.LocalSecondaryIndexes, types.LocalSecondaryIndexDescription{
+			IndexName:      aws.String(idx.name),
+			IndexArn:       indexArn,
+			KeySchema:      keySchemaElements(idx.hashKey, idx.rangeKey),
+			Projection:     &projection,
+			ItemCount:      itemCount,
+			IndexSizeBytes: aws.Int64(0),
+		})
+	}
+
+	return description
+}
+
+func keySchemaElements(hashKey, rangeKey string) []types.KeySchemaElement {
+	elements := []types.KeySchemaElement{{AttributeName: aws.String(hashKey), KeyType: types.KeyTypeHash}}
+	if rangeKey != "" {
+		elements = append(elements, types.KeySchemaElement{AttributeName: aws.String(rangeKey), KeyType: types.KeyTypeRange})
+	}
+	return elements
+}
+
+func throughputDescription(throughput *types.ProvisionedThroughput) *types.ProvisionedThroughputDescription {
+	description := &types.ProvisionedThroughputDescription{
+		NumberOfDecreasesToday: aws.Int64(0),
+		ReadCapacityUnits:      aws.Int64(0),
+		WriteCapacityUnits:     aws.Int64(0),
+	}
+	if throughput != nil {
+		description.ReadCapacityUnits = throughput.ReadCapacityUnits
+		description.WriteCapacityUnits = throughput.WriteCapacityUnits
+	}
+	return description
+}
+
+func (db *DB) UpdateTable(ctx context.Context, in *ddb.UpdateTableInput, optFns ...func(*ddb.Options)) (*ddb.UpdateTableOutput, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(in.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.BillingMode == "" && in.ProvisionedThroughput == nil && in.StreamSpecification == nil && len(in.GlobalSecondaryIndexUpdates) == 0 {
+		return nil, validationError("At least one of ProvisionedThroughput, BillingMode, UpdateStreamEnabled, GlobalSecondaryIndexUpdates or SSESpecification or ReplicaUpdates is required")
+	}
+
+	updated := *t
+	updated.attributeTypes = make(map[string]types.ScalarAttributeType, len(t.attributeTypes))
+	for name, attributeType := range t.attributeTypes {
+		updated.attributeTypes[name] = attributeType
+	}
+	updated.indexes = make(map[string]*index, len(t.indexes))
+	for name, idx := range t.indexes {
+		copied := *idx
+		updated.indexes[name] = &copied
+	}
+
+	for _, def := range in.AttributeDefinitions {
+		if def.AttributeName == nil {
+			return nil, validationError("One or more parameter values were invalid: attribute name is empty in AttributeDefinitions")
+		}
+		if existing, ok := updated.attributeTypes[*def.AttributeName]; ok && existing != def.AttributeType {
+			return nil, validationError("One or more parameter values were invalid: attribute %s is already defined with type %s", *def.AttributeName, existing)
+		}
+		updated.attributeTypes[*def.AttributeName] = def.AttributeType
+	}
+
+	if in.BillingMode != "" {
+		updated.billingMode = in.BillingMode
+		if in.BillingMode == types.BillingModePayPerRequest {
+			updated.throughput = nil
+		}
+	}
+
+	if in.ProvisionedThroughput != nil {
+		updated.throughput = in.ProvisionedThroughput
+	}
+
+	if in.BillingMode != "" || in.ProvisionedThroughput != nil {
+		if err := checkThroughput(updated.billingMode, updated.throughput, "table"); err != nil {
+			return nil, err
+		}
+	}
+
+	if in.StreamSpecification != nil {
+		enabled := in.StreamSpecification.StreamEnabled != nil && *in.StreamSpecification.StreamEnabled
+		if enabled && in.StreamSpecification.StreamViewType == "" {
+			return nil, validationError("One or more parameter values were invalid: StreamViewType is required when enabling streams")
+		}
+		updated.streams = in.StreamSpecification
+	}
+
+	for _, update := range in.GlobalSecondaryIndexUpdates {
+		if err := updated.applyIndexUpdate(update); err != nil {
+			return nil, err
+		}
+	}
+
+	*t = updated
+
+	return &ddb.UpdateTableOutput{TableDescription: t.describe()}, nil
+}
+
+func (t *table) applyIndexUpdate(update types.GlobalSecondaryIndexUpdate) error {
+	switch {
+	case update.Create != nil && update.Delete == nil && update.Update == nil:
+		action := update.Create
+		idx, err := newIndex(t, action.IndexName, action.KeySchema, action.Projection, true, make(map[string]bool))
+		if err != nil {
+			return err
+		}
+		if err := checkThroughput(t.billingMode, action.ProvisionedThroughput, "index "+idx.name); err != nil {
+			return err
+		}
+		idx.throughput = action.ProvisionedThroughput
+
+	case update.Delete != nil && update.Create == nil && update.Update == nil:
+		idx, err := t.findIndex(update.Delete.IndexName)
+		if err != nil {
+			return resourceNotFound(t.name)
+		}
+		if idx == nil || !idx.global {
+			return validationError("One or more parameter values were invalid: only global secondary indexes can be deleted")
+		}
+		delete(t.indexes, idx.name)
+
+	case update.Update != nil && update.Create == nil && update.Delete == nil:
+		idx, err := t.findIndex(update.Update.IndexName)
+		if err != nil {
+			return resourceNotFound(t.name)
+		}
+		if idx == nil || !idx.global {
+			return validationError("One or more parameter values were invalid: only global secondary indexes can be updated")
+		}
+		if err := checkThroughput(t.billingMode, update.Update.ProvisionedThroughput, "index "+idx.name); err != nil {
+			return err
+		}
+		idx.throughput = update.Update.ProvisionedThroughput
+
+	default:
+		return validationError("One or more parameter values were invalid: a GlobalSecondaryIndexUpdate must contain exactly one of Create, Update or Delete")
+	}
+
+	return nil
+}
+
+func (db *DB) UpdateTimeToLive(ctx context.Context, in *ddb.UpdateTimeToLiveInput, optFns ...func(*ddb.Options)) (*ddb.UpdateTimeToLiveOutput, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(in.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := in.TimeToLiveSpecification
+	if spec == nil || spec.AttributeName == nil || *spec.AttributeName == "" || spec.Enabled == nil {
+		return nil, validationError("1 validation error detected: Value null at 'timeToLiveSpecification' failed to satisfy constraint: Member must not be null")
+	}
+
+	if *spec.Enabled {
+		if t.ttlEnabled {
+			return nil, validationError("TimeToLive is already enabled")
+		}
+		t.ttlEnabled = true
+		t.ttlAttribute = *spec.AttributeName
+	} else {
+		if !t.ttlEnabled {
+			return nil, validationError("TimeToLive is already disabled")
+		}
+		if t.ttlAttribute != *spec.AttributeName {
+			return nil, validationError("TimeToLive is enabled on attribute %s", t.ttlAttribute)
+		}
+		t.ttlEnabled = false
+	}
+
+	return &ddb.UpdateTimeToLiveOutput{TimeToLiveSpecification: &types.TimeToLiveSpecification{
+		AttributeName: aws.String(*spec.AttributeName),
+		Enabled:       aws.Bool(*spec.Enabled),
+	}}, nil
+}
+
+func (db *DB) DescribeTimeToLive(ctx context.Context, in *ddb.DescribeTimeToLiveInput, optFns ...func(*ddb.Options)) (*ddb.DescribeTimeToLiveOutput, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(in.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	description := &types.TimeToLiveDescription{TimeToLiveStatus: types.TimeToLiveStatusDisabled}
+	if t.ttlEnabled {
+		description.TimeToLiveStatus = types.TimeToLiveStatusEnabled
+		description.AttributeName = aws.String(t.ttlAttribute)
+	}
+
+	return &ddb.DescribeTimeToLiveOutput{TimeToLiveDescription: description}, nil
+}
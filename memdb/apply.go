@@ -0,0 +1,339 @@
+package memdb
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func applyUpdate(base map[string]types.AttributeValue, actions *updateActions, keyAttributes []string) (map[string]types.AttributeValue, []string, error) {
+	if err := checkUpdatePaths(actions, keyAttributes); err != nil {
+		return nil, nil, err
+	}
+
+	setValues := make([]types.AttributeValue, 0, len(actions.set))
+	for _, action := range actions.set {
+		value, err := evalUpdateOperand(base, action.value)
+		if err != nil {
+			return nil, nil, err
+		}
+		setValues = append(setValues, value)
+	}
+
+	item := copyItem(base)
+	if item == nil {
+		item = make(map[string]types.AttributeValue)
+	}
+
+	for i, action := range actions.set {
+		if err := setPath(item, action.path, copyValue(setValues[i])); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	removals := append([]path(nil), actions.remove...)
+	sort.SliceStable(removals, func(i, j int) bool {
+		return comparePaths(removals[i], removals[j]) > 0
+	})
+	for _, p := range removals {
+		removePath(item, p)
+	}
+
+	for _, action := range actions.add {
+		if err := addValue(item, action); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, action := range actions.delete {
+		if err := deleteValue(item, action); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return item, updatedAttributes(actions), nil
+}
+
+func checkUpdatePaths(actions *updateActions, keyAttributes []string) error {
+	var paths []path
+	for _, action := range actions.set {
+		paths = append(paths, action.path)
+	}
+	paths = append(paths, actions.remove...)
+	for _, action := range actions.add {
+		paths = append(paths, action.path)
+	}
+	for _, action := range actions.delete {
+		paths = append(paths, action.path)
+	}
+
+	for i, p := range paths {
+		for _, key := range keyAttributes {
+			if p.topLevel() == key {
+				return validationError("One or more parameter values were invalid: Cannot update attribute %s. This attribute is part of the key", key)
+			}
+		}
+
+		for _, other := range paths[i+1:] {
+			if isPathPrefix(p, other) || isPathPrefix(other, p) {
+				return validationError("Invalid UpdateExpression: Two document paths overlap with each other; must remove or rewrite one of these paths; path one: [%s], path two: [%s]", p, other)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p path) String() string {
+	parts := make([]string, 0, len(p))
+	for _, elem := range p {
+		if elem.isIndex {
+			parts = append(parts, "["+strconv.Itoa(elem.index)+"]")
+		} else {
+			parts = append(parts, elem.name)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func isPathPrefix(prefix, p path) bool {
+	if len(prefix) > len(p) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != p[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func comparePaths(a, b path) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		switch {
+		case a[i].isIndex && b[i].isIndex && a[i].index != b[i].index:
+			if a[i].index < b[i].index {
+				return -1
+			}
+			return 1
+		case a[i].name != b[i].name:
+			return strings.Compare(a[i].name, b[i].name)
+		}
+	}
+	return len(a) - len(b)
+}
+
+func updatedAttributes(actions *updateActions) []string {
+	seen := make(map[string]bool)
+	var names []string
+	add := func(p path) {
+		if name := p.topLevel(); !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, action := range actions.set {
+		add(action.path)
+	}
+	for _, p := range actions.remove {
+		add(p)
+	}
+	for _, action := range actions.add {
+		add(action.path)
+	}
+	for _, action := range actions.delete {
+		add(action.path)
+	}
+
+	return names
+}
+
+func invalidUpdatePath() error {
+	return validationError("The document path provided in the update expression is invalid for update")
+}
+
+func setPath(item map[string]types.AttributeValue, p path, value types.AttributeValue) error {
+	if len(p) == 1 {
+		item[p[0].name] = value
+		return nil
+	}
+
+	last := p[len(p)-1]
+	switch parent := resolvePath(item, p[:len(p)-1]).(type) {
+	case *types.AttributeValueMemberM:
+		if last.isIndex {
+			return invalidUpdatePath()
+		}
+		parent.Value[last.name] = value
+	case *types.AttributeValueMemberL:
+		if !last.isIndex {
+			return invalidUpdatePath()
+		}
+		if last.index >= len(parent.Value) {
+			parent.Value = append(parent.Value, value)
+		} else {
+			parent.Value[last.index] = value
+		}
+	default:
+		return invalidUpdatePath()
+	}
+
+	return nil
+}
+
+func removePath(item map[string]types.AttributeValue, p path) {
+	if len(p) == 1 {
+		delete(item, p[0].name)
+		return
+	}
+
+	last := p[len(p)-1]
+	switch parent := resolvePath(item, p[:len(p)-1]).(type) {
+	case *types.AttributeValueMemberM:
+		if !last.isIndex {
+			delete(parent.Value, last.name)
+		}
+	case *types.AttributeValueMemberL:
+		if last.isIndex && last.index < len(parent.Value) {
+			parent.Value = append(parent.Value[:last.index], parent.Value[last.index+1:]...)
+		}
+	}
+}
+
+func addValue(item map[string]types.AttributeValue, action setAction) error {
+	value := action.value.(valueOperand).value
+	existing := resolvePath(item, action.path)
+
+	switch v := value.(type) {
+	case *types.AttributeValueMemberN:
+		if existing == nil {
+			return setPath(item, action.path, copyValue(v))
+		}
+		current, ok := numberValue(existing)
+		delta, dok := numberValue(v)
+		if !ok || !dok {
+			return incorrectOperandType("ADD", typeName(existing))
+		}
+		sum := new(big.Float).SetPrec(numberPrecision).Add(current, delta)
+		return setPath(item, action.path, &types.AttributeValueMemberN{Value: formatNumber(sum)})
+
+	case *types.AttributeValueMemberSS, *types.AttributeValueMemberNS, *types.AttributeValueMemberBS:
+		if existing == nil {
+			return setPath(item, action.path, copyValue(v))
+		}
+		if typeName(existing) != typeName(v) {
+			return incorrectOperandType("ADD", typeName(existing))
+		}
+		elems := setElements(existing)
+		seen := setKeys(existing)
+		for _, elem := range setElements(v) {
+			if k, _ := keyString(elem); !seen[k] {
+				seen[k] = true
+				elems = append(elems, elem)
+			}
+		}
+		return setPath(item, action.path, buildSet(typeName(v), elems))
+	}
+
+	return incorrectOperandType("ADD", typeName(value))
+}
+
+func deleteValue(item map[string]types.AttributeValue, action setAction) error {
+	value := action.value.(valueOperand).value
+	switch value.(type) {
+	case *types.AttributeValueMemberSS, *types.AttributeValueMemberNS, *types.AttributeValueMemberBS:
+	default:
+		return incorrectOperandType("DELETE", typeName(value))
+	}
+
+	existing := resolvePath(item, action.path)
+	if existing == nil {
+		return nil
+	}
+	if typeName(existing) != typeName(value) {
+		return incorrectOperandType("DELETE", typeName(existing))
+	}
+
+	removed := setKeys(value)
+	var remaining []types.AttributeValue
+	for _, elem := range setElements(existing) {
+		if k, _ := keyString(elem); !removed[k] {
+			remaining = append(remaining, elem)
+		}
+	}
+
+	if len(remaining) == 0 {
+		removePath(item, action.path)
+		return nil
+	}
+
+	return setPath(item, action.path, buildSet(typeName(value), remaining))
+}
+
+func projectItem(item map[string]types.AttributeValue, paths []path) map[string]types.AttributeValue {
+	if item == nil {
+		return nil
+	}
+	if paths == nil {
+		return copyItem(item)
+	}
+
+	result := make(map[string]types.AttributeValue)
+	for _, p := range paths {
+		value := resolvePath(item, p)
+		if value == nil {
+			continue
+		}
+		insertProjected(result, p, copyValue(value))
+	}
+	return result
+}
+
+func insertProjected(result map[string]types.AttributeValue, p path, value types.AttributeValue) {
+	if len(p) == 1 {
+		result[p[0].name] = value
+		return
+	}
+
+	var container types.AttributeValue = &types.AttributeValueMemberM{Value: result}
+	for i, elem := range p[:len(p)-1] {
+		nextIsIndex := p[i+1].isIndex
+		container = projectedChild(container, elem, nextIsIndex)
+	}
+
+	last := p[len(p)-1]
+	switch c := container.(type) {
+	case *types.AttributeValueMemberM:
+		c.Value[last.name] = value
+	case *types.AttributeValueMemberL:
+		c.Value = append(c.Value, value)
+	}
+}
+
+func projectedChild(container types.AttributeValue, elem pathElem, listChild bool) types.AttributeValue {
+	newChild := func() types.AttributeValue {
+		if listChild {
+			return &types.AttributeValueMemberL{}
+		}
+		return &types.AttributeValueMemberM{Value: make(map[string]types.AttributeValue)}
+	}
+
+	switch c := container.(type) {
+	case *types.AttributeValueMemberM:
+		if child, ok := c.Value[elem.name]; ok {
+			return child
+		}
+		child := newChild()
+		c.Value[elem.name] = child
+		return child
+	case *types.AttributeValueMemberL:
+		child := newChild()
+		c.Value = append(c.Value, child)
+		return child
+	}
+
+	return container
+}
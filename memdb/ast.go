@@ -0,0 +1,105 @@
+package memdb
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type pathElem struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+type path []pathElem
+
+func (p path) topLevel() string {
+	if len(p) == 0 {
+		return ""
+	}
+	return p[0].name
+}
+
+type operand interface{}
+
+type pathOperand struct {
+	path path
+}
+
+type valueOperand struct {
+	value types.AttributeValue
+}
+
+type sizeOperand struct {
+	path path
+}
+
+type ifNotExistsOperand struct {
+	path     path
+	fallback operand
+}
+
+type listAppendOperand struct {
+	left  operand
+	right operand
+}
+
+type arithmeticOperand struct {
+	operator string
+	left     operand
+	right    operand
+}
+
+type condition interface{}
+
+type comparisonCondition struct {
+	operator string
+	left     operand
+	right    operand
+}
+
+type betweenCondition struct {
+	operand operand
+	lower   operand
+	upper   operand
+}
+
+type inCondition struct {
+	operand operand
+	values  []operand
+}
+
+type functionCondition struct {
+	name string
+	args []operand
+}
+
+type missingCondition struct {
+	path    path
+	missing bool
+}
+
+type andCondition struct {
+	left  condition
+	right condition
+}
+
+type orCondition struct {
+	left  condition
+	right condition
+}
+
+type notCondition struct {
+	condition condition
+}
+
+type setAction struct {
+	path  path
+	value operand
+}
+
+type updateActions struct {
+	set    []setAction
+	remove []path
+	add    []setAction
+	delete []setAction
+}
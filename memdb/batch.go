@@ -0,0 +1,194 @@
+package memdb
+
+import (
+	"context"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"sort"
+)
+
+const (
+	maxBatchWriteItems = 25
+	maxBatchGetKeys    = 100
+)
+
+type pendingWrite struct {
+	table   *table
+	key     string
+	request types.WriteRequest
+}
+
+type pendingGet struct {
+	table      *table
+	key        string
+	request    types.KeysAndAttributes
+	keyValues  map[string]types.AttributeValue
+	projection []path
+}
+
+func (db *DB) BatchWriteItem(ctx context.Context, in *ddb.BatchWriteItemInput, optFns ...func(*ddb.Options)) (*ddb.BatchWriteItemOutput, error) {
+	total := 0
+	for _, requests := range in.RequestItems {
+		total += len(requests)
+	}
+
+	if total == 0 {
+		return nil, validationError("1 validation error detected: Value at 'requestItems' failed to satisfy constraint: Map value must satisfy constraint: Member must have length greater than or equal to 1")
+	}
+
+	if total > maxBatchWriteItems {
+		return nil, validationError("Too many items requested for the BatchWriteItem call")
+	}
+
+	throttled := db.throttled("BatchWriteItem")
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var pending []pendingWrite
+	for _, name := range sortedTableNames(in.RequestItems) {
+		t, err := db.table(&name)
+		if err != nil {
+			return nil, err
+		}
+
+		seen := make(map[string]bool)
+		for _, request := range in.RequestItems[name] {
+			var key string
+			switch {
+			case request.PutRequest != nil && request.DeleteRequest == nil:
+				key, err = t.validateItem(request.PutRequest.Item)
+			case request.DeleteRequest != nil && request.PutRequest == nil:
+				key, err = t.lookupKey(request.DeleteRequest.Key)
+			default:
+				err = validationError("Supplied AttributeValue has more than one datatypes set, must contain exactly one of the supported datatypes")
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if seen[key] {
+				return nil, validationError("Provided list of item keys contains duplicates")
+			}
+			seen[key] = true
+
+			pending = append(pending, pendingWrite{table: t, key: key, request: request})
+		}
+	}
+
+	processed := len(pending)
+	if throttled {
+		processed = len(pending) / 2
+	}
+
+	for _, w := range pending[:processed] {
+		if w.request.PutRequest != nil {
+			w.table.items[w.key] = copyItem(w.request.PutRequest.Item)
+		} else {
+			delete(w.table.items, w.key)
+		}
+	}
+
+	unprocessed := make(map[string][]types.WriteRequest)
+	for _, w := range pending[processed:] {
+		unprocessed[w.table.name] = append(unprocessed[w.table.name], w.request)
+	}
+
+	return &ddb.BatchWriteItemOutput{UnprocessedItems: unprocessed}, nil
+}
+
+func (db *DB) BatchGetItem(ctx context.Context, in *ddb.BatchGetItemInput, optFns ...func(*ddb.Options)) (*ddb.BatchGetItemOutput, error) {
+	total := 0
+	for _, request := range in.RequestItems {
+		total += len(request.Keys)
+	}
+
+	if total == 0 {
+		return nil, validationError("1 validation error detected: Value at 'requestItems' failed to satisfy constraint: Map value must satisfy constraint: Member must have length greater than or equal to 1")
+	}
+
+	if total > maxBatchGetKeys {
+		return nil, validationError("Too many items requested for the BatchGetItem call")
+	}
+
+	throttled := db.throttled("BatchGetItem")
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var pending []pendingGet
+	for _, name := range sortedTableNames(in.RequestItems) {
+		t, err := db.table(&name)
+		if err != nil {
+			return nil, err
+		}
+
+		request := in.RequestItems[name]
+		exprCtx := newExprContext(request.ExpressionAttributeNames, nil)
+		projection, err := parseProjectionExpression(request.ProjectionExpression, exprCtx)
+		if err != nil {
+			return nil, err
+		}
+		if err := exprCtx.checkUnused(); err != nil {
+			return nil, err
+		}
+
+		seen := make(map[string]bool)
+		for _, keyValues := range request.Keys {
+			key, err := t.lookupKey(keyValues)
+			if err != nil {
+				return nil, err
+			}
+
+			if seen[key] {
+				return nil, validationError("Provided list of item keys contains duplicates")
+			}
+			seen[key] = true
+
+			pending = append(pending, pendingGet{
+				table:      t,
+				key:        key,
+				request:    request,
+				keyValues:  keyValues,
+				projection: projection,
+			})
+		}
+	}
+
+	processed := len(pending)
+	if throttled {
+		processed = len(pending) / 2
+	}
+
+	responses := make(map[string][]map[string]types.AttributeValue)
+	for _, g := range pending[:processed] {
+		if _, ok := responses[g.table.name]; !ok {
+			responses[g.table.name] = []map[string]types.AttributeValue{}
+		}
+		if item, ok := g.table.items[g.key]; ok {
+			responses[g.table.name] = append(responses[g.table.name], projectItem(item, g.projection))
+		}
+	}
+
+	unprocessed := make(map[string]types.KeysAndAttributes)
+	for _, g := range pending[processed:] {
+		request, ok := unprocessed[g.table.name]
+		if !ok {
+			request = g.request
+			request.Keys = nil
+		}
+		request.Keys = append(request.Keys, g.keyValues)
+		unprocessed[g.table.name] = request
+	}
+
+	return &ddb.BatchGetItemOutput{Responses: responses, UnprocessedKeys: unprocessed}, nil
+}
+
+func sortedTableNames[T any](requests map[string]T) []string {
+	names := make([]string, 0, len(requests))
+	for name := range requests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
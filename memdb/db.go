@@ -0,0 +1,77 @@
+package memdb
+
+import (
+	"github.com/jhmachado/dynamodb/client"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+var _ client.DynamoDBAPI = (*DB)(nil)
+
+type Option func(db *DB)
+
+type DB struct {
+	mu            sync.Mutex
+	tables        map[string]*table
+	throttle      func(operation string) bool
+	requestTokens map[string]bool
+	nextTokens    map[string]statementPage
+	tokenCounter  int
+}
+
+func New(options ...Option) *DB {
+	db := &DB{
+		tables:        make(map[string]*table),
+		requestTokens: make(map[string]bool),
+		nextTokens:    make(map[string]statementPage),
+	}
+
+	for _, option := range options {
+		option(db)
+	}
+
+	return db
+}
+
+func WithThrottle(throttle func(operation string) bool) Option {
+	return func(db *DB) {
+		db.throttle = throttle
+	}
+}
+
+func ThrottleFirst(n int) func(operation string) bool {
+	var calls int64
+	return func(operation string) bool {
+		return atomic.AddInt64(&calls, 1) <= int64(n)
+	}
+}
+
+func (db *DB) TableNames() []string {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	names := make([]string, 0, len(db.tables))
+	for name := range db.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (db *DB) throttled(operation string) bool {
+	return db.throttle != nil && db.throttle(operation)
+}
+
+func (db *DB) table(name *string) (*table, error) {
+	if name == nil || *name == "" {
+		return nil, validationError("1 validation error detected: Value null at 'tableName' failed to satisfy constraint: Member must not be null")
+	}
+
+	t, ok := db.tables[*name]
+	if !ok {
+		return nil, resourceNotFound(*name)
+	}
+
+	return t, nil
+}
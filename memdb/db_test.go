@@ -0,0 +1,141 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"testing"
+)
+
+func TestThrottleFirst(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        int
+		calls    int
+		expected []bool
+	}{
+		{name: "never", n: 0, calls: 3, expected: []bool{false, false, false}},
+		{name: "first call", n: 1, calls: 3, expected: []bool{true, false, false}},
+		{name: "first two calls", n: 2, calls: 3, expected: []bool{true, true, false}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			throttle := ThrottleFirst(test.n)
+			for i := 0; i < test.calls; i++ {
+				if got := throttle("GetItem"); got != test.expected[i] {
+					t.Errorf("call %d: expected %v, got %v", i, test.expected[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestThrottledOperations(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(db *DB) error
+	}{
+		{
+			name: "GetItem",
+			call: func(db *DB) error {
+				_, err := db.GetItem(context.Background(), &ddb.GetItemInput{TableName: aws.String(testTable), Key: testKey("a", "1")})
+				return err
+			},
+		},
+		{
+			name: "PutItem",
+			call: func(db *DB) error {
+				_, err := db.PutItem(context.Background(), &ddb.PutItemInput{TableName: aws.String(testTable), Item: testKey("c", "1")})
+				return err
+			},
+		},
+		{
+			name: "Query",
+			call: func(db *DB) error {
+				_, err := db.Query(context.Background(), &ddb.QueryInput{
+					TableName:                 aws.String(testTable),
+					KeyConditionExpression:    aws.String("pk = :pk"),
+					ExpressionAttributeValues: map[string]types.AttributeValue{":pk": &types.AttributeValueMemberS{Value: "a"}},
+				})
+				return err
+			},
+		},
+		{
+			name: "Scan",
+			call: func(db *DB) error {
+				_, err := db.Scan(context.Background(), &ddb.ScanInput{TableName: aws.String(testTable)})
+				return err
+			},
+		},
+		{
+			name: "ExecuteStatement",
+			call: func(db *DB) error {
+				_, err := db.ExecuteStatement(context.Background(), &ddb.ExecuteStatementInput{Statement: aws.String(`SELECT * FROM "items"`)})
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := newTestDB(t)
+			seedTestDB(t, db)
+			db.throttle = ThrottleFirst(1)
+
+			var throttled *types.ProvisionedThroughputExceededException
+			if err := test.call(db); !errors.As(err, &throttled) {
+				t.Fatalf("expected the first call to be throttled, got %v", err)
+			}
+
+			if err := test.call(db); err != nil {
+				t.Fatalf("expected the second call to succeed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestThrottledBatchOperations(t *testing.T) {
+	db := newTestDB(t, WithThrottle(ThrottleFirst(2)))
+
+	writes := make([]types.WriteRequest, 0, 4)
+	for _, pk := range []string{"a", "b", "c", "d"} {
+		writes = append(writes, types.WriteRequest{PutRequest: &types.PutRequest{Item: testKey(pk, "1")}})
+	}
+
+	out, err := db.BatchWriteItem(context.Background(), &ddb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{testTable: writes},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.UnprocessedItems[testTable]) != 2 || len(db.tables[testTable].items) != 2 {
+		t.Fatalf("expected half of the writes to be unprocessed, got %d unprocessed and %d stored", len(out.UnprocessedItems[testTable]), len(db.tables[testTable].items))
+	}
+
+	statements := make([]types.BatchStatementRequest, 0, 2)
+	for _, pk := range []string{"e", "f"} {
+		statements = append(statements, types.BatchStatementRequest{
+			Statement:  aws.String(`INSERT INTO "items" VALUE {'pk': ?, 'sk': ?}`),
+			Parameters: []types.AttributeValue{&types.AttributeValueMemberS{Value: pk}, &types.AttributeValueMemberN{Value: "1"}},
+		})
+	}
+
+	batch, err := db.BatchExecuteStatement(context.Background(), &ddb.BatchExecuteStatementInput{Statements: statements})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batch.Responses[0].Error != nil || batch.Responses[1].Error == nil || batch.Responses[1].Error.Code != types.BatchStatementErrorCodeEnumThrottlingError {
+		t.Fatalf("expected only the second statement to be throttled, got %+v", batch.Responses)
+	}
+
+	retried, err := db.BatchWriteItem(context.Background(), &ddb.BatchWriteItemInput{RequestItems: out.UnprocessedItems})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(retried.UnprocessedItems) != 0 || len(db.tables[testTable].items) != 5 {
+		t.Errorf("expected the retried writes to be processed once throttling stops, got %d stored", len(db.tables[testTable].items))
+	}
+}
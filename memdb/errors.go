@@ -0,0 +1,41 @@
+package memdb
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+)
+
+func validationError(format string, args ...interface{}) error {
+	return &smithy.GenericAPIError{
+		Code:    "ValidationException",
+		Message: fmt.Sprintf(format, args...),
+		Fault:   smithy.FaultClient,
+	}
+}
+
+func conditionalCheckFailed() error {
+	return &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed")}
+}
+
+func resourceNotFound(tableName string) error {
+	return &types.ResourceNotFoundException{Message: aws.String("Requested resource not found: Table: " + tableName + " not found")}
+}
+
+func resourceInUse(tableName string) error {
+	return &types.ResourceInUseException{Message: aws.String("Table already exists: " + tableName)}
+}
+
+func throughputExceeded() error {
+	return &types.ProvisionedThroughputExceededException{
+		Message: aws.String("The level of configured provisioned throughput for the table was exceeded"),
+	}
+}
+
+func errorMessage(err error) string {
+	if apiErr, ok := err.(smithy.APIError); ok {
+		return apiErr.ErrorMessage()
+	}
+	return err.Error()
+}
@@ -0,0 +1,390 @@
+package memdb
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var attributeTypes = map[string]bool{
+	"S": true, "N": true, "B": true, "BOOL": true, "NULL": true,
+	"SS": true, "NS": true, "BS": true, "L": true, "M": true,
+}
+
+func resolvePath(item map[string]types.AttributeValue, p path) types.AttributeValue {
+	if len(p) == 0 {
+		return nil
+	}
+
+	current, ok := item[p[0].name]
+	if !ok {
+		return nil
+	}
+
+	for _, elem := range p[1:] {
+		if elem.isIndex {
+			l, ok := current.(*types.AttributeValueMemberL)
+			if !ok || elem.index >= len(l.Value) {
+				return nil
+			}
+			current = l.Value[elem.index]
+			continue
+		}
+
+		m, ok := current.(*types.AttributeValueMemberM)
+		if !ok {
+			return nil
+		}
+		if current, ok = m.Value[elem.name]; !ok {
+			return nil
+		}
+	}
+
+	return current
+}
+
+func evalOperand(item map[string]types.AttributeValue, op operand) (types.AttributeValue, error) {
+	switch o := op.(type) {
+	case pathOperand:
+		return resolvePath(item, o.path), nil
+
+	case valueOperand:
+		return o.value, nil
+
+	case sizeOperand:
+		size, ok := valueSize(resolvePath(item, o.path))
+		if !ok {
+			return nil, nil
+		}
+		return &types.AttributeValueMemberN{Value: strconv.Itoa(size)}, nil
+
+	case ifNotExistsOperand:
+		if value := resolvePath(item, o.path); value != nil {
+			return value, nil
+		}
+		return evalOperand(item, o.fallback)
+
+	case listAppendOperand:
+		left, err := evalUpdateOperand(item, o.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := evalUpdateOperand(item, o.right)
+		if err != nil {
+			return nil, err
+		}
+		ll, lok := left.(*types.AttributeValueMemberL)
+		rl, rok := right.(*types.AttributeValueMemberL)
+		if !lok || !rok {
+			operandType := typeName(left)
+			if lok {
+				operandType = typeName(right)
+			}
+			return nil, incorrectOperandType("list_append", operandType)
+		}
+		l := make([]types.AttributeValue, 0, len(ll.Value)+len(rl.Value))
+		l = append(l, ll.Value...)
+		l = append(l, rl.Value...)
+		return &types.AttributeValueMemberL{Value: l}, nil
+
+	case arithmeticOperand:
+		left, err := evalUpdateOperand(item, o.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := evalUpdateOperand(item, o.right)
+		if err != nil {
+			return nil, err
+		}
+		ln, lok := numberValue(left)
+		rn, rok := numberValue(right)
+		if !lok || !rok {
+			operandType := typeName(left)
+			if lok {
+				operandType = typeName(right)
+			}
+			return nil, incorrectOperandType(o.operator, operandType)
+		}
+		result := new(big.Float).SetPrec(numberPrecision)
+		if o.operator == "+" {
+			result.Add(ln, rn)
+		} else {
+			result.Sub(ln, rn)
+		}
+		return &types.AttributeValueMemberN{Value: formatNumber(result)}, nil
+	}
+
+	return nil, validationError("Invalid expression: unsupported operand")
+}
+
+func evalUpdateOperand(item map[string]types.AttributeValue, op operand) (types.AttributeValue, error) {
+	value, err := evalOperand(item, op)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, validationError("The provided expression refers to an attribute that does not exist in the item")
+	}
+	return value, nil
+}
+
+func numberValue(av types.AttributeValue) (*big.Float, bool) {
+	n, ok := av.(*types.AttributeValueMemberN)
+	if !ok {
+		return nil, false
+	}
+	return parseNumber(n.Value)
+}
+
+func incorrectOperandType(operator, operandType string) error {
+	return validationError("Invalid UpdateExpression: Incorrect operand type for operator or function; operator or function: %s, operand type: %s", operator, operandType)
+}
+
+func evalCondition(item map[string]types.AttributeValue, c condition) (bool, error) {
+	switch cond := c.(type) {
+	case comparisonCondition:
+		left, err := evalOperand(item, cond.left)
+		if err != nil || left == nil {
+			return false, err
+		}
+		right, err := evalOperand(item, cond.right)
+		if err != nil || right == nil {
+			return false, err
+		}
+		switch cond.operator {
+		case "=":
+			return equalValues(left, right), nil
+		case "<>":
+			return !equalValues(left, right), nil
+		}
+		cmp, ok := compareValues(left, right)
+		if !ok {
+			return false, nil
+		}
+		switch cond.operator {
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		default:
+			return cmp >= 0, nil
+		}
+
+	case betweenCondition:
+		values := make([]types.AttributeValue, 0, 3)
+		for _, op := range []operand{cond.operand, cond.lower, cond.upper} {
+			value, err := evalOperand(item, op)
+			if err != nil || value == nil {
+				return false, err
+			}
+			values = append(values, value)
+		}
+		if cmp, ok := compareValues(values[1], values[2]); ok && cmp > 0 {
+			return false, validationError("Invalid ConditionExpression: The BETWEEN operator requires upper bound to be greater than or equal to lower bound")
+		}
+		lower, lok := compareValues(values[0], values[1])
+		upper, uok := compareValues(values[0], values[2])
+		return lok && uok && lower >= 0 && upper <= 0, nil
+
+	case inCondition:
+		value, err := evalOperand(item, cond.operand)
+		if err != nil || value == nil {
+			return false, err
+		}
+		for _, op := range cond.values {
+			candidate, err := evalOperand(item, op)
+			if err != nil {
+				return false, err
+			}
+			if candidate != nil && equalValues(value, candidate) {
+				return true, nil
+			}
+		}
+		return false, nil
+
+	case functionCondition:
+		return evalFunction(item, cond)
+
+	case missingCondition:
+		return (resolvePath(item, cond.path) == nil) == cond.missing, nil
+
+	case andCondition:
+		left, err := evalCondition(item, cond.left)
+		if err != nil || !left {
+			return false, err
+		}
+		return evalCondition(item, cond.right)
+
+	case orCondition:
+		left, err := evalCondition(item, cond.left)
+		if err != nil || left {
+			return left, err
+		}
+		return evalCondition(item, cond.right)
+
+	case notCondition:
+		result, err := evalCondition(item, cond.condition)
+		return !result, err
+	}
+
+	return false, validationError("Invalid expression: unsupported condition")
+}
+
+func evalFunction(item map[string]types.AttributeValue, f functionCondition) (bool, error) {
+	target := resolvePath(item, f.args[0].(pathOperand).path)
+
+	switch f.name {
+	case "attribute_exists":
+		return target != nil, nil
+	case "attribute_not_exists":
+		return target == nil, nil
+	}
+
+	arg, err := evalOperand(item, f.args[1])
+	if err != nil {
+		return false, err
+	}
+
+	switch f.name {
+	case "attribute_type":
+		s, ok := arg.(*types.AttributeValueMemberS)
+		if !ok || !attributeTypes[s.Value] {
+			return false, validationError("Invalid ConditionExpression: Invalid attribute type name found; type: %s, valid types: { B,N,S,BOOL,NULL,SS,BS,NS,L,M }", typeName(arg))
+		}
+		return target != nil && typeName(target) == s.Value, nil
+
+	case "begins_with":
+		switch t := target.(type) {
+		case *types.AttributeValueMemberS:
+			prefix, ok := arg.(*types.AttributeValueMemberS)
+			return ok && strings.HasPrefix(t.Value, prefix.Value), nil
+		case *types.AttributeValueMemberB:
+			prefix, ok := arg.(*types.AttributeValueMemberB)
+			return ok && bytes.HasPrefix(t.Value, prefix.Value), nil
+		}
+		return false, nil
+
+	case "contains":
+		if target == nil || arg == nil {
+			return false, nil
+		}
+		switch t := target.(type) {
+		case *types.AttributeValueMemberS:
+			sub, ok := arg.(*types.AttributeValueMemberS)
+			return ok && strings.Contains(t.Value, sub.Value), nil
+		case *types.AttributeValueMemberB:
+			sub, ok := arg.(*types.AttributeValueMemberB)
+			return ok && bytes.Contains(t.Value, sub.Value), nil
+		case *types.AttributeValueMemberSS, *types.AttributeValueMemberNS, *types.AttributeValueMemberBS:
+			for _, elem := range setElements(target) {
+				if equalValues(elem, arg) {
+					return true, nil
+				}
+			}
+		case *types.AttributeValueMemberL:
+			for _, elem := range t.Value {
+				if equalValues(elem, arg) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+
+	return false, validationError("Invalid expression: unsupported function %s", f.name)
+}
+
+func conjuncts(c condition) []condition {
+	if and, ok := c.(andCondition); ok {
+		return append(conjuncts(and.left), conjuncts(and.right)...)
+	}
+	return []condition{c}
+}
+
+func combineConditions(conditions []condition) condition {
+	var combined condition
+	for _, c := range conditions {
+		if combined == nil {
+			combined = c
+		} else {
+			combined = andCondition{left: combined, right: c}
+		}
+	}
+	return combined
+}
+
+func keyEquality(c condition) (string, types.AttributeValue, bool) {
+	cmp, ok := c.(comparisonCondition)
+	if !ok || cmp.operator != "=" {
+		return "", nil, false
+	}
+
+	left, right := cmp.left, cmp.right
+	if _, ok := left.(valueOperand); ok {
+		left, right = right, left
+	}
+
+	po, ok := left.(pathOperand)
+	if !ok || len(po.path) != 1 {
+		return "", nil, false
+	}
+
+	vo, ok := right.(valueOperand)
+	if !ok {
+		return "", nil, false
+	}
+
+	return po.path[0].name, vo.value, true
+}
+
+func referencesOnly(c condition, attribute string) bool {
+	paths := conditionPaths(c)
+	if len(paths) == 0 {
+		return false
+	}
+	for _, p := range paths {
+		if len(p) != 1 || p[0].name != attribute {
+			return false
+		}
+	}
+	return true
+}
+
+func conditionPaths(c condition) []path {
+	var paths []path
+	collect := func(ops ...operand) {
+		for _, op := range ops {
+			switch o := op.(type) {
+			case pathOperand:
+				paths = append(paths, o.path)
+			case sizeOperand:
+				paths = append(paths, o.path)
+			}
+		}
+	}
+
+	switch cond := c.(type) {
+	case comparisonCondition:
+		collect(cond.left, cond.right)
+	case betweenCondition:
+		collect(cond.operand, cond.lower, cond.upper)
+	case inCondition:
+		collect(append([]operand{cond.operand}, cond.values...)...)
+	case functionCondition:
+		collect(cond.args...)
+	case missingCondition:
+		paths = append(paths, cond.path)
+	case andCondition:
+		paths = append(conditionPaths(cond.left), conditionPaths(cond.right)...)
+	case orCondition:
+		paths = append(conditionPaths(cond.left), conditionPaths(cond.right)...)
+	case notCondition:
+		paths = conditionPaths(cond.condition)
+	}
+
+	return paths
+}
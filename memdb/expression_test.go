@@ -0,0 +1,207 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+	"testing"
+)
+
+func TestFilterExpressionEvaluation(t *testing.T) {
+	db := newTestDB(t)
+	seedTestDB(t, db)
+
+	tests := []struct {
+		name       string
+		expression string
+		names      map[string]string
+		values     map[string]types.AttributeValue
+		expected   []string
+	}{
+		{
+			name:       "nested map path",
+			expression: "#profile.#address.#city = :city",
+			names:      map[string]string{"#profile": "profile", "#address": "address", "#city": "city"},
+			values:     map[string]types.AttributeValue{":city": &types.AttributeValueMemberS{Value: "Lisbon"}},
+			expected:   []string{"a#1"},
+		},
+		{
+			name:       "list index path",
+			expression: "scores[1] = :score",
+			values:     map[string]types.AttributeValue{":score": &types.AttributeValueMemberN{Value: "2"}},
+			expected:   []string{"a#1"},
+		},
+		{
+			name:       "size of list",
+			expression: "size(scores) >= :size",
+			values:     map[string]types.AttributeValue{":size": &types.AttributeValueMemberN{Value: "2"}},
+			expected:   []string{"a#1"},
+		},
+		{
+			name:       "size of string",
+			expression: "size(#name) = :size",
+			names:      map[string]string{"#name": "name"},
+			values:     map[string]types.AttributeValue{":size": &types.AttributeValueMemberN{Value: "4"}},
+			expected:   []string{"a#2"},
+		},
+		{
+			name:       "begins_with",
+			expression: "begins_with(#name, :prefix)",
+			names:      map[string]string{"#name": "name"},
+			values:     map[string]types.AttributeValue{":prefix": &types.AttributeValueMemberS{Value: "alpha"}},
+			expected:   []string{"a#1", "b#1"},
+		},
+		{
+			name:       "contains on string set",
+			expression: "contains(tags, :tag)",
+			values:     map[string]types.AttributeValue{":tag": &types.AttributeValueMemberS{Value: "blue"}},
+			expected:   []string{"a#1"},
+		},
+		{
+			name:       "contains on list",
+			expression: "contains(scores, :score)",
+			values:     map[string]types.AttributeValue{":score": &types.AttributeValueMemberN{Value: "5"}},
+			expected:   []string{"a#2"},
+		},
+		{
+			name:       "contains on string",
+			expression: "contains(#name, :part)",
+			names:      map[string]string{"#name": "name"},
+			values:     map[string]types.AttributeValue{":part": &types.AttributeValueMemberS{Value: "mm"}},
+			expected:   []string{"a#3"},
+		},
+		{
+			name:       "IN",
+			expression: "#name IN (:first, :second)",
+			names:      map[string]string{"#name": "name"},
+			values: map[string]types.AttributeValue{
+				":first":  &types.AttributeValueMemberS{Value: "beta"},
+				":second": &types.AttributeValueMemberS{Value: "alphabet"},
+			},
+			expected: []string{"a#2", "b#1"},
+		},
+		{
+			name:       "BETWEEN",
+			expression: "score BETWEEN :low AND :high",
+			values: map[string]types.AttributeValue{
+				":low":  &types.AttributeValueMemberN{Value: "10"},
+				":high": &types.AttributeValueMemberN{Value: "20"},
+			},
+			expected: []string{"a#1", "a#3"},
+		},
+		{
+			name:       "numbers compare numerically",
+			expression: "score > :score",
+			values:     map[string]types.AttributeValue{":score": &types.AttributeValueMemberN{Value: "9"}},
+			expected:   []string{"a#1", "a#2", "a#3"},
+		},
+		{
+			name:       "attribute_not_exists with NOT and OR",
+			expression: "attribute_not_exists(tags) AND NOT (#status = :closed OR pk = :b)",
+			names:      map[string]string{"#status": "status"},
+			values: map[string]types.AttributeValue{
+				":closed": &types.AttributeValueMemberS{Value: "closed"},
+				":b":      &types.AttributeValueMemberS{Value: "b"},
+			},
+			expected: []string{"a#3"},
+		},
+		{
+			name:       "attribute_type",
+			expression: "attribute_type(tags, :type)",
+			values:     map[string]types.AttributeValue{":type": &types.AttributeValueMemberS{Value: "SS"}},
+			expected:   []string{"a#1", "a#2"},
+		},
+		{
+			name:       "missing path never matches a comparison",
+			expression: "#missing.#path = :value",
+			names:      map[string]string{"#missing": "missing", "#path": "path"},
+			values:     map[string]types.AttributeValue{":value": &types.AttributeValueMemberS{Value: "x"}},
+			expected:   []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := db.Scan(context.Background(), &ddb.ScanInput{
+				TableName:                 aws.String(testTable),
+				FilterExpression:          aws.String(test.expression),
+				ExpressionAttributeNames:  test.names,
+				ExpressionAttributeValues: test.values,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if ids := sortedItemIDs(out.Items); !equalIDs(ids, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, ids)
+			}
+		})
+	}
+}
+
+func TestFilterExpressionValidation(t *testing.T) {
+	db := newTestDB(t)
+	seedTestDB(t, db)
+
+	tests := []struct {
+		name       string
+		expression string
+		names      map[string]string
+		values     map[string]types.AttributeValue
+	}{
+		{
+			name:       "syntax error",
+			expression: "score > ",
+		},
+		{
+			name:       "undefined value",
+			expression: "score > :missing",
+		},
+		{
+			name:       "undefined name",
+			expression: "#missing = :value",
+			values:     map[string]types.AttributeValue{":value": &types.AttributeValueMemberN{Value: "1"}},
+		},
+		{
+			name:       "unused value",
+			expression: "score > :score",
+			values: map[string]types.AttributeValue{
+				":score":  &types.AttributeValueMemberN{Value: "1"},
+				":unused": &types.AttributeValueMemberN{Value: "2"},
+			},
+		},
+		{
+			name:       "unknown function",
+			expression: "starts_with(#name, :prefix)",
+			names:      map[string]string{"#name": "name"},
+			values:     map[string]types.AttributeValue{":prefix": &types.AttributeValueMemberS{Value: "a"}},
+		},
+		{
+			name:       "BETWEEN with inverted bounds",
+			expression: "score BETWEEN :high AND :low",
+			values: map[string]types.AttributeValue{
+				":low":  &types.AttributeValueMemberN{Value: "10"},
+				":high": &types.AttributeValueMemberN{Value: "20"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := db.Scan(context.Background(), &ddb.ScanInput{
+				TableName:                 aws.String(testTable),
+				FilterExpression:          aws.String(test.expression),
+				ExpressionAttributeNames:  test.names,
+				ExpressionAttributeValues: test.values,
+			})
+
+			var apiErr smithy.APIError
+			if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "ValidationException" {
+				t.Fatalf("expected a ValidationException, got %v", err)
+			}
+		})
+	}
+}
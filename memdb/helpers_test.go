@@ -0,0 +1,159 @@
+package memdb
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"sort"
+	"testing"
+)
+
+const testTable = "items"
+
+func newTestDB(t *testing.T, options ...Option) *DB {
+	t.Helper()
+
+	db := New(options...)
+	_, err := db.CreateTable(context.Background(), &ddb.CreateTableInput{
+		TableName: aws.String(testTable),
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("pk"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("sk"), AttributeType: types.ScalarAttributeTypeN},
+			{AttributeName: aws.String("status"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("score"), AttributeType: types.ScalarAttributeTypeN},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("pk"), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String("sk"), KeyType: types.KeyTypeRange},
+		},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{{
+			IndexName: aws.String("by-status"),
+			KeySchema: []types.KeySchemaElement{
+				{AttributeName: aws.String("status"), KeyType: types.KeyTypeHash},
+				{AttributeName: aws.String("sk"), KeyType: types.KeyTypeRange},
+			},
+			Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+		}},
+		LocalSecondaryIndexes: []types.LocalSecondaryIndex{{
+			IndexName: aws.String("by-score"),
+			KeySchema: []types.KeySchemaElement{
+				{AttributeName: aws.String("pk"), KeyType: types.KeyTypeHash},
+				{AttributeName: aws.String("score"), KeyType: types.KeyTypeRange},
+			},
+			Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+		}},
+		BillingMode: types.BillingModePayPerRequest,
+	})
+	if err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	return db
+}
+
+func seedTestDB(t *testing.T, db *DB) {
+	t.Helper()
+
+	items := []map[string]types.AttributeValue{
+		{
+			"pk":     &types.AttributeValueMemberS{Value: "a"},
+			"sk":     &types.AttributeValueMemberN{Value: "1"},
+			"status": &types.AttributeValueMemberS{Value: "open"},
+			"score":  &types.AttributeValueMemberN{Value: "10"},
+			"name":   &types.AttributeValueMemberS{Value: "alpha"},
+			"tags":   &types.AttributeValueMemberSS{Value: []string{"red", "blue"}},
+			"profile": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"address": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"city": &types.AttributeValueMemberS{Value: "Lisbon"},
+				}},
+			}},
+			"scores": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+				&types.AttributeValueMemberN{Value: "1"},
+				&types.AttributeValueMemberN{Value: "2"},
+				&types.AttributeValueMemberN{Value: "3"},
+			}},
+		},
+		{
+			"pk":     &types.AttributeValueMemberS{Value: "a"},
+			"sk":     &types.AttributeValueMemberN{Value: "2"},
+			"status": &types.AttributeValueMemberS{Value: "closed"},
+			"score":  &types.AttributeValueMemberN{Value: "30"},
+			"name":   &types.AttributeValueMemberS{Value: "beta"},
+			"tags":   &types.AttributeValueMemberSS{Value: []string{"green"}},
+			"profile": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"address": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"city": &types.AttributeValueMemberS{Value: "Porto"},
+				}},
+			}},
+			"scores": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+				&types.AttributeValueMemberN{Value: "5"},
+			}},
+		},
+		{
+			"pk":     &types.AttributeValueMemberS{Value: "a"},
+			"sk":     &types.AttributeValueMemberN{Value: "3"},
+			"status": &types.AttributeValueMemberS{Value: "open"},
+			"score":  &types.AttributeValueMemberN{Value: "20"},
+			"name":   &types.AttributeValueMemberS{Value: "gamma"},
+		},
+		{
+			"pk":     &types.AttributeValueMemberS{Value: "b"},
+			"sk":     &types.AttributeValueMemberN{Value: "1"},
+			"status": &types.AttributeValueMemberS{Value: "open"},
+			"score":  &types.AttributeValueMemberN{Value: "5"},
+			"name":   &types.AttributeValueMemberS{Value: "alphabet"},
+		},
+	}
+
+	for _, item := range items {
+		if _, err := db.PutItem(context.Background(), &ddb.PutItemInput{TableName: aws.String(testTable), Item: item}); err != nil {
+			t.Fatalf("failed to seed item: %v", err)
+		}
+	}
+}
+
+func itemIDs(items []map[string]types.AttributeValue) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		pk, _ := item["pk"].(*types.AttributeValueMemberS)
+		sk, _ := item["sk"].(*types.AttributeValueMemberN)
+		if pk == nil || sk == nil {
+			ids = append(ids, "?")
+			continue
+		}
+		ids = append(ids, pk.Value+"#"+sk.Value)
+	}
+	return ids
+}
+
+func sortedItemIDs(items []map[string]types.AttributeValue) []string {
+	ids := itemIDs(items)
+	sort.Strings(ids)
+	return ids
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalItemSets(a, b map[string]map[string]types.AttributeValue) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, item := range a {
+		other, ok := b[key]
+		if !ok || !equalValues(&types.AttributeValueMemberM{Value: item}, &types.AttributeValueMemberM{Value: other}) {
+			return false
+		}
+	}
+	return true
+}
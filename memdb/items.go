@@ -0,0 +1,252 @@
+package memdb
+
+import (
+	"context"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func (db *DB) GetItem(ctx context.Context, in *ddb.GetItemInput, optFns ...func(*ddb.Options)) (*ddb.GetItemOutput, error) {
+	if db.throttled("GetItem") {
+		return nil, throughputExceeded()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(in.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := t.lookupKey(in.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	exprCtx := newExprContext(in.ExpressionAttributeNames, nil)
+	projection, err := parseProjectionExpression(in.ProjectionExpression, exprCtx)
+	if err != nil {
+		return nil, err
+	}
+	if err := exprCtx.checkUnused(); err != nil {
+		return nil, err
+	}
+
+	return &ddb.GetItemOutput{Item: projectItem(t.items[key], projection)}, nil
+}
+
+func (db *DB) PutItem(ctx context.Context, in *ddb.PutItemInput, optFns ...func(*ddb.Options)) (*ddb.PutItemOutput, error) {
+	if db.throttled("PutItem") {
+		return nil, throughputExceeded()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(in.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	returnValues, err := checkReturnValues(in.ReturnValues, types.ReturnValueAllOld)
+	if err != nil {
+		return nil, err
+	}
+
+	exprCtx := newExprContext(in.ExpressionAttributeNames, in.ExpressionAttributeValues)
+	cond, err := parseConditionExpression("ConditionExpression", in.ConditionExpression, exprCtx)
+	if err != nil {
+		return nil, err
+	}
+	if err := exprCtx.checkUnused(); err != nil {
+		return nil, err
+	}
+
+	key, err := t.validateItem(in.Item)
+	if err != nil {
+		return nil, err
+	}
+
+	old := t.items[key]
+	if err := checkCondition(old, cond); err != nil {
+		return nil, err
+	}
+
+	t.items[key] = copyItem(in.Item)
+
+	out := &ddb.PutItemOutput{}
+	if returnValues == types.ReturnValueAllOld {
+		out.Attributes = copyItem(old)
+	}
+	return out, nil
+}
+
+func (db *DB) UpdateItem(ctx context.Context, in *ddb.UpdateItemInput, optFns ...func(*ddb.Options)) (*ddb.UpdateItemOutput, error) {
+	if db.throttled("UpdateItem") {
+		return nil, throughputExceeded()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(in.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	returnValues, err := checkReturnValues(in.ReturnValues,
+		types.ReturnValueAllOld, types.ReturnValueUpdatedOld, types.ReturnValueAllNew, types.ReturnValueUpdatedNew)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := t.lookupKey(in.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	exprCtx := newExprContext(in.ExpressionAttributeNames, in.ExpressionAttributeValues)
+	actions, err := parseUpdateExpression(in.UpdateExpression, exprCtx)
+	if err != nil {
+		return nil, err
+	}
+	cond, err := parseConditionExpression("ConditionExpression", in.ConditionExpression, exprCtx)
+	if err != nil {
+		return nil, err
+	}
+	if err := exprCtx.checkUnused(); err != nil {
+		return nil, err
+	}
+
+	old := t.items[key]
+	if err := checkCondition(old, cond); err != nil {
+		return nil, err
+	}
+
+	item, updated, err := t.update(old, in.Key, actions)
+	if err != nil {
+		return nil, err
+	}
+	t.items[key] = item
+
+	out := &ddb.UpdateItemOutput{}
+	switch returnValues {
+	case types.ReturnValueAllOld:
+		out.Attributes = copyItem(old)
+	case types.ReturnValueAllNew:
+		out.Attributes = copyItem(item)
+	case types.ReturnValueUpdatedOld:
+		out.Attributes = selectAttributes(old, updated)
+	case types.ReturnValueUpdatedNew:
+		out.Attributes = selectAttributes(item, updated)
+	}
+	return out, nil
+}
+
+func (db *DB) DeleteItem(ctx context.Context, in *ddb.DeleteItemInput, optFns ...func(*ddb.Options)) (*ddb.DeleteItemOutput, error) {
+	if db.throttled("DeleteItem") {
+		return nil, throughputExceeded()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(in.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	returnValues, err := checkReturnValues(in.ReturnValues, types.ReturnValueAllOld)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := t.lookupKey(in.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	exprCtx := newExprContext(in.ExpressionAttributeNames, in.ExpressionAttributeValues)
+	cond, err := parseConditionExpression("ConditionExpression", in.ConditionExpression, exprCtx)
+	if err != nil {
+		return nil, err
+	}
+	if err := exprCtx.checkUnused(); err != nil {
+		return nil, err
+	}
+
+	old := t.items[key]
+	if err := checkCondition(old, cond); err != nil {
+		return nil, err
+	}
+
+	delete(t.items, key)
+
+	out := &ddb.DeleteItemOutput{}
+	if returnValues == types.ReturnValueAllOld {
+		out.Attributes = copyItem(old)
+	}
+	return out, nil
+}
+
+func (t *table) update(old, key map[string]types.AttributeValue, actions *updateActions) (map[string]types.AttributeValue, []string, error) {
+	base := old
+	if base == nil {
+		base = key
+	}
+
+	item, updated, err := applyUpdate(base, actions, t.keyAttributes())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := t.validateItem(item); err != nil {
+		return nil, nil, err
+	}
+
+	return item, updated, nil
+}
+
+func checkCondition(item map[string]types.AttributeValue, cond condition) error {
+	if cond == nil {
+		return nil
+	}
+
+	ok, err := evalCondition(item, cond)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return conditionalCheckFailed()
+	}
+	return nil
+}
+
+func checkReturnValues(returnValues types.ReturnValue, allowed ...types.ReturnValue) (types.ReturnValue, error) {
+	if returnValues == "" || returnValues == types.ReturnValueNone {
+		return types.ReturnValueNone, nil
+	}
+
+	for _, rv := range allowed {
+		if returnValues == rv {
+			return rv, nil
+		}
+	}
+
+	return "", validationError("Return values set to invalid value")
+}
+
+func selectAttributes(item map[string]types.AttributeValue, names []string) map[string]types.AttributeValue {
+	if item == nil {
+		return nil
+	}
+
+	selected := make(map[string]types.AttributeValue)
+	for _, name := range names {
+		if value, ok := item[name]; ok {
+			selected[name] = copyValue(value)
+		}
+	}
+	return selected
+}
@@ -0,0 +1,169 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"testing"
+)
+
+func testKey(pk, sk string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"pk": &types.AttributeValueMemberS{Value: pk},
+		"sk": &types.AttributeValueMemberN{Value: sk},
+	}
+}
+
+func TestConditionalWrites(t *testing.T) {
+	tests := []struct {
+		name   string
+		write  func(db *DB) error
+		failed bool
+	}{
+		{
+			name: "put attribute_not_exists on an existing item",
+			write: func(db *DB) error {
+				_, err := db.PutItem(context.Background(), &ddb.PutItemInput{
+					TableName:           aws.String(testTable),
+					Item:                testKey("a", "1"),
+					ConditionExpression: aws.String("attribute_not_exists(pk)"),
+				})
+				return err
+			},
+			failed: true,
+		},
+		{
+			name: "put attribute_not_exists on a new item",
+			write: func(db *DB) error {
+				_, err := db.PutItem(context.Background(), &ddb.PutItemInput{
+					TableName:           aws.String(testTable),
+					Item:                testKey("c", "1"),
+					ConditionExpression: aws.String("attribute_not_exists(pk)"),
+				})
+				return err
+			},
+		},
+		{
+			name: "update with a failing comparison",
+			write: func(db *DB) error {
+				_, err := db.UpdateItem(context.Background(), &ddb.UpdateItemInput{
+					TableName:                 aws.String(testTable),
+					Key:                       testKey("a", "1"),
+					UpdateExpression:          aws.String("SET score = score + :inc"),
+					ConditionExpression:       aws.String("score > :min"),
+					ExpressionAttributeValues: map[string]types.AttributeValue{":inc": &types.AttributeValueMemberN{Value: "1"}, ":min": &types.AttributeValueMemberN{Value: "100"}},
+				})
+				return err
+			},
+			failed: true,
+		},
+		{
+			name: "update with attribute_exists on a missing item",
+			write: func(db *DB) error {
+				_, err := db.UpdateItem(context.Background(), &ddb.UpdateItemInput{
+					TableName:                 aws.String(testTable),
+					Key:                       testKey("z", "9"),
+					UpdateExpression:          aws.String("SET score = :score"),
+					ConditionExpression:       aws.String("attribute_exists(pk)"),
+					ExpressionAttributeValues: map[string]types.AttributeValue{":score": &types.AttributeValueMemberN{Value: "1"}},
+				})
+				return err
+			},
+			failed: true,
+		},
+		{
+			name: "delete with a failing nested condition",
+			write: func(db *DB) error {
+				_, err := db.DeleteItem(context.Background(), &ddb.DeleteItemInput{
+					TableName:                 aws.String(testTable),
+					Key:                       testKey("a", "1"),
+					ConditionExpression:       aws.String("profile.address.city = :city"),
+					ExpressionAttributeValues: map[string]types.AttributeValue{":city": &types.AttributeValueMemberS{Value: "Porto"}},
+				})
+				return err
+			},
+			failed: true,
+		},
+		{
+			name: "delete with a passing condition",
+			write: func(db *DB) error {
+				_, err := db.DeleteItem(context.Background(), &ddb.DeleteItemInput{
+					TableName:                 aws.String(testTable),
+					Key:                       testKey("a", "1"),
+					ConditionExpression:       aws.String("contains(tags, :tag)"),
+					ExpressionAttributeValues: map[string]types.AttributeValue{":tag": &types.AttributeValueMemberS{Value: "red"}},
+				})
+				return err
+			},
+		},
+		{
+			name: "transaction with one failing condition",
+			write: func(db *DB) error {
+				_, err := db.TransactWriteItems(context.Background(), &ddb.TransactWriteItemsInput{
+					TransactItems: []types.TransactWriteItem{
+						{Put: &types.Put{TableName: aws.String(testTable), Item: testKey("c", "1")}},
+						{ConditionCheck: &types.ConditionCheck{
+							TableName:           aws.String(testTable),
+							Key:                 testKey("a", "1"),
+							ConditionExpression: aws.String("attribute_not_exists(pk)"),
+						}},
+					},
+				})
+				return err
+			},
+			failed: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := newTestDB(t)
+			seedTestDB(t, db)
+			before := snapshotItems(db.tables[testTable].items)
+
+			err := test.write(db)
+
+			var conditionFailed *types.ConditionalCheckFailedException
+			var canceled *types.TransactionCanceledException
+			switch {
+			case !test.failed && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.failed && !errors.As(err, &conditionFailed) && !errors.As(err, &canceled):
+				t.Fatalf("expected a conditional check failure, got %v", err)
+			case test.failed && !equalItemSets(before, snapshotItems(db.tables[testTable].items)):
+				t.Error("a failed conditional write modified the table")
+			}
+		})
+	}
+}
+
+func TestConditionalCheckFailedReturnValues(t *testing.T) {
+	db := newTestDB(t)
+	seedTestDB(t, db)
+
+	_, err := db.TransactWriteItems(context.Background(), &ddb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{{
+			ConditionCheck: &types.ConditionCheck{
+				TableName:                           aws.String(testTable),
+				Key:                                 testKey("a", "2"),
+				ConditionExpression:                 aws.String("attribute_not_exists(pk)"),
+				ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+			},
+		}},
+	})
+
+	var canceled *types.TransactionCanceledException
+	if !errors.As(err, &canceled) {
+		t.Fatalf("expected a transaction cancellation, got %v", err)
+	}
+
+	if len(canceled.CancellationReasons) != 1 || aws.ToString(canceled.CancellationReasons[0].Code) != "ConditionalCheckFailed" {
+		t.Fatalf("unexpected cancellation reasons: %+v", canceled.CancellationReasons)
+	}
+
+	if ids := itemIDs([]map[string]types.AttributeValue{canceled.CancellationReasons[0].Item}); !equalIDs(ids, []string{"a#2"}) {
+		t.Errorf("expected the old item to be returned, got %v", ids)
+	}
+}
@@ -0,0 +1,184 @@
+package memdb
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokQuotedIdent
+	tokNamePlaceholder
+	tokValuePlaceholder
+	tokParam
+	tokString
+	tokNumber
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) is(kind tokenKind, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+func (t token) isKeyword(keyword string) bool {
+	return t.kind == tokIdent && strings.EqualFold(t.text, keyword)
+}
+
+func tokenize(input string, partiQL bool) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	i := 0
+
+	for i < len(runes) {
+		r := runes[i]
+		start := i
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+
+		case r == '#' && !partiQL:
+			i++
+			for i < len(runes) && isIdentRune(runes[i]) {
+				i++
+			}
+			if i == start+1 {
+				return nil, fmt.Errorf("invalid name placeholder at position %d", start)
+			}
+			tokens = append(tokens, token{kind: tokNamePlaceholder, text: string(runes[start:i]), pos: start})
+
+		case r == ':' && !partiQL:
+			i++
+			for i < len(runes) && isIdentRune(runes[i]) {
+				i++
+			}
+			if i == start+1 {
+				return nil, fmt.Errorf("invalid value placeholder at position %d", start)
+			}
+			tokens = append(tokens, token{kind: tokValuePlaceholder, text: string(runes[start:i]), pos: start})
+
+		case r == '?' && partiQL:
+			i++
+			tokens = append(tokens, token{kind: tokParam, text: "?", pos: start})
+
+		case r == '\'' && partiQL:
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						sb.WriteRune('\'')
+						i += 2
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated string literal at position %d", start)
+			}
+			tokens = append(tokens, token{kind: tokString, text: sb.String(), pos: start})
+
+		case r == '"' && partiQL:
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '"' {
+					if i+1 < len(runes) && runes[i+1] == '"' {
+						sb.WriteRune('"')
+						i += 2
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated identifier at position %d", start)
+			}
+			tokens = append(tokens, token{kind: tokQuotedIdent, text: sb.String(), pos: start})
+
+		case unicode.IsDigit(r) || (r == '-' && partiQL && i+1 < len(runes) && unicode.IsDigit(runes[i+1]) && !lastIsOperand(tokens)):
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || runes[i] == 'e' || runes[i] == 'E' ||
+				((runes[i] == '-' || runes[i] == '+') && (runes[i-1] == 'e' || runes[i-1] == 'E'))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: string(runes[start:i]), pos: start})
+
+		case isIdentStartRune(r):
+			for i < len(runes) && isIdentRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: string(runes[start:i]), pos: start})
+
+		default:
+			two := ""
+			if i+1 < len(runes) {
+				two = string(runes[i : i+2])
+			}
+
+			switch two {
+			case "<>", "<=", ">=", "!=", "<<", ">>":
+				tokens = append(tokens, token{kind: tokPunct, text: two, pos: start})
+				i += 2
+				continue
+			}
+
+			if strings.ContainsRune("()[],.=<>+-*{}:", r) {
+				tokens = append(tokens, token{kind: tokPunct, text: string(r), pos: start})
+				i++
+				continue
+			}
+
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, start)
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokEOF, pos: len(runes)})
+	return tokens, nil
+}
+
+func lastIsOperand(tokens []token) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+
+	last := tokens[len(tokens)-1]
+	switch last.kind {
+	case tokIdent, tokQuotedIdent, tokNumber, tokString, tokParam:
+		return true
+	case tokPunct:
+		return last.text == ")" || last.text == "]"
+	default:
+		return false
+	}
+}
+
+func isIdentStartRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,806 @@
+package memdb
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type exprContext struct {
+	names      map[string]string
+	values     map[string]types.AttributeValue
+	usedNames  map[string]bool
+	usedValues map[string]bool
+}
+
+func newExprContext(names map[string]string, values map[string]types.AttributeValue) *exprContext {
+	return &exprContext{
+		names:      names,
+		values:     values,
+		usedNames:  make(map[string]bool),
+		usedValues: make(map[string]bool),
+	}
+}
+
+func (c *exprContext) checkUnused() error {
+	var unusedNames, unusedValues []string
+	for name := range c.names {
+		if !c.usedNames[name] {
+			unusedNames = append(unusedNames, name)
+		}
+	}
+	for value := range c.values {
+		if !c.usedValues[value] {
+			unusedValues = append(unusedValues, value)
+		}
+	}
+
+	if len(unusedNames) > 0 {
+		sort.Strings(unusedNames)
+		return validationError("Value provided in ExpressionAttributeNames unused in expressions: keys: {%s}", strings.Join(unusedNames, ", "))
+	}
+
+	if len(unusedValues) > 0 {
+		sort.Strings(unusedValues)
+		return validationError("Value provided in ExpressionAttributeValues unused in expressions: keys: {%s}", strings.Join(unusedValues, ", "))
+	}
+
+	return nil
+}
+
+type parser struct {
+	kind      string
+	tokens    []token
+	pos       int
+	ctx       *exprContext
+	partiQL   bool
+	params    []types.AttributeValue
+	nextParam int
+}
+
+func newParser(kind, input string, ctx *exprContext) (*parser, error) {
+	tokens, err := tokenize(input, false)
+	if err != nil {
+		return nil, validationError("Invalid %s: %s", kind, err)
+	}
+	return &parser{kind: kind, tokens: tokens, ctx: ctx}, nil
+}
+
+func newPartiQLParser(input string, params []types.AttributeValue) (*parser, error) {
+	tokens, err := tokenize(input, true)
+	if err != nil {
+		return nil, validationError("Statement wasn't well formed, can't be processed: %s", err)
+	}
+	return &parser{
+		kind:    "PartiQL",
+		tokens:  tokens,
+		ctx:     newExprContext(nil, nil),
+		partiQL: true,
+		params:  params,
+	}, nil
+}
+
+func parseConditionExpression(kind string, input *string, ctx *exprContext) (condition, error) {
+	if input == nil {
+		return nil, nil
+	}
+
+	p, err := newParser(kind, *input, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind == tokEOF {
+		return nil, validationError("Invalid %s: The expression can not be empty;", kind)
+	}
+
+	c, err := p.parseCondition()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expectEOF(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func parseUpdateExpression(input *string, ctx *exprContext) (*updateActions, error) {
+	if input == nil {
+		return &updateActions{}, nil
+	}
+
+	p, err := newParser("UpdateExpression", *input, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.parseUpdate()
+}
+
+func parseProjectionExpression(input *string, ctx *exprContext) ([]path, error) {
+	if input == nil {
+		return nil, nil
+	}
+
+	p, err := newParser("ProjectionExpression", *input, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := p.parsePathList()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expectEOF(); err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+	if p.partiQL {
+		return validationError("Statement wasn't well formed, can't be processed: %s", msg)
+	}
+	return validationError("Invalid %s: %s", p.kind, msg)
+}
+
+func (p *parser) unexpected(t token) error {
+	if t.kind == tokEOF {
+		return p.errorf("Syntax error; token: <EOF>, near: position %d", t.pos)
+	}
+	return p.errorf("Syntax error; token: \"%s\", near: position %d", t.text, t.pos)
+}
+
+func (p *parser) peek() token {
+	return p.peekAt(0)
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) acceptPunct(text string) bool {
+	if p.peek().is(tokPunct, text) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) acceptKeyword(keyword string) bool {
+	if p.peek().isKeyword(keyword) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectPunct(text string) error {
+	if t := p.next(); !t.is(tokPunct, text) {
+		return p.unexpected(t)
+	}
+	return nil
+}
+
+func (p *parser) expectKeyword(keyword string) error {
+	if t := p.next(); !t.isKeyword(keyword) {
+		return p.unexpected(t)
+	}
+	return nil
+}
+
+func (p *parser) expectEOF() error {
+	if t := p.peek(); t.kind != tokEOF {
+		return p.unexpected(t)
+	}
+	return nil
+}
+
+func (p *parser) parseCondition() (condition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptKeyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orCondition{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (condition, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptKeyword("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andCondition{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (condition, error) {
+	if p.acceptKeyword("NOT") {
+		c, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notCondition{condition: c}, nil
+	}
+	return p.parsePredicate()
+}
+
+func (p *parser) parsePredicate() (condition, error) {
+	if p.acceptPunct("(") {
+		c, err := p.parseCondition()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+
+	if t := p.peek(); t.kind == tokIdent && p.peekAt(1).is(tokPunct, "(") {
+		if name := strings.ToLower(t.text); isConditionFunction(name) {
+			return p.parseFunction(name)
+		}
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	switch {
+	case t.kind == tokPunct && isComparator(t.text, p.partiQL):
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		operator := t.text
+		if operator == "!=" {
+			operator = "<>"
+		}
+		return comparisonCondition{operator: operator, left: left, right: right}, nil
+
+	case t.isKeyword("BETWEEN"):
+		p.next()
+		lower, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectKeyword("AND"); err != nil {
+			return nil, err
+		}
+		upper, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return betweenCondition{operand: left, lower: lower, upper: upper}, nil
+
+	case t.isKeyword("IN"):
+		p.next()
+		closing := ")"
+		if p.partiQL && p.acceptPunct("[") {
+			closing = "]"
+		} else if err := p.expectPunct("("); err != nil {
+			return nil, err
+		}
+		var values []operand
+		for {
+			value, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+		if err := p.expectPunct(closing); err != nil {
+			return nil, err
+		}
+		if len(values) > 100 {
+			return nil, p.errorf("The IN operator is provided with too many operands; number of operands: %d", len(values))
+		}
+		return inCondition{operand: left, values: values}, nil
+
+	case p.partiQL && t.isKeyword("IS"):
+		p.next()
+		missing := !p.acceptKeyword("NOT")
+		if err := p.expectKeyword("MISSING"); err != nil {
+			return nil, err
+		}
+		po, ok := left.(pathOperand)
+		if !ok {
+			return nil, p.errorf("IS MISSING requires an attribute path")
+		}
+		return missingCondition{path: po.path, missing: missing}, nil
+	}
+
+	return nil, p.unexpected(t)
+}
+
+func (p *parser) parseFunction(name string) (condition, error) {
+	p.next()
+	p.next()
+
+	var args []operand
+	if !p.peek().is(tokPunct, ")") {
+		for {
+			arg, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+	}
+
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+
+	expected := 2
+	if name == "attribute_exists" || name == "attribute_not_exists" {
+		expected = 1
+	}
+
+	if len(args) != expected {
+		return nil, p.errorf("Incorrect number of operands for operator or function; operator or function: %s, number of operands: %d", name, len(args))
+	}
+
+	if _, ok := args[0].(pathOperand); !ok {
+		return nil, p.errorf("Operator or function requires a document path; operator or function: %s", name)
+	}
+
+	return functionCondition{name: name, args: args}, nil
+}
+
+func (p *parser) parseOperand() (operand, error) {
+	t := p.peek()
+
+	switch {
+	case t.isKeyword("size") && p.peekAt(1).is(tokPunct, "("):
+		p.next()
+		p.next()
+		path, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return sizeOperand{path: path}, nil
+
+	case t.kind == tokValuePlaceholder:
+		p.next()
+		value, ok := p.ctx.values[t.text]
+		if !ok {
+			return nil, p.errorf("An expression attribute value used in expression is not defined; attribute value: %s", t.text)
+		}
+		p.ctx.usedValues[t.text] = true
+		return valueOperand{value: value}, nil
+
+	case p.partiQL:
+		value, ok, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return valueOperand{value: value}, nil
+		}
+	}
+
+	path, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+	return pathOperand{path: path}, nil
+}
+
+func (p *parser) parseLiteral() (types.AttributeValue, bool, error) {
+	t := p.peek()
+
+	switch {
+	case t.kind == tokParam:
+		p.next()
+		if p.nextParam >= len(p.params) {
+			return nil, false, validationError("Number of parameters in request and statement don't match.")
+		}
+		value := p.params[p.nextParam]
+		p.nextParam++
+		return value, true, nil
+
+	case t.kind == tokString:
+		p.next()
+		return &types.AttributeValueMemberS{Value: t.text}, true, nil
+
+	case t.kind == tokNumber:
+		p.next()
+		if _, ok := parseNumber(t.text); !ok {
+			return nil, false, p.unexpected(t)
+		}
+		return &types.AttributeValueMemberN{Value: t.text}, true, nil
+
+	case t.isKeyword("TRUE"), t.isKeyword("FALSE"):
+		p.next()
+		return &types.AttributeValueMemberBOOL{Value: strings.EqualFold(t.text, "TRUE")}, true, nil
+
+	case t.isKeyword("NULL"):
+		p.next()
+		return &types.AttributeValueMemberNULL{Value: true}, true, nil
+
+	case t.is(tokPunct, "{"):
+		p.next()
+		m := make(map[string]types.AttributeValue)
+		if p.acceptPunct("}") {
+			return &types.AttributeValueMemberM{Value: m}, true, nil
+		}
+		for {
+			key := p.next()
+			if key.kind != tokString && key.kind != tokQuotedIdent {
+				return nil, false, p.unexpected(key)
+			}
+			if err := p.expectPunct(":"); err != nil {
+				return nil, false, err
+			}
+			value, err := p.expectLiteral()
+			if err != nil {
+				return nil, false, err
+			}
+			m[key.text] = value
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+		if err := p.expectPunct("}"); err != nil {
+			return nil, false, err
+		}
+		return &types.AttributeValueMemberM{Value: m}, true, nil
+
+	case t.is(tokPunct, "["):
+		p.next()
+		var l []types.AttributeValue
+		if !p.peek().is(tokPunct, "]") {
+			for {
+				value, err := p.expectLiteral()
+				if err != nil {
+					return nil, false, err
+				}
+				l = append(l, value)
+				if !p.acceptPunct(",") {
+					break
+				}
+			}
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return nil, false, err
+		}
+		return &types.AttributeValueMemberL{Value: l}, true, nil
+
+	case t.is(tokPunct, "<<"):
+		p.next()
+		var elems []types.AttributeValue
+		for {
+			value, err := p.expectLiteral()
+			if err != nil {
+				return nil, false, err
+			}
+			elems = append(elems, value)
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+		if err := p.expectPunct(">>"); err != nil {
+			return nil, false, err
+		}
+		set, err := literalSet(elems)
+		if err != nil {
+			return nil, false, p.errorf("%s", err)
+		}
+		return set, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func (p *parser) expectLiteral() (types.AttributeValue, error) {
+	value, ok, err := p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, p.unexpected(p.peek())
+	}
+	return value, nil
+}
+
+func (p *parser) parsePath() (path, error) {
+	name, err := p.parsePathName()
+	if err != nil {
+		return nil, err
+	}
+
+	result := path{{name: name}}
+	for {
+		switch {
+		case p.acceptPunct("."):
+			name, err := p.parsePathName()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, pathElem{name: name})
+
+		case p.peek().is(tokPunct, "["):
+			p.next()
+			t := p.next()
+			if t.kind != tokNumber {
+				return nil, p.unexpected(t)
+			}
+			index, err := strconv.Atoi(t.text)
+			if err != nil || index < 0 {
+				return nil, p.errorf("Invalid list index; index: %s", t.text)
+			}
+			if err := p.expectPunct("]"); err != nil {
+				return nil, err
+			}
+			result = append(result, pathElem{index: index, isIndex: true})
+
+		default:
+			return result, nil
+		}
+	}
+}
+
+func (p *parser) parsePathName() (string, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokNamePlaceholder:
+		name, ok := p.ctx.names[t.text]
+		if !ok {
+			return "", p.errorf("An expression attribute name used in the document path is not defined; attribute name: %s", t.text)
+		}
+		p.ctx.usedNames[t.text] = true
+		return name, nil
+	case tokIdent, tokQuotedIdent:
+		return t.text, nil
+	default:
+		return "", p.unexpected(t)
+	}
+}
+
+func (p *parser) parsePathList() ([]path, error) {
+	var paths []path
+	for {
+		path, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+		if !p.acceptPunct(",") {
+			return paths, nil
+		}
+	}
+}
+
+func (p *parser) parseUpdate() (*updateActions, error) {
+	actions := &updateActions{}
+	seen := make(map[string]bool)
+
+	for p.peek().kind != tokEOF {
+		t := p.next()
+		clause := strings.ToUpper(t.text)
+		if t.kind != tokIdent || (clause != "SET" && clause != "REMOVE" && clause != "ADD" && clause != "DELETE") {
+			return nil, p.unexpected(t)
+		}
+
+		if seen[clause] {
+			return nil, p.errorf("The \"%s\" section can only be used once in an update expression;", clause)
+		}
+		seen[clause] = true
+
+		if err := p.parseUpdateClause(clause, actions); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(seen) == 0 {
+		return nil, p.errorf("The expression can not be empty;")
+	}
+
+	return actions, nil
+}
+
+func (p *parser) parseUpdateClause(clause string, actions *updateActions) error {
+	for {
+		path, err := p.parsePath()
+		if err != nil {
+			return err
+		}
+
+		switch clause {
+		case "SET":
+			if err := p.expectPunct("="); err != nil {
+				return err
+			}
+			value, err := p.parseSetValue()
+			if err != nil {
+				return err
+			}
+			actions.set = append(actions.set, setAction{path: path, value: value})
+
+		case "REMOVE":
+			actions.remove = append(actions.remove, path)
+
+		case "ADD", "DELETE":
+			value, err := p.parseOperand()
+			if err != nil {
+				return err
+			}
+			if _, ok := value.(valueOperand); !ok {
+				return p.errorf("Incorrect operand type for operator or function; operator: %s, operand type: PATH", clause)
+			}
+			if clause == "ADD" {
+				actions.add = append(actions.add, setAction{path: path, value: value})
+			} else {
+				actions.delete = append(actions.delete, setAction{path: path, value: value})
+			}
+		}
+
+		if !p.acceptPunct(",") {
+			return nil
+		}
+	}
+}
+
+func (p *parser) parseSetValue() (operand, error) {
+	left, err := p.parseSetTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.is(tokPunct, "+") || t.is(tokPunct, "-") {
+		p.next()
+		right, err := p.parseSetTerm()
+		if err != nil {
+			return nil, err
+		}
+		return arithmeticOperand{operator: t.text, left: left, right: right}, nil
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseSetTerm() (operand, error) {
+	t := p.peek()
+	if t.kind != tokIdent || !p.peekAt(1).is(tokPunct, "(") {
+		return p.parseOperand()
+	}
+
+	switch strings.ToLower(t.text) {
+	case "if_not_exists":
+		p.next()
+		p.next()
+		path, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(","); err != nil {
+			return nil, err
+		}
+		fallback, err := p.parseSetTerm()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return ifNotExistsOperand{path: path, fallback: fallback}, nil
+
+	case "list_append":
+		p.next()
+		p.next()
+		left, err := p.parseSetTerm()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(","); err != nil {
+			return nil, err
+		}
+		right, err := p.parseSetTerm()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return listAppendOperand{left: left, right: right}, nil
+	}
+
+	return p.parseOperand()
+}
+
+func isConditionFunction(name string) bool {
+	switch name {
+	case "attribute_exists", "attribute_not_exists", "attribute_type", "begins_with", "contains":
+		return true
+	default:
+		return false
+	}
+}
+
+func isComparator(text string, partiQL bool) bool {
+	switch text {
+	case "=", "<>", "<", "<=", ">", ">=":
+		return true
+	case "!=":
+		return partiQL
+	default:
+		return false
+	}
+}
+
+func literalSet(elems []types.AttributeValue) (types.AttributeValue, error) {
+	setType := ""
+	for _, elem := range elems {
+		elemType := typeName(elem)
+		switch elemType {
+		case "S", "N", "B":
+		default:
+			return nil, fmt.Errorf("sets can only contain strings, numbers or binaries; found: %s", elemType)
+		}
+		if setType != "" && setType != elemType+"S" {
+			return nil, fmt.Errorf("sets can only contain elements of a single type")
+		}
+		setType = elemType + "S"
+	}
+
+	seen := make(map[string]bool)
+	var unique []types.AttributeValue
+	for _, elem := range elems {
+		k, _ := keyString(elem)
+		if !seen[k] {
+			seen[k] = true
+			unique = append(unique, elem)
+		}
+	}
+
+	return buildSet(setType, unique), nil
+}
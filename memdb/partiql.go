@@ -0,0 +1,676 @@
+package memdb
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"strconv"
+	"strings"
+)
+
+const maxBatchStatements = 25
+
+type statement struct {
+	kind       string
+	tableName  string
+	indexName  string
+	projection []path
+	where      condition
+	item       map[string]types.AttributeValue
+	actions    *updateActions
+}
+
+type statementPage struct {
+	statement string
+	lastKey   map[string]types.AttributeValue
+}
+
+func (s *statement) isRead() bool {
+	return s.kind == "SELECT"
+}
+
+func parseStatement(input string, params []types.AttributeValue) (*statement, error) {
+	p, err := newPartiQLParser(input, params)
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.next()
+	s := &statement{kind: strings.ToUpper(t.text)}
+	if t.kind != tokIdent {
+		return nil, p.unexpected(t)
+	}
+
+	switch s.kind {
+	case "SELECT":
+		err = p.parseSelect(s)
+	case "INSERT":
+		err = p.parseInsert(s)
+	case "UPDATE":
+		err = p.parseUpdateStatement(s)
+	case "DELETE":
+		err = p.parseDelete(s)
+	default:
+		return nil, p.unexpected(t)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expectEOF(); err != nil {
+		return nil, err
+	}
+
+	if p.nextParam != len(params) {
+		return nil, validationError("Number of parameters in request and statement don't match.")
+	}
+
+	return s, nil
+}
+
+func (p *parser) parseTableRef(s *statement, allowIndex bool) error {
+	t := p.next()
+	if t.kind != tokIdent && t.kind != tokQuotedIdent {
+		return p.unexpected(t)
+	}
+	s.tableName = t.text
+
+	if allowIndex && p.acceptPunct(".") {
+		t := p.next()
+		if t.kind != tokIdent && t.kind != tokQuotedIdent {
+			return p.unexpected(t)
+		}
+		s.indexName = t.text
+	}
+
+	return nil
+}
+
+func (p *parser) parseWhere(s *statement, required bool) error {
+	if !p.acceptKeyword("WHERE") {
+		if required {
+			return p.unexpected(p.peek())
+		}
+		return nil
+	}
+
+	where, err := p.parseCondition()
+	if err != nil {
+		return err
+	}
+	s.where = where
+	return nil
+}
+
+func (p *parser) parseSelect(s *statement) error {
+	if !p.acceptPunct("*") {
+		projection, err := p.parsePathList()
+		if err != nil {
+			return err
+		}
+		s.projection = projection
+	}
+
+	if err := p.expectKeyword("FROM"); err != nil {
+		return err
+	}
+
+	if err := p.parseTableRef(s, true); err != nil {
+		return err
+	}
+
+	return p.parseWhere(s, false)
+}
+
+func (p *parser) parseInsert(s *statement) error {
+	if err := p.expectKeyword("INTO"); err != nil {
+		return err
+	}
+
+	if err := p.parseTableRef(s, false); err != nil {
+		return err
+	}
+
+	if err := p.expectKeyword("VALUE"); err != nil {
+		return err
+	}
+
+	value, err := p.expectLiteral()
+	if err != nil {
+		return err
+	}
+
+	m, ok := value.(*types.AttributeValueMemberM)
+	if !ok {
+		return p.errorf("INSERT requires a single item value")
+	}
+	s.item = m.Value
+
+	return nil
+}
+
+func (p *parser) parseUpdateStatement(s *statement) error {
+	if err := p.parseTableRef(s, false); err != nil {
+		return err
+	}
+
+	s.actions = &updateActions{}
+	for {
+		switch {
+		case p.acceptKeyword("SET"):
+			if err := p.parsePartiQLSet(s.actions); err != nil {
+				return err
+			}
+		case p.acceptKeyword("REMOVE"):
+			paths, err := p.parsePathList()
+			if err != nil {
+				return err
+			}
+			s.actions.remove = append(s.actions.remove, paths...)
+		default:
+			if len(s.actions.set)+len(s.actions.remove)+len(s.actions.add)+len(s.actions.delete) == 0 {
+				return p.unexpected(p.peek())
+			}
+			return p.parseWhere(s, true)
+		}
+	}
+}
+
+func (p *parser) parsePartiQLSet(actions *updateActions) error {
+	for {
+		path, err := p.parsePath()
+		if err != nil {
+			return err
+		}
+
+		if err := p.expectPunct("="); err != nil {
+			return err
+		}
+
+		if t := p.peek(); t.kind == tokIdent && p.peekAt(1).is(tokPunct, "(") &&
+			(strings.EqualFold(t.text, "set_add") || strings.EqualFold(t.text, "set_delete")) {
+			p.next()
+			p.next()
+			target, err := p.parsePath()
+			if err != nil {
+				return err
+			}
+			if err := p.expectPunct(","); err != nil {
+				return err
+			}
+			value, err := p.expectLiteral()
+			if err != nil {
+				return err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return err
+			}
+			if !isPathPrefix(target, path) || !isPathPrefix(path, target) {
+				return p.errorf("%s must target the attribute being set", strings.ToLower(t.text))
+			}
+			action := setAction{path: path, value: valueOperand{value: value}}
+			if strings.EqualFold(t.text, "set_add") {
+				actions.add = append(actions.add, action)
+			} else {
+				actions.delete = append(actions.delete, action)
+			}
+		} else {
+			value, err := p.parseSetValue()
+			if err != nil {
+				return err
+			}
+			actions.set = append(actions.set, setAction{path: path, value: value})
+		}
+
+		if !p.acceptPunct(",") {
+			return nil
+		}
+	}
+}
+
+func (p *parser) parseDelete(s *statement) error {
+	if err := p.expectKeyword("FROM"); err != nil {
+		return err
+	}
+
+	if err := p.parseTableRef(s, false); err != nil {
+		return err
+	}
+
+	return p.parseWhere(s, true)
+}
+
+func (db *DB) BatchExecuteStatement(ctx context.Context, in *ddb.BatchExecuteStatementInput, optFns ...func(*ddb.Options)) (*ddb.BatchExecuteStatementOutput, error) {
+	if len(in.Statements) == 0 || len(in.Statements) > maxBatchStatements {
+		return nil, validationError("1 validation error detected: Value at 'statements' failed to satisfy constraint: Member must have length less than or equal to %d", maxBatchStatements)
+	}
+
+	throttled := db.throttled("BatchExecuteStatement")
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	statements := make([]*statement, len(in.Statements))
+	parseErrors := make([]error, len(in.Statements))
+	reads, writes := 0, 0
+
+	for i, request := range in.Statements {
+		if request.Statement == nil {
+			parseErrors[i] = validationError("Statement must not be null")
+			continue
+		}
+
+		s, err := parseStatement(*request.Statement, request.Parameters)
+		if err != nil {
+			parseErrors[i] = err
+			continue
+		}
+
+		statements[i] = s
+		if s.isRead() {
+			reads++
+		} else {
+			writes++
+		}
+	}
+
+	if reads > 0 && writes > 0 {
+		return nil, validationError("Batch statements must either be all reads or all writes")
+	}
+
+	responses := make([]types.BatchStatementResponse, len(in.Statements))
+	for i, s := range statements {
+		if s != nil {
+			responses[i].TableName = aws.String(s.tableName)
+		}
+
+		if throttled && i >= len(statements)/2 {
+			responses[i].Error = &types.BatchStatementError{
+				Code:    types.BatchStatementErrorCodeEnumThrottlingError,
+				Message: aws.String("Throughput exceeds the current capacity for one or more global secondary indexes"),
+			}
+			continue
+		}
+
+		err := parseErrors[i]
+		if err == nil {
+			var items []map[string]types.AttributeValue
+			items, err = db.executeBatchStatement(s, in.Statements[i].ConsistentRead)
+			if err == nil && len(items) > 0 {
+				responses[i].Item = items[0]
+			}
+		}
+
+		if err != nil {
+			responses[i].Error = &types.BatchStatementError{
+				Code:    statementErrorCode(err),
+				Message: aws.String(errorMessage(err)),
+			}
+		}
+	}
+
+	return &ddb.BatchExecuteStatementOutput{Responses: responses}, nil
+}
+
+func (db *DB) ExecuteStatement(ctx context.Context, in *ddb.ExecuteStatementInput, optFns ...func(*ddb.Options)) (*ddb.ExecuteStatementOutput, error) {
+	if in.Statement == nil {
+		return nil, validationError("Statement must not be null")
+	}
+
+	if db.throttled("ExecuteStatement") {
+		return nil, throughputExceeded()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	s, err := parseStatement(*in.Statement, in.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := db.table(&s.tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.isRead() {
+		if in.NextToken != nil || in.Limit != nil {
+			return nil, validationError("Limit and NextToken are only supported for select statements")
+		}
+		if _, err := t.execute(s, in.ConsistentRead); err != nil {
+			return nil, err
+		}
+		return &ddb.ExecuteStatementOutput{}, nil
+	}
+
+	var startKey map[string]types.AttributeValue
+	if in.NextToken != nil {
+		page, ok := db.nextTokens[*in.NextToken]
+		if !ok || page.statement != *in.Statement {
+			return nil, validationError("Given NextToken is not valid")
+		}
+		startKey = page.lastKey
+	}
+
+	result, err := t.selectPage(s, in.ConsistentRead, startKey, in.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ddb.ExecuteStatementOutput{Items: result.items}
+	if out.Items == nil {
+		out.Items = []map[string]types.AttributeValue{}
+	}
+	if result.lastKey != nil {
+		db.tokenCounter++
+		token := strconv.Itoa(db.tokenCounter)
+		db.nextTokens[token] = statementPage{statement: *in.Statement, lastKey: result.lastKey}
+		out.NextToken = aws.String(token)
+	}
+
+	return out, nil
+}
+
+func (db *DB) ExecuteTransaction(ctx context.Context, in *ddb.ExecuteTransactionInput, optFns ...func(*ddb.Options)) (*ddb.ExecuteTransactionOutput, error) {
+	if len(in.TransactStatements) == 0 || len(in.TransactStatements) > maxTransactionItems {
+		return nil, validationError("1 validation error detected: Value at 'transactStatements' failed to satisfy constraint: Member must have length less than or equal to %d", maxTransactionItems)
+	}
+
+	if db.throttled("ExecuteTransaction") {
+		return nil, throughputExceeded()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if in.ClientRequestToken != nil && db.requestTokens[*in.ClientRequestToken] {
+		return &ddb.ExecuteTransactionOutput{}, nil
+	}
+
+	statements := make([]*statement, 0, len(in.TransactStatements))
+	tables := make([]*table, 0, len(in.TransactStatements))
+	seen := make(map[string]bool)
+	reads := 0
+
+	for _, request := range in.TransactStatements {
+		if request.Statement == nil {
+			return nil, validationError("Statement must not be null")
+		}
+
+		s, err := parseStatement(*request.Statement, request.Parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := db.table(&s.tableName)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := t.statementKey(s)
+		if err != nil {
+			return nil, err
+		}
+
+		id := t.name + "/" + key
+		if seen[id] {
+			return nil, validationError("Transaction request cannot include multiple operations on one item")
+		}
+		seen[id] = true
+
+		if s.isRead() {
+			reads++
+		}
+		statements = append(statements, s)
+		tables = append(tables, t)
+	}
+
+	if reads > 0 && reads < len(statements) {
+		return nil, validationError("Transaction statements must either be all reads or all writes")
+	}
+
+	snapshots := make(map[*table]map[string]map[string]types.AttributeValue)
+	for _, t := range tables {
+		if _, ok := snapshots[t]; !ok {
+			snapshots[t] = snapshotItems(t.items)
+		}
+	}
+	rollback := func() {
+		for t, items := range snapshots {
+			t.items = items
+		}
+	}
+
+	out := &ddb.ExecuteTransactionOutput{}
+	reasons := make([]types.CancellationReason, len(statements))
+	codes := make([]string, len(statements))
+	canceled := false
+
+	for i, s := range statements {
+		reasons[i] = types.CancellationReason{Code: aws.String("None")}
+		codes[i] = "None"
+
+		items, err := tables[i].execute(s, nil)
+		switch err.(type) {
+		case nil:
+		case *types.ConditionalCheckFailedException:
+			codes[i] = "ConditionalCheckFailed"
+		case *types.DuplicateItemException:
+			codes[i] = "DuplicateItem"
+		default:
+			rollback()
+			return nil, err
+		}
+
+		if codes[i] != "None" {
+			canceled = true
+			reasons[i] = types.CancellationReason{
+				Code:    aws.String(codes[i]),
+				Message: aws.String(errorMessage(err)),
+			}
+			continue
+		}
+
+		if s.isRead() {
+			response := types.ItemResponse{}
+			if len(items) > 0 {
+				response.Item = items[0]
+			}
+			out.Responses = append(out.Responses, response)
+		}
+	}
+
+	if canceled {
+		rollback()
+		return nil, &types.TransactionCanceledException{
+			Message:             aws.String("Transaction cancelled, please refer cancellation reasons for specific reasons [" + strings.Join(codes, ", ") + "]"),
+			CancellationReasons: reasons,
+		}
+	}
+
+	if in.ClientRequestToken != nil {
+		db.requestTokens[*in.ClientRequestToken] = true
+	}
+
+	return out, nil
+}
+
+func snapshotItems(items map[string]map[string]types.AttributeValue) map[string]map[string]types.AttributeValue {
+	snapshot := make(map[string]map[string]types.AttributeValue, len(items))
+	for key, item := range items {
+		snapshot[key] = item
+	}
+	return snapshot
+}
+
+func (t *table) statementKey(s *statement) (string, error) {
+	if s.kind == "INSERT" {
+		return t.validateItem(s.item)
+	}
+
+	if s.indexName != "" {
+		return "", validationError("Transaction statements cannot be executed on an index")
+	}
+
+	keyValues, _, ok := splitKeyPredicate(s.where, t)
+	if !ok {
+		return "", validationError("Where clause does not contain a mandatory equality on all key attributes")
+	}
+
+	return t.lookupKey(keyValues)
+}
+
+func (db *DB) executeBatchStatement(s *statement, consistentRead *bool) ([]map[string]types.AttributeValue, error) {
+	t, err := db.table(&s.tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.isRead() {
+		if s.indexName != "" {
+			return nil, validationError("Batch select statements cannot be executed on an index")
+		}
+		if _, _, ok := splitKeyPredicate(s.where, t); !ok {
+			return nil, validationError("Select statements within BatchExecuteStatement must specify the full primary key")
+		}
+	}
+
+	return t.execute(s, consistentRead)
+}
+
+func (t *table) execute(s *statement, consistentRead *bool) ([]map[string]types.AttributeValue, error) {
+	switch s.kind {
+	case "SELECT":
+		return t.executeSelect(s, consistentRead)
+	case "INSERT":
+		key, err := t.validateItem(s.item)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := t.items[key]; ok {
+			return nil, &types.DuplicateItemException{Message: aws.String("Duplicate primary key exists in table")}
+		}
+		t.items[key] = copyItem(s.item)
+		return nil, nil
+	}
+
+	keyValues, rest, ok := splitKeyPredicate(s.where, t)
+	if !ok {
+		return nil, validationError("Where clause does not contain a mandatory equality on all key attributes")
+	}
+
+	key, err := t.lookupKey(keyValues)
+	if err != nil {
+		return nil, err
+	}
+
+	old := t.items[key]
+	if s.kind == "UPDATE" && old == nil {
+		return nil, conditionalCheckFailed()
+	}
+
+	if old == nil && rest == nil {
+		return nil, nil
+	}
+
+	if err := checkCondition(old, rest); err != nil {
+		return nil, err
+	}
+
+	if s.kind == "DELETE" {
+		delete(t.items, key)
+		return nil, nil
+	}
+
+	item, _, err := t.update(old, keyValues, s.actions)
+	if err != nil {
+		return nil, err
+	}
+	t.items[key] = item
+
+	return nil, nil
+}
+
+func (t *table) executeSelect(s *statement, consistentRead *bool) ([]map[string]types.AttributeValue, error) {
+	result, err := t.selectPage(s, consistentRead, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.items, nil
+}
+
+func (t *table) selectPage(s *statement, consistentRead *bool, startKey map[string]types.AttributeValue, limit *int32) (*readResult, error) {
+	idx, err := t.findIndex(optionalString(s.indexName))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkConsistentRead(idx, consistentRead); err != nil {
+		return nil, err
+	}
+
+	req := &readRequest{
+		filter:     s.where,
+		projection: s.projection,
+		table:      t,
+		index:      idx,
+		items:      t.view(idx),
+		order:      t.orderAttributes(idx, true),
+		forward:    true,
+		startKey:   startKey,
+		limit:      limit,
+	}
+
+	return req.run()
+}
+
+func splitKeyPredicate(where condition, t *table) (map[string]types.AttributeValue, condition, bool) {
+	if where == nil {
+		return nil, nil, false
+	}
+
+	keyValues := make(map[string]types.AttributeValue)
+	var rest []condition
+
+	for _, part := range conjuncts(where) {
+		name, value, ok := keyEquality(part)
+		if ok && (name == t.hashKey || name == t.rangeKey) {
+			if _, dup := keyValues[name]; !dup {
+				keyValues[name] = value
+				continue
+			}
+		}
+		rest = append(rest, part)
+	}
+
+	if len(keyValues) != len(t.keyAttributes()) {
+		return nil, nil, false
+	}
+
+	return keyValues, combineConditions(rest), true
+}
+
+func statementErrorCode(err error) types.BatchStatementErrorCodeEnum {
+	switch err.(type) {
+	case *types.ConditionalCheckFailedException:
+		return types.BatchStatementErrorCodeEnumConditionalCheckFailed
+	case *types.DuplicateItemException:
+		return types.BatchStatementErrorCodeEnumDuplicateItem
+	case *types.ResourceNotFoundException:
+		return types.BatchStatementErrorCodeEnumResourceNotFound
+	case *types.ProvisionedThroughputExceededException:
+		return types.BatchStatementErrorCodeEnumProvisionedThroughputExceeded
+	default:
+		return types.BatchStatementErrorCodeEnumValidationError
+	}
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,211 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"testing"
+)
+
+func TestBatchExecuteStatementErrors(t *testing.T) {
+	db := newTestDB(t)
+	seedTestDB(t, db)
+
+	tests := []struct {
+		name       string
+		statement  string
+		parameters []types.AttributeValue
+		code       types.BatchStatementErrorCodeEnum
+	}{
+		{
+			name:       "insert of a new item",
+			statement:  `INSERT INTO "items" VALUE {'pk': ?, 'sk': ?}`,
+			parameters: []types.AttributeValue{&types.AttributeValueMemberS{Value: "c"}, &types.AttributeValueMemberN{Value: "1"}},
+		},
+		{
+			name:       "insert of an existing item",
+			statement:  `INSERT INTO "items" VALUE {'pk': ?, 'sk': ?}`,
+			parameters: []types.AttributeValue{&types.AttributeValueMemberS{Value: "a"}, &types.AttributeValueMemberN{Value: "1"}},
+			code:       types.BatchStatementErrorCodeEnumDuplicateItem,
+		},
+		{
+			name:      "update with a failing condition",
+			statement: `UPDATE "items" SET score = ? WHERE pk = ? AND sk = ? AND score > ?`,
+			parameters: []types.AttributeValue{
+				&types.AttributeValueMemberN{Value: "1"},
+				&types.AttributeValueMemberS{Value: "a"},
+				&types.AttributeValueMemberN{Value: "2"},
+				&types.AttributeValueMemberN{Value: "100"},
+			},
+			code: types.BatchStatementErrorCodeEnumConditionalCheckFailed,
+		},
+		{
+			name:       "delete of an existing item",
+			statement:  `DELETE FROM "items" WHERE pk = ? AND sk = ?`,
+			parameters: []types.AttributeValue{&types.AttributeValueMemberS{Value: "a"}, &types.AttributeValueMemberN{Value: "3"}},
+		},
+		{
+			name:       "unknown table",
+			statement:  `DELETE FROM "missing" WHERE pk = ? AND sk = ?`,
+			parameters: []types.AttributeValue{&types.AttributeValueMemberS{Value: "a"}, &types.AttributeValueMemberN{Value: "3"}},
+			code:       types.BatchStatementErrorCodeEnumResourceNotFound,
+		},
+		{
+			name:      "syntax error",
+			statement: `DELETE "items" WHERE`,
+			code:      types.BatchStatementErrorCodeEnumValidationError,
+		},
+		{
+			name:       "missing parameter",
+			statement:  `DELETE FROM "items" WHERE pk = ? AND sk = ?`,
+			parameters: []types.AttributeValue{&types.AttributeValueMemberS{Value: "a"}},
+			code:       types.BatchStatementErrorCodeEnumValidationError,
+		},
+	}
+
+	requests := make([]types.BatchStatementRequest, 0, len(tests))
+	for _, test := range tests {
+		requests = append(requests, types.BatchStatementRequest{Statement: aws.String(test.statement), Parameters: test.parameters})
+	}
+
+	out, err := db.BatchExecuteStatement(context.Background(), &ddb.BatchExecuteStatementInput{Statements: requests})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(out.Responses) != len(tests) {
+		t.Fatalf("expected %d responses, got %d", len(tests), len(out.Responses))
+	}
+
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statementErr := out.Responses[i].Error
+			switch {
+			case test.code == "" && statementErr != nil:
+				t.Errorf("unexpected error: %s %s", statementErr.Code, aws.ToString(statementErr.Message))
+			case test.code != "" && statementErr == nil:
+				t.Errorf("expected error code %s", test.code)
+			case test.code != "" && statementErr.Code != test.code:
+				t.Errorf("expected error code %s, got %s", test.code, statementErr.Code)
+			}
+		})
+	}
+
+	if _, ok := db.tables[testTable].items[mustLookupKey(t, db, testKey("c", "1"))]; !ok {
+		t.Error("expected the successful insert to be applied")
+	}
+	if _, ok := db.tables[testTable].items[mustLookupKey(t, db, testKey("a", "3"))]; ok {
+		t.Error("expected the successful delete to be applied")
+	}
+}
+
+func TestBatchExecuteStatementValidation(t *testing.T) {
+	db := newTestDB(t)
+	seedTestDB(t, db)
+
+	tests := []struct {
+		name       string
+		statements []types.BatchStatementRequest
+	}{
+		{
+			name: "no statements",
+		},
+		{
+			name: "mixed reads and writes",
+			statements: []types.BatchStatementRequest{
+				{Statement: aws.String(`SELECT * FROM "items" WHERE pk = 'a' AND sk = 1`)},
+				{Statement: aws.String(`DELETE FROM "items" WHERE pk = 'a' AND sk = 1`)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := db.BatchExecuteStatement(context.Background(), &ddb.BatchExecuteStatementInput{Statements: test.statements})
+			if err == nil {
+				t.Fatal("expected a validation error")
+			}
+		})
+	}
+}
+
+func TestExecuteStatementPagination(t *testing.T) {
+	db := newTestDB(t)
+	seedTestDB(t, db)
+
+	var nextToken *string
+	pages := make([][]string, 0)
+
+	for {
+		out, err := db.ExecuteStatement(context.Background(), &ddb.ExecuteStatementInput{
+			Statement:  aws.String(`SELECT * FROM "items" WHERE pk = ?`),
+			Parameters: []types.AttributeValue{&types.AttributeValueMemberS{Value: "a"}},
+			Limit:      aws.Int32(2),
+			NextToken:  nextToken,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		pages = append(pages, itemIDs(out.Items))
+		if out.NextToken == nil {
+			break
+		}
+		if len(pages) > 2 {
+			t.Fatalf("pagination did not terminate, pages so far: %v", pages)
+		}
+		nextToken = out.NextToken
+	}
+
+	if len(pages) != 2 || !equalIDs(pages[0], []string{"a#1", "a#2"}) || !equalIDs(pages[1], []string{"a#3"}) {
+		t.Errorf("unexpected pages %v", pages)
+	}
+}
+
+func TestExecuteTransactionRollsBack(t *testing.T) {
+	db := newTestDB(t)
+	seedTestDB(t, db)
+	before := snapshotItems(db.tables[testTable].items)
+
+	_, err := db.ExecuteTransaction(context.Background(), &ddb.ExecuteTransactionInput{
+		TransactStatements: []types.ParameterizedStatement{
+			{
+				Statement:  aws.String(`DELETE FROM "items" WHERE pk = ? AND sk = ?`),
+				Parameters: []types.AttributeValue{&types.AttributeValueMemberS{Value: "a"}, &types.AttributeValueMemberN{Value: "1"}},
+			},
+			{
+				Statement:  aws.String(`INSERT INTO "items" VALUE {'pk': ?, 'sk': ?}`),
+				Parameters: []types.AttributeValue{&types.AttributeValueMemberS{Value: "b"}, &types.AttributeValueMemberN{Value: "1"}},
+			},
+		},
+	})
+
+	var canceled *types.TransactionCanceledException
+	if !errors.As(err, &canceled) {
+		t.Fatalf("expected a transaction cancellation, got %v", err)
+	}
+
+	codes := make([]string, 0, len(canceled.CancellationReasons))
+	for _, reason := range canceled.CancellationReasons {
+		codes = append(codes, aws.ToString(reason.Code))
+	}
+	if !equalIDs(codes, []string{"None", "DuplicateItem"}) {
+		t.Errorf("unexpected cancellation codes %v", codes)
+	}
+
+	if !equalItemSets(before, snapshotItems(db.tables[testTable].items)) {
+		t.Error("a cancelled transaction modified the table")
+	}
+}
+
+func mustLookupKey(t *testing.T, db *DB, key map[string]types.AttributeValue) string {
+	t.Helper()
+
+	k, err := db.tables[testTable].lookupKey(key)
+	if err != nil {
+		t.Fatalf("failed to build key: %v", err)
+	}
+	return k
+}
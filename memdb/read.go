@@ -0,0 +1,348 @@
+package memdb
+
+import (
+	"context"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"hash/fnv"
+	"sort"
+)
+
+type readRequest struct {
+	items      []map[string]types.AttributeValue
+	order      []string
+	forward    bool
+	startKey   map[string]types.AttributeValue
+	limit      *int32
+	filter     condition
+	projection []path
+	count      bool
+	table      *table
+	index      *index
+}
+
+type readResult struct {
+	items        []map[string]types.AttributeValue
+	count        int32
+	scannedCount int32
+	lastKey      map[string]types.AttributeValue
+}
+
+func (db *DB) Query(ctx context.Context, in *ddb.QueryInput, optFns ...func(*ddb.Options)) (*ddb.QueryOutput, error) {
+	if db.throttled("Query") {
+		return nil, throughputExceeded()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(in.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := t.findIndex(in.IndexName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkConsistentRead(idx, in.ConsistentRead); err != nil {
+		return nil, err
+	}
+
+	if in.KeyConditionExpression == nil {
+		return nil, validationError("Either the KeyConditions or KeyConditionExpression parameter must be specified in the request.")
+	}
+
+	hashKey, rangeKey := t.hashKey, t.rangeKey
+	if idx != nil {
+		hashKey, rangeKey = idx.hashKey, idx.rangeKey
+	}
+
+	exprCtx := newExprContext(in.ExpressionAttributeNames, in.ExpressionAttributeValues)
+	keyCondition, err := parseConditionExpression("KeyConditionExpression", in.KeyConditionExpression, exprCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	hashValue, rangeCondition, err := splitKeyCondition(keyCondition, hashKey, rangeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := newReadRequest(t, idx, in.FilterExpression, in.ProjectionExpression, exprCtx, in.Select)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range t.view(idx) {
+		if !equalValues(item[hashKey], hashValue) {
+			continue
+		}
+		if rangeCondition != nil {
+			ok, err := evalCondition(item, rangeCondition)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		req.items = append(req.items, item)
+	}
+
+	req.order = t.orderAttributes(idx, false)
+	req.forward = in.ScanIndexForward == nil || *in.ScanIndexForward
+	req.startKey = in.ExclusiveStartKey
+	req.limit = in.Limit
+
+	result, err := req.run()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ddb.QueryOutput{
+		Items:            result.items,
+		Count:            result.count,
+		ScannedCount:     result.scannedCount,
+		LastEvaluatedKey: result.lastKey,
+	}, nil
+}
+
+func (db *DB) Scan(ctx context.Context, in *ddb.ScanInput, optFns ...func(*ddb.Options)) (*ddb.ScanOutput, error) {
+	if db.throttled("Scan") {
+		return nil, throughputExceeded()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, err := db.table(in.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := t.findIndex(in.IndexName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkConsistentRead(idx, in.ConsistentRead); err != nil {
+		return nil, err
+	}
+
+	segment, totalSegments, err := checkSegments(in.Segment, in.TotalSegments)
+	if err != nil {
+		return nil, err
+	}
+
+	exprCtx := newExprContext(in.ExpressionAttributeNames, in.ExpressionAttributeValues)
+	req, err := newReadRequest(t, idx, in.FilterExpression, in.ProjectionExpression, exprCtx, in.Select)
+	if err != nil {
+		return nil, err
+	}
+
+	hashKey := t.hashKey
+	if idx != nil {
+		hashKey = idx.hashKey
+	}
+
+	for _, item := range t.view(idx) {
+		if totalSegments > 1 {
+			k, _ := keyString(item[hashKey])
+			h := fnv.New32a()
+			_, _ = h.Write([]byte(k))
+			if int32(h.Sum32()%uint32(totalSegments)) != segment {
+				continue
+			}
+		}
+		req.items = append(req.items, item)
+	}
+
+	req.order = t.orderAttributes(idx, true)
+	req.forward = true
+	req.startKey = in.ExclusiveStartKey
+	req.limit = in.Limit
+
+	result, err := req.run()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ddb.ScanOutput{
+		Items:            result.items,
+		Count:            result.count,
+		ScannedCount:     result.scannedCount,
+		LastEvaluatedKey: result.lastKey,
+	}, nil
+}
+
+func newReadRequest(t *table, idx *index, filterExpression, projectionExpression *string, exprCtx *exprContext, selectType types.Select) (*readRequest, error) {
+	filter, err := parseConditionExpression("FilterExpression", filterExpression, exprCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	projection, err := parseProjectionExpression(projectionExpression, exprCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := exprCtx.checkUnused(); err != nil {
+		return nil, err
+	}
+
+	switch selectType {
+	case "", types.SelectAllAttributes, types.SelectAllProjectedAttributes:
+		if projection != nil && selectType != "" {
+			return nil, validationError("Cannot specify the ProjectionExpression when choosing to get %s", selectType)
+		}
+	case types.SelectCount:
+		if projection != nil {
+			return nil, validationError("Cannot specify the ProjectionExpression when choosing to get COUNT")
+		}
+	case types.SelectSpecificAttributes:
+		if projection == nil {
+			return nil, validationError("SPECIFIC_ATTRIBUTES requires a ProjectionExpression")
+		}
+	default:
+		return nil, validationError("Select value %q is not supported", selectType)
+	}
+
+	return &readRequest{
+		filter:     filter,
+		projection: projection,
+		count:      selectType == types.SelectCount,
+		table:      t,
+		index:      idx,
+	}, nil
+}
+
+func (r *readRequest) run() (*readResult, error) {
+	if r.limit != nil && *r.limit < 1 {
+		return nil, validationError("1 validation error detected: Value '%d' at 'limit' failed to satisfy constraint: Member must have value greater than or equal to 1", *r.limit)
+	}
+
+	sort.SliceStable(r.items, func(i, j int) bool {
+		c := compareByAttributes(r.items[i], r.items[j], r.order)
+		if r.forward {
+			return c < 0
+		}
+		return c > 0
+	})
+
+	start := 0
+	if r.startKey != nil {
+		for _, name := range r.table.indexKeyAttributes(r.index) {
+			if _, ok := r.startKey[name]; !ok {
+				return nil, validationError("The provided starting key is invalid: The provided key element does not match the schema")
+			}
+		}
+
+		start = sort.Search(len(r.items), func(i int) bool {
+			c := compareByAttributes(r.items[i], r.startKey, r.order)
+			if r.forward {
+				return c > 0
+			}
+			return c < 0
+		})
+	}
+
+	result := &readResult{}
+	for i := start; i < len(r.items); i++ {
+		if r.limit != nil && result.scannedCount >= *r.limit {
+			result.lastKey = r.table.keyOf(r.items[i-1], r.index)
+			break
+		}
+
+		item := r.items[i]
+		result.scannedCount++
+
+		if r.filter != nil {
+			ok, err := evalCondition(item, r.filter)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		result.count++
+		if !r.count {
+			result.items = append(result.items, projectItem(item, r.projection))
+		}
+	}
+
+	return result, nil
+}
+
+func checkConsistentRead(idx *index, consistentRead *bool) error {
+	if idx != nil && idx.global && consistentRead != nil && *consistentRead {
+		return validationError("Consistent reads are not supported on global secondary indexes")
+	}
+	return nil
+}
+
+func checkSegments(segment, totalSegments *int32) (int32, int32, error) {
+	if segment == nil && totalSegments == nil {
+		return 0, 0, nil
+	}
+
+	if segment == nil || totalSegments == nil {
+		return 0, 0, validationError("The TotalSegments parameter is required but was not present in the request when Segment parameter is present")
+	}
+
+	if *totalSegments < 1 || *totalSegments > 1000000 {
+		return 0, 0, validationError("1 validation error detected: Value '%d' at 'totalSegments' failed to satisfy constraint: Member must have value between 1 and 1000000", *totalSegments)
+	}
+
+	if *segment < 0 || *segment >= *totalSegments {
+		return 0, 0, validationError("The Segment parameter is zero-based and must be less than parameter TotalSegments: Segment: %d is not less than TotalSegments: %d", *segment, *totalSegments)
+	}
+
+	return *segment, *totalSegments, nil
+}
+
+func splitKeyCondition(c condition, hashKey, rangeKey string) (types.AttributeValue, condition, error) {
+	parts := conjuncts(c)
+	if len(parts) > 2 {
+		return nil, nil, validationError("Invalid KeyConditionExpression: The expression can only contain a hash key condition and an optional range key condition")
+	}
+
+	var hashValue types.AttributeValue
+	var rangeCondition condition
+
+	for _, part := range parts {
+		if name, value, ok := keyEquality(part); ok && name == hashKey && hashValue == nil {
+			hashValue = value
+			continue
+		}
+
+		if rangeKey != "" && rangeCondition == nil && isRangeKeyCondition(part, rangeKey) {
+			rangeCondition = part
+			continue
+		}
+
+		return nil, nil, validationError("Query key condition not supported")
+	}
+
+	if hashValue == nil {
+		return nil, nil, validationError("Query condition missed key schema element: %s", hashKey)
+	}
+
+	return hashValue, rangeCondition, nil
+}
+
+func isRangeKeyCondition(c condition, rangeKey string) bool {
+	switch cond := c.(type) {
+	case comparisonCondition:
+		return cond.operator != "<>" && referencesOnly(c, rangeKey)
+	case betweenCondition:
+		return referencesOnly(c, rangeKey)
+	case functionCondition:
+		return cond.name == "begins_with" && referencesOnly(c, rangeKey)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,320 @@
+package memdb
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"sort"
+	"testing"
+)
+
+func TestQueryPagination(t *testing.T) {
+	db := newTestDB(t)
+	seedTestDB(t, db)
+
+	tests := []struct {
+		name     string
+		index    *string
+		pk       string
+		pkValue  types.AttributeValue
+		forward  bool
+		limit    int32
+		expected [][]string
+	}{
+		{
+			name:     "table forward",
+			pk:       "pk",
+			pkValue:  &types.AttributeValueMemberS{Value: "a"},
+			forward:  true,
+			limit:    2,
+			expected: [][]string{{"a#1", "a#2"}, {"a#3"}},
+		},
+		{
+			name:     "table backward",
+			pk:       "pk",
+			pkValue:  &types.AttributeValueMemberS{Value: "a"},
+			limit:    2,
+			expected: [][]string{{"a#3", "a#2"}, {"a#1"}},
+		},
+		{
+			name:     "exact page boundary",
+			pk:       "pk",
+			pkValue:  &types.AttributeValueMemberS{Value: "a"},
+			forward:  true,
+			limit:    3,
+			expected: [][]string{{"a#1", "a#2", "a#3"}},
+		},
+		{
+			name:     "global index",
+			index:    aws.String("by-status"),
+			pk:       "status",
+			pkValue:  &types.AttributeValueMemberS{Value: "open"},
+			forward:  true,
+			limit:    1,
+			expected: [][]string{{"a#1"}, {"b#1"}, {"a#3"}},
+		},
+		{
+			name:     "local index",
+			index:    aws.String("by-score"),
+			pk:       "pk",
+			pkValue:  &types.AttributeValueMemberS{Value: "a"},
+			forward:  true,
+			limit:    2,
+			expected: [][]string{{"a#1", "a#3"}, {"a#2"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var startKey map[string]types.AttributeValue
+			pages := make([][]string, 0)
+
+			for {
+				out, err := db.Query(context.Background(), &ddb.QueryInput{
+					TableName:                 aws.String(testTable),
+					IndexName:                 test.index,
+					KeyConditionExpression:    aws.String("#pk = :pk"),
+					ExpressionAttributeNames:  map[string]string{"#pk": test.pk},
+					ExpressionAttributeValues: map[string]types.AttributeValue{":pk": test.pkValue},
+					ScanIndexForward:          aws.Bool(test.forward),
+					Limit:                     aws.Int32(test.limit),
+					ExclusiveStartKey:         startKey,
+				})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if len(out.Items) > 0 {
+					pages = append(pages, itemIDs(out.Items))
+				}
+				if out.LastEvaluatedKey == nil {
+					break
+				}
+				if len(pages) > len(test.expected) {
+					t.Fatalf("pagination did not terminate, pages so far: %v", pages)
+				}
+				startKey = out.LastEvaluatedKey
+			}
+
+			if len(pages) != len(test.expected) {
+				t.Fatalf("expected pages %v, got %v", test.expected, pages)
+			}
+			for i := range pages {
+				if !equalIDs(pages[i], test.expected[i]) {
+					t.Errorf("expected pages %v, got %v", test.expected, pages)
+				}
+			}
+		})
+	}
+}
+
+func TestQueryLastEvaluatedKeyAttributes(t *testing.T) {
+	db := newTestDB(t)
+	seedTestDB(t, db)
+
+	tests := []struct {
+		name     string
+		index    *string
+		pk       string
+		pkValue  types.AttributeValue
+		expected []string
+	}{
+		{
+			name:     "table",
+			pk:       "pk",
+			pkValue:  &types.AttributeValueMemberS{Value: "a"},
+			expected: []string{"pk", "sk"},
+		},
+		{
+			name:     "global index",
+			index:    aws.String("by-status"),
+			pk:       "status",
+			pkValue:  &types.AttributeValueMemberS{Value: "open"},
+			expected: []string{"pk", "sk", "status"},
+		},
+		{
+			name:     "local index",
+			index:    aws.String("by-score"),
+			pk:       "pk",
+			pkValue:  &types.AttributeValueMemberS{Value: "a"},
+			expected: []string{"pk", "score", "sk"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := db.Query(context.Background(), &ddb.QueryInput{
+				TableName:                 aws.String(testTable),
+				IndexName:                 test.index,
+				KeyConditionExpression:    aws.String("#pk = :pk"),
+				ExpressionAttributeNames:  map[string]string{"#pk": test.pk},
+				ExpressionAttributeValues: map[string]types.AttributeValue{":pk": test.pkValue},
+				Limit:                     aws.Int32(1),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			names := make([]string, 0, len(out.LastEvaluatedKey))
+			for name := range out.LastEvaluatedKey {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			if !equalIDs(names, test.expected) {
+				t.Errorf("expected key attributes %v, got %v", test.expected, names)
+			}
+		})
+	}
+}
+
+func TestQueryRangeConditions(t *testing.T) {
+	db := newTestDB(t)
+	seedTestDB(t, db)
+
+	tests := []struct {
+		name      string
+		index     *string
+		condition string
+		values    map[string]types.AttributeValue
+		filter    *string
+		expected  []string
+	}{
+		{
+			name:      "range BETWEEN",
+			condition: "pk = :pk AND sk BETWEEN :low AND :high",
+			values: map[string]types.AttributeValue{
+				":pk":   &types.AttributeValueMemberS{Value: "a"},
+				":low":  &types.AttributeValueMemberN{Value: "2"},
+				":high": &types.AttributeValueMemberN{Value: "3"},
+			},
+			expected: []string{"a#2", "a#3"},
+		},
+		{
+			name:      "local index range",
+			index:     aws.String("by-score"),
+			condition: "pk = :pk AND score > :score",
+			values: map[string]types.AttributeValue{
+				":pk":    &types.AttributeValueMemberS{Value: "a"},
+				":score": &types.AttributeValueMemberN{Value: "15"},
+			},
+			expected: []string{"a#3", "a#2"},
+		},
+		{
+			name:      "global index with filter",
+			index:     aws.String("by-status"),
+			condition: "#status = :status",
+			values: map[string]types.AttributeValue{
+				":status": &types.AttributeValueMemberS{Value: "open"},
+				":pk":     &types.AttributeValueMemberS{Value: "a"},
+			},
+			filter:   aws.String("pk = :pk"),
+			expected: []string{"a#1", "a#3"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var names map[string]string
+			if test.index != nil && *test.index == "by-status" {
+				names = map[string]string{"#status": "status"}
+			}
+
+			out, err := db.Query(context.Background(), &ddb.QueryInput{
+				TableName:                 aws.String(testTable),
+				IndexName:                 test.index,
+				KeyConditionExpression:    aws.String(test.condition),
+				FilterExpression:          test.filter,
+				ExpressionAttributeNames:  names,
+				ExpressionAttributeValues: test.values,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if ids := itemIDs(out.Items); !equalIDs(ids, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, ids)
+			}
+		})
+	}
+}
+
+func TestScanPagination(t *testing.T) {
+	db := newTestDB(t)
+	seedTestDB(t, db)
+
+	var startKey map[string]types.AttributeValue
+	var items []map[string]types.AttributeValue
+	pages := 0
+
+	for {
+		out, err := db.Scan(context.Background(), &ddb.ScanInput{
+			TableName:         aws.String(testTable),
+			Limit:             aws.Int32(3),
+			ExclusiveStartKey: startKey,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		pages++
+		items = append(items, out.Items...)
+		if out.LastEvaluatedKey == nil {
+			break
+		}
+		if pages > 2 {
+			t.Fatalf("pagination did not terminate")
+		}
+		startKey = out.LastEvaluatedKey
+	}
+
+	expected := []string{"a#1", "a#2", "a#3", "b#1"}
+	if ids := sortedItemIDs(items); pages != 2 || !equalIDs(ids, expected) {
+		t.Errorf("expected %v over 2 pages, got %v over %d pages", expected, ids, pages)
+	}
+}
+
+func TestQueryIndexValidation(t *testing.T) {
+	db := newTestDB(t)
+	seedTestDB(t, db)
+
+	tests := []struct {
+		name           string
+		index          *string
+		consistentRead *bool
+		startKey       map[string]types.AttributeValue
+	}{
+		{
+			name:  "unknown index",
+			index: aws.String("missing"),
+		},
+		{
+			name:           "consistent read on a global index",
+			index:          aws.String("by-status"),
+			consistentRead: aws.Bool(true),
+		},
+		{
+			name:     "start key without index attributes",
+			index:    aws.String("by-status"),
+			startKey: map[string]types.AttributeValue{"pk": &types.AttributeValueMemberS{Value: "a"}, "sk": &types.AttributeValueMemberN{Value: "1"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := db.Query(context.Background(), &ddb.QueryInput{
+				TableName:                 aws.String(testTable),
+				IndexName:                 test.index,
+				ConsistentRead:            test.consistentRead,
+				KeyConditionExpression:    aws.String("#status = :status"),
+				ExpressionAttributeNames:  map[string]string{"#status": "status"},
+				ExpressionAttributeValues: map[string]types.AttributeValue{":status": &types.AttributeValueMemberS{Value: "open"}},
+				ExclusiveStartKey:         test.startKey,
+			})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
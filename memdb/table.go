@@ -0,0 +1,237 @@
+package memdb
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"sort"
+	"time"
+)
+
+type index struct {
+	name        string
+	hashKey     string
+	rangeKey    string
+	global      bool
+	projection  types.Projection
+	throughput  *types.ProvisionedThroughput
+	createdTime time.Time
+}
+
+type table struct {
+	name           string
+	hashKey        string
+	rangeKey       string
+	attributeTypes map[string]types.ScalarAttributeType
+	indexes        map[string]*index
+	items          map[string]map[string]types.AttributeValue
+	billingMode    types.BillingMode
+	throughput     *types.ProvisionedThroughput
+	streams        *types.StreamSpecification
+	ttlAttribute   string
+	ttlEnabled     bool
+	createdTime    time.Time
+}
+
+func (t *table) keyAttributes() []string {
+	if t.rangeKey == "" {
+		return []string{t.hashKey}
+	}
+	return []string{t.hashKey, t.rangeKey}
+}
+
+func (t *table) indexKeyAttributes(idx *index) []string {
+	attributes := t.keyAttributes()
+	if idx == nil {
+		return attributes
+	}
+
+	for _, name := range []string{idx.hashKey, idx.rangeKey} {
+		if name != "" && name != t.hashKey && name != t.rangeKey {
+			attributes = append(attributes, name)
+		}
+	}
+	return attributes
+}
+
+func (t *table) itemKey(item map[string]types.AttributeValue) (string, error) {
+	key := ""
+	for _, name := range t.keyAttributes() {
+		value, ok := item[name]
+		if !ok {
+			return "", validationError("One or more parameter values were invalid: Missing the key %s in the item", name)
+		}
+
+		expected := string(t.attributeTypes[name])
+		if actual := typeName(value); actual != expected {
+			return "", validationError("One or more parameter values were invalid: Type mismatch for key %s expected: %s actual: %s", name, expected, actual)
+		}
+
+		if size, _ := valueSize(value); size == 0 && expected != "N" {
+			return "", validationError("One or more parameter values are not valid. The AttributeValue for a key attribute cannot contain an empty string value. Key: %s", name)
+		}
+
+		ks, _ := keyString(value)
+		key += ks + "|"
+	}
+
+	return key, nil
+}
+
+func (t *table) lookupKey(key map[string]types.AttributeValue) (string, error) {
+	if len(key) != len(t.keyAttributes()) {
+		return "", validationError("The provided key element does not match the schema")
+	}
+
+	for _, name := range t.keyAttributes() {
+		value, ok := key[name]
+		if !ok || typeName(value) != string(t.attributeTypes[name]) {
+			return "", validationError("The provided key element does not match the schema")
+		}
+	}
+
+	return t.itemKey(key)
+}
+
+func (t *table) validateItem(item map[string]types.AttributeValue) (string, error) {
+	key, err := t.itemKey(item)
+	if err != nil {
+		return "", err
+	}
+
+	for _, idx := range t.sortedIndexes() {
+		for _, name := range []string{idx.hashKey, idx.rangeKey} {
+			value, ok := item[name]
+			if name == "" || !ok {
+				continue
+			}
+
+			expected := string(t.attributeTypes[name])
+			if actual := typeName(value); actual != expected {
+				return "", validationError("One or more parameter values were invalid: Type mismatch for Index Key %s Expected: %s Actual: %s IndexName: %s", name, expected, actual, idx.name)
+			}
+
+			if size, _ := valueSize(value); size == 0 && expected != "N" {
+				return "", validationError("One or more parameter values are not valid. A value specified for a secondary index key is not supported. The AttributeValue for a key attribute cannot contain an empty string value. IndexName: %s, IndexKey: %s", idx.name, name)
+			}
+		}
+	}
+
+	return key, nil
+}
+
+func (t *table) keyOf(item map[string]types.AttributeValue, idx *index) map[string]types.AttributeValue {
+	key := make(map[string]types.AttributeValue)
+	for _, name := range t.indexKeyAttributes(idx) {
+		if value, ok := item[name]; ok {
+			key[name] = copyValue(value)
+		}
+	}
+	return key
+}
+
+func (t *table) sortedIndexes() []*index {
+	indexes := make([]*index, 0, len(t.indexes))
+	for _, idx := range t.indexes {
+		indexes = append(indexes, idx)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i].name < indexes[j].name
+	})
+	return indexes
+}
+
+func (t *table) findIndex(name *string) (*index, error) {
+	if name == nil {
+		return nil, nil
+	}
+
+	idx, ok := t.indexes[*name]
+	if !ok {
+		return nil, validationError("The table does not have the specified index: %s", *name)
+	}
+	return idx, nil
+}
+
+func (t *table) view(idx *index) []map[string]types.AttributeValue {
+	items := make([]map[string]types.AttributeValue, 0, len(t.items))
+	for _, item := range t.items {
+		if idx == nil {
+			items = append(items, item)
+			continue
+		}
+
+		if _, ok := item[idx.hashKey]; !ok {
+			continue
+		}
+		if _, ok := item[idx.rangeKey]; idx.rangeKey != "" && !ok {
+			continue
+		}
+
+		items = append(items, t.projectToIndex(item, idx))
+	}
+	return items
+}
+
+func (t *table) projectToIndex(item map[string]types.AttributeValue, idx *index) map[string]types.AttributeValue {
+	if idx.projection.ProjectionType == types.ProjectionTypeAll {
+		return item
+	}
+
+	projected := t.keyOf(item, idx)
+	if idx.projection.ProjectionType == types.ProjectionTypeInclude {
+		for _, name := range idx.projection.NonKeyAttributes {
+			if value, ok := item[name]; ok {
+				projected[name] = value
+			}
+		}
+	}
+	return projected
+}
+
+func (t *table) orderAttributes(idx *index, scan bool) []string {
+	var order []string
+	seen := make(map[string]bool)
+	add := func(names ...string) {
+		for _, name := range names {
+			if name != "" && !seen[name] {
+				seen[name] = true
+				order = append(order, name)
+			}
+		}
+	}
+
+	if idx == nil {
+		if scan {
+			add(t.hashKey)
+		}
+		add(t.rangeKey)
+		return order
+	}
+
+	if scan {
+		add(idx.hashKey)
+	}
+	add(idx.rangeKey, t.hashKey, t.rangeKey)
+	return order
+}
+
+func compareByAttributes(a, b map[string]types.AttributeValue, attributes []string) int {
+	for _, name := range attributes {
+		av, bv := a[name], b[name]
+		if c, ok := compareValues(av, bv); ok {
+			if c != 0 {
+				return c
+			}
+			continue
+		}
+
+		ak, _ := keyString(av)
+		bk, _ := keyString(bv)
+		if ak < bk {
+			return -1
+		}
+		if ak > bk {
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,236 @@
+package memdb
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"strings"
+)
+
+const maxTransactionItems = 100
+
+type transactWrite struct {
+	table            *table
+	key              string
+	condition        condition
+	item             map[string]types.AttributeValue
+	update           *updateActions
+	keyValues        map[string]types.AttributeValue
+	delete           bool
+	check            bool
+	returnOnConflict types.ReturnValuesOnConditionCheckFailure
+}
+
+func (db *DB) TransactWriteItems(ctx context.Context, in *ddb.TransactWriteItemsInput, optFns ...func(*ddb.Options)) (*ddb.TransactWriteItemsOutput, error) {
+	if len(in.TransactItems) == 0 || len(in.TransactItems) > maxTransactionItems {
+		return nil, validationError("1 validation error detected: Value at 'transactItems' failed to satisfy constraint: Member must have length less than or equal to %d", maxTransactionItems)
+	}
+
+	if db.throttled("TransactWriteItems") {
+		return nil, throughputExceeded()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if in.ClientRequestToken != nil && db.requestTokens[*in.ClientRequestToken] {
+		return &ddb.TransactWriteItemsOutput{}, nil
+	}
+
+	writes := make([]transactWrite, 0, len(in.TransactItems))
+	seen := make(map[string]bool)
+
+	for _, item := range in.TransactItems {
+		w, err := db.prepareTransactWrite(item)
+		if err != nil {
+			return nil, err
+		}
+
+		id := w.table.name + "/" + w.key
+		if seen[id] {
+			return nil, validationError("Transaction request cannot include multiple operations on one item")
+		}
+		seen[id] = true
+
+		writes = append(writes, w)
+	}
+
+	reasons := make([]types.CancellationReason, len(writes))
+	codes := make([]string, len(writes))
+	canceled := false
+
+	for i, w := range writes {
+		old := w.table.items[w.key]
+		reasons[i] = types.CancellationReason{Code: aws.String("None")}
+		codes[i] = "None"
+
+		err := checkCondition(old, w.condition)
+		if _, failed := err.(*types.ConditionalCheckFailedException); failed {
+			canceled = true
+			codes[i] = "ConditionalCheckFailed"
+			reasons[i] = types.CancellationReason{
+				Code:    aws.String(codes[i]),
+				Message: aws.String("The conditional request failed"),
+			}
+			if w.returnOnConflict == types.ReturnValuesOnConditionCheckFailureAllOld {
+				reasons[i].Item = copyItem(old)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if w.update != nil {
+			item, _, err := w.table.update(old, w.keyValues, w.update)
+			if err != nil {
+				return nil, err
+			}
+			writes[i].item = item
+		}
+	}
+
+	if canceled {
+		return nil, &types.TransactionCanceledException{
+			Message:             aws.String("Transaction cancelled, please refer cancellation reasons for specific reasons [" + strings.Join(codes, ", ") + "]"),
+			CancellationReasons: reasons,
+		}
+	}
+
+	for _, w := range writes {
+		switch {
+		case w.check:
+		case w.delete:
+			delete(w.table.items, w.key)
+		default:
+			w.table.items[w.key] = w.item
+		}
+	}
+
+	if in.ClientRequestToken != nil {
+		db.requestTokens[*in.ClientRequestToken] = true
+	}
+
+	return &ddb.TransactWriteItemsOutput{}, nil
+}
+
+func (db *DB) prepareTransactWrite(item types.TransactWriteItem) (transactWrite, error) {
+	var (
+		w         transactWrite
+		tableName *string
+		names     map[string]string
+		values    map[string]types.AttributeValue
+		condition *string
+		update    *string
+		actions   int
+	)
+
+	if item.Put != nil {
+		actions++
+		tableName, names, values, condition = item.Put.TableName, item.Put.ExpressionAttributeNames, item.Put.ExpressionAttributeValues, item.Put.ConditionExpression
+		w.item = copyItem(item.Put.Item)
+		w.returnOnConflict = item.Put.ReturnValuesOnConditionCheckFailure
+	}
+	if item.Update != nil {
+		actions++
+		tableName, names, values, condition = item.Update.TableName, item.Update.ExpressionAttributeNames, item.Update.ExpressionAttributeValues, item.Update.ConditionExpression
+		w.keyValues = item.Update.Key
+		update = item.Update.UpdateExpression
+		w.returnOnConflict = item.Update.ReturnValuesOnConditionCheckFailure
+	}
+	if item.Delete != nil {
+		actions++
+		tableName, names, values, condition = item.Delete.TableName, item.Delete.ExpressionAttributeNames, item.Delete.ExpressionAttributeValues, item.Delete.ConditionExpression
+		w.keyValues = item.Delete.Key
+		w.delete = true
+		w.returnOnConflict = item.Delete.ReturnValuesOnConditionCheckFailure
+	}
+	if item.ConditionCheck != nil {
+		actions++
+		tableName, names, values, condition = item.ConditionCheck.TableName, item.ConditionCheck.ExpressionAttributeNames, item.ConditionCheck.ExpressionAttributeValues, item.ConditionCheck.ConditionExpression
+		w.keyValues = item.ConditionCheck.Key
+		w.check = true
+		w.returnOnConflict = item.ConditionCheck.ReturnValuesOnConditionCheckFailure
+		if condition == nil {
+			return w, validationError("The ConditionExpression is required for a ConditionCheck")
+		}
+	}
+
+	if actions != 1 {
+		return w, validationError("TransactItems can only contain one of Check, Put, Update or Delete")
+	}
+
+	t, err := db.table(tableName)
+	if err != nil {
+		return w, err
+	}
+	w.table = t
+
+	if w.item != nil {
+		w.key, err = t.validateItem(w.item)
+	} else {
+		w.key, err = t.lookupKey(w.keyValues)
+	}
+	if err != nil {
+		return w, err
+	}
+
+	exprCtx := newExprContext(names, values)
+	if item.Update != nil {
+		if w.update, err = parseUpdateExpression(update, exprCtx); err != nil {
+			return w, err
+		}
+	}
+	if w.condition, err = parseConditionExpression("ConditionExpression", condition, exprCtx); err != nil {
+		return w, err
+	}
+	if err := exprCtx.checkUnused(); err != nil {
+		return w, err
+	}
+
+	return w, nil
+}
+
+func (db *DB) TransactGetItems(ctx context.Context, in *ddb.TransactGetItemsInput, optFns ...func(*ddb.Options)) (*ddb.TransactGetItemsOutput, error) {
+	if len(in.TransactItems) == 0 || len(in.TransactItems) > maxTransactionItems {
+		return nil, validationError("1 validation error detected: Value at 'transactItems' failed to satisfy constraint: Member must have length less than or equal to %d", maxTransactionItems)
+	}
+
+	if db.throttled("TransactGetItems") {
+		return nil, throughputExceeded()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	responses := make([]types.ItemResponse, 0, len(in.TransactItems))
+	for _, item := range in.TransactItems {
+		if item.Get == nil {
+			return nil, validationError("TransactItems can only contain Get operations")
+		}
+
+		t, err := db.table(item.Get.TableName)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := t.lookupKey(item.Get.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		exprCtx := newExprContext(item.Get.ExpressionAttributeNames, nil)
+		projection, err := parseProjectionExpression(item.Get.ProjectionExpression, exprCtx)
+		if err != nil {
+			return nil, err
+		}
+		if err := exprCtx.checkUnused(); err != nil {
+			return nil, err
+		}
+
+		responses = append(responses, types.ItemResponse{Item: projectItem(t.items[key], projection)})
+	}
+
+	return &ddb.TransactGetItemsOutput{Responses: responses}, nil
+}
@@ -0,0 +1,289 @@
+package memdb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"math/big"
+	"sort"
+	"unicode/utf8"
+)
+
+const numberPrecision = 256
+
+func parseNumber(s string) (*big.Float, bool) {
+	f, _, err := big.ParseFloat(s, 10, numberPrecision, big.ToNearestEven)
+	if err != nil {
+		return nil, false
+	}
+	return f, true
+}
+
+func formatNumber(f *big.Float) string {
+	return f.Text('g', -1)
+}
+
+func canonicalNumber(s string) string {
+	f, ok := parseNumber(s)
+	if !ok {
+		return s
+	}
+	return formatNumber(f)
+}
+
+func typeName(av types.AttributeValue) string {
+	switch av.(type) {
+	case *types.AttributeValueMemberS:
+		return "S"
+	case *types.AttributeValueMemberN:
+		return "N"
+	case *types.AttributeValueMemberB:
+		return "B"
+	case *types.AttributeValueMemberBOOL:
+		return "BOOL"
+	case *types.AttributeValueMemberNULL:
+		return "NULL"
+	case *types.AttributeValueMemberM:
+		return "M"
+	case *types.AttributeValueMemberL:
+		return "L"
+	case *types.AttributeValueMemberSS:
+		return "SS"
+	case *types.AttributeValueMemberNS:
+		return "NS"
+	case *types.AttributeValueMemberBS:
+		return "BS"
+	default:
+		return ""
+	}
+}
+
+func keyString(av types.AttributeValue) (string, bool) {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return "S:" + v.Value, true
+	case *types.AttributeValueMemberN:
+		return "N:" + canonicalNumber(v.Value), true
+	case *types.AttributeValueMemberB:
+		return "B:" + base64.StdEncoding.EncodeToString(v.Value), true
+	default:
+		return "", false
+	}
+}
+
+func compareValues(a, b types.AttributeValue) (int, bool) {
+	switch av := a.(type) {
+	case *types.AttributeValueMemberS:
+		bv, ok := b.(*types.AttributeValueMemberS)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case av.Value < bv.Value:
+			return -1, true
+		case av.Value > bv.Value:
+			return 1, true
+		default:
+			return 0, true
+		}
+	case *types.AttributeValueMemberN:
+		bv, ok := b.(*types.AttributeValueMemberN)
+		if !ok {
+			return 0, false
+		}
+		af, aok := parseNumber(av.Value)
+		bf, bok := parseNumber(bv.Value)
+		if !aok || !bok {
+			return 0, false
+		}
+		return af.Cmp(bf), true
+	case *types.AttributeValueMemberB:
+		bv, ok := b.(*types.AttributeValueMemberB)
+		if !ok {
+			return 0, false
+		}
+		return bytes.Compare(av.Value, bv.Value), true
+	default:
+		return 0, false
+	}
+}
+
+func equalValues(a, b types.AttributeValue) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	switch av := a.(type) {
+	case *types.AttributeValueMemberS, *types.AttributeValueMemberN, *types.AttributeValueMemberB:
+		c, ok := compareValues(a, b)
+		return ok && c == 0
+	case *types.AttributeValueMemberBOOL:
+		bv, ok := b.(*types.AttributeValueMemberBOOL)
+		return ok && av.Value == bv.Value
+	case *types.AttributeValueMemberNULL:
+		_, ok := b.(*types.AttributeValueMemberNULL)
+		return ok
+	case *types.AttributeValueMemberM:
+		bv, ok := b.(*types.AttributeValueMemberM)
+		if !ok || len(av.Value) != len(bv.Value) {
+			return false
+		}
+		for k, v := range av.Value {
+			if !equalValues(v, bv.Value[k]) {
+				return false
+			}
+		}
+		return true
+	case *types.AttributeValueMemberL:
+		bv, ok := b.(*types.AttributeValueMemberL)
+		if !ok || len(av.Value) != len(bv.Value) {
+			return false
+		}
+		for i := range av.Value {
+			if !equalValues(av.Value[i], bv.Value[i]) {
+				return false
+			}
+		}
+		return true
+	case *types.AttributeValueMemberSS, *types.AttributeValueMemberNS, *types.AttributeValueMemberBS:
+		if typeName(a) != typeName(b) {
+			return false
+		}
+		as, bs := setKeys(a), setKeys(b)
+		if len(as) != len(bs) {
+			return false
+		}
+		for k := range as {
+			if !bs[k] {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func setKeys(av types.AttributeValue) map[string]bool {
+	keys := make(map[string]bool)
+	for _, elem := range setElements(av) {
+		k, _ := keyString(elem)
+		keys[k] = true
+	}
+	return keys
+}
+
+func setElements(av types.AttributeValue) []types.AttributeValue {
+	var elems []types.AttributeValue
+	switch v := av.(type) {
+	case *types.AttributeValueMemberSS:
+		for _, s := range v.Value {
+			elems = append(elems, &types.AttributeValueMemberS{Value: s})
+		}
+	case *types.AttributeValueMemberNS:
+		for _, n := range v.Value {
+			elems = append(elems, &types.AttributeValueMemberN{Value: n})
+		}
+	case *types.AttributeValueMemberBS:
+		for _, b := range v.Value {
+			elems = append(elems, &types.AttributeValueMemberB{Value: b})
+		}
+	}
+	return elems
+}
+
+func buildSet(setType string, elems []types.AttributeValue) types.AttributeValue {
+	sort.SliceStable(elems, func(i, j int) bool {
+		c, _ := compareValues(elems[i], elems[j])
+		return c < 0
+	})
+
+	switch setType {
+	case "SS":
+		ss := make([]string, 0, len(elems))
+		for _, e := range elems {
+			ss = append(ss, e.(*types.AttributeValueMemberS).Value)
+		}
+		return &types.AttributeValueMemberSS{Value: ss}
+	case "NS":
+		ns := make([]string, 0, len(elems))
+		for _, e := range elems {
+			ns = append(ns, e.(*types.AttributeValueMemberN).Value)
+		}
+		return &types.AttributeValueMemberNS{Value: ns}
+	default:
+		bs := make([][]byte, 0, len(elems))
+		for _, e := range elems {
+			bs = append(bs, e.(*types.AttributeValueMemberB).Value)
+		}
+		return &types.AttributeValueMemberBS{Value: bs}
+	}
+}
+
+func valueSize(av types.AttributeValue) (int, bool) {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return utf8.RuneCountInString(v.Value), true
+	case *types.AttributeValueMemberB:
+		return len(v.Value), true
+	case *types.AttributeValueMemberM:
+		return len(v.Value), true
+	case *types.AttributeValueMemberL:
+		return len(v.Value), true
+	case *types.AttributeValueMemberSS:
+		return len(v.Value), true
+	case *types.AttributeValueMemberNS:
+		return len(v.Value), true
+	case *types.AttributeValueMemberBS:
+		return len(v.Value), true
+	default:
+		return 0, false
+	}
+}
+
+func copyValue(av types.AttributeValue) types.AttributeValue {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return &types.AttributeValueMemberS{Value: v.Value}
+	case *types.AttributeValueMemberN:
+		return &types.AttributeValueMemberN{Value: v.Value}
+	case *types.AttributeValueMemberB:
+		return &types.AttributeValueMemberB{Value: append([]byte(nil), v.Value...)}
+	case *types.AttributeValueMemberBOOL:
+		return &types.AttributeValueMemberBOOL{Value: v.Value}
+	case *types.AttributeValueMemberNULL:
+		return &types.AttributeValueMemberNULL{Value: v.Value}
+	case *types.AttributeValueMemberM:
+		return &types.AttributeValueMemberM{Value: copyItem(v.Value)}
+	case *types.AttributeValueMemberL:
+		l := make([]types.AttributeValue, 0, len(v.Value))
+		for _, e := range v.Value {
+			l = append(l, copyValue(e))
+		}
+		return &types.AttributeValueMemberL{Value: l}
+	case *types.AttributeValueMemberSS:
+		return &types.AttributeValueMemberSS{Value: append([]string(nil), v.Value...)}
+	case *types.AttributeValueMemberNS:
+		return &types.AttributeValueMemberNS{Value: append([]string(nil), v.Value...)}
+	case *types.AttributeValueMemberBS:
+		bs := make([][]byte, 0, len(v.Value))
+		for _, b := range v.Value {
+			bs = append(bs, append([]byte(nil), b...))
+		}
+		return &types.AttributeValueMemberBS{Value: bs}
+	default:
+		return av
+	}
+}
+
+func copyItem(item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	if item == nil {
+		return nil
+	}
+
+	c := make(map[string]types.AttributeValue, len(item))
+	for k, v := range item {
+		c[k] = copyValue(v)
+	}
+	return c
+}
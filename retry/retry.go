@@ -0,0 +1,263 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsretry "github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"math"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultMaxAttempts  = 3
+	DefaultInitialDelay = 50 * time.Millisecond
+	DefaultMaxDelay     = 5 * time.Second
+	DefaultMaxElapsed   = 30 * time.Second
+	DefaultMultiplier   = 2
+)
+
+var retryableCodes = map[string]bool{
+	"ProvisionedThroughputExceededException": true,
+	"ProvisionedThroughputExceeded":          true,
+	"RequestLimitExceeded":                   true,
+	"ThrottlingException":                    true,
+	"Throttling":                             true,
+	"ThrottlingError":                        true,
+	"TransactionConflictException":           true,
+	"TransactionConflict":                    true,
+	"TransactionInProgressException":         true,
+	"InternalServerError":                    true,
+	"InternalFailure":                        true,
+	"ServiceUnavailable":                     true,
+	"LimitExceededException":                 true,
+}
+
+type Policy interface {
+	NextDelay(attempt int, elapsed time.Duration, err error) (time.Duration, bool)
+}
+
+type Classifier func(err error) bool
+
+type Backoff struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	MaxElapsed   time.Duration
+	Multiplier   float64
+	Retryable    Classifier
+}
+
+type never struct{}
+
+type nonIdempotent struct {
+	policy Policy
+}
+
+var (
+	mu            sync.RWMutex
+	defaultPolicy Policy
+	jitterMu      sync.Mutex
+	jitter        = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+func NewBackoff() Backoff {
+	return Backoff{
+		MaxAttempts:  DefaultMaxAttempts,
+		InitialDelay: DefaultInitialDelay,
+		MaxDelay:     DefaultMaxDelay,
+		MaxElapsed:   DefaultMaxElapsed,
+		Multiplier:   DefaultMultiplier,
+		Retryable:    IsRetryable,
+	}
+}
+
+func Never() Policy {
+	return never{}
+}
+
+func DefaultPolicy() Policy {
+	mu.RLock()
+	defer mu.RUnlock()
+	if defaultPolicy == nil {
+		return NewBackoff()
+	}
+	return defaultPolicy
+}
+
+func ClientPolicy(sdkRetries bool) Policy {
+	mu.RLock()
+	defer mu.RUnlock()
+	if defaultPolicy != nil {
+		return defaultPolicy
+	}
+	if sdkRetries {
+		return Never()
+	}
+	return NewBackoff()
+}
+
+func NonIdempotent(policy Policy) Policy {
+	if policy == nil {
+		policy = DefaultPolicy()
+	}
+	return nonIdempotent{policy: policy}
+}
+
+func SetDefaultPolicy(policy Policy) {
+	if policy == nil {
+		policy = Never()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	defaultPolicy = policy
+}
+
+func (b Backoff) NextDelay(attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+	b = b.withDefaults()
+	if b.MaxAttempts > 0 && attempt >= b.MaxAttempts {
+		return 0, false
+	}
+
+	retryable := b.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+	if err != nil && !retryable(err) {
+		return 0, false
+	}
+
+	delay := b.Delay(attempt)
+	if b.MaxElapsed > 0 && elapsed+delay > b.MaxElapsed {
+		return 0, false
+	}
+	return delay, true
+}
+
+func (b Backoff) Delay(attempt int) time.Duration {
+	b = b.withDefaults()
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = DefaultMultiplier
+	}
+
+	ceiling := float64(b.InitialDelay) * math.Pow(multiplier, float64(attempt-1))
+	if b.MaxDelay > 0 && ceiling > float64(b.MaxDelay) {
+		ceiling = float64(b.MaxDelay)
+	}
+	if ceiling < 1 {
+		return 0
+	}
+
+	jitterMu.Lock()
+	defer jitterMu.Unlock()
+	return time.Duration(jitter.Int63n(int64(ceiling) + 1))
+}
+
+func (b Backoff) withDefaults() Backoff {
+	if b.MaxAttempts == 0 {
+		b.MaxAttempts = DefaultMaxAttempts
+	}
+	if b.InitialDelay == 0 {
+		b.InitialDelay = DefaultInitialDelay
+	}
+	if b.MaxDelay == 0 {
+		b.MaxDelay = DefaultMaxDelay
+	}
+	if b.MaxElapsed == 0 {
+		b.MaxElapsed = DefaultMaxElapsed
+	}
+	return b
+}
+
+func (never) NextDelay(int, time.Duration, error) (time.Duration, bool) {
+	return 0, false
+}
+
+func (p nonIdempotent) NextDelay(attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+	if IsAmbiguous(err) {
+		return 0, false
+	}
+	return p.policy.NextDelay(attempt, elapsed, err)
+}
+
+func IsAmbiguous(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return false
+	}
+	if strings.Contains(err.Error(), "connection refused") {
+		return false
+	}
+
+	return awsretry.RetryableConnectionError{}.IsErrorRetryable(err) == aws.TrueTernary
+}
+
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if retryableCodes[dberrors.Code(err)] {
+		return true
+	}
+
+	if errors.Is(err, dberrors.ErrThrottled) || errors.Is(err, dberrors.ErrTransactionConflict) || errors.Is(err, dberrors.ErrInternal) {
+		return true
+	}
+
+	return awsretry.IsErrorRetryables(awsretry.DefaultRetryables).IsErrorRetryable(err) == aws.TrueTernary
+}
+
+func Do(ctx context.Context, policy Policy, fn func(ctx context.Context) error) error {
+	if policy == nil {
+		policy = DefaultPolicy()
+	}
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return err
+		}
+
+		delay, ok := policy.NextDelay(attempt, time.Since(start), err)
+		if !ok {
+			return err
+		}
+
+		if sleepErr := Sleep(ctx, delay); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
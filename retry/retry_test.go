@@ -0,0 +1,132 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"net"
+	"testing"
+)
+
+func TestClientPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured Policy
+		sdkRetries bool
+		retries    bool
+	}{
+		{name: "library backoff without SDK retries", retries: true},
+		{name: "no library retries on top of SDK retries", sdkRetries: true},
+		{name: "configured default wins over SDK retries", configured: Backoff{MaxAttempts: 2}, sdkRetries: true, retries: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mu.Lock()
+			previous := defaultPolicy
+			defaultPolicy = test.configured
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				defaultPolicy = previous
+				mu.Unlock()
+			}()
+
+			_, ok := ClientPolicy(test.sdkRetries).NextDelay(1, 0, &types.ProvisionedThroughputExceededException{})
+			if ok != test.retries {
+				t.Errorf("expected retries %v, got %v", test.retries, ok)
+			}
+		})
+	}
+}
+
+func TestIsAmbiguous(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		ambiguous bool
+	}{
+		{name: "nil"},
+		{name: "throttling", err: &types.ProvisionedThroughputExceededException{}},
+		{name: "cancelled context", err: fmt.Errorf("call failed: %w", context.Canceled)},
+		{name: "dial failure", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}},
+		{name: "connection reset", err: &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}, ambiguous: true},
+		{name: "wrapped connection reset", err: fmt.Errorf("request send failed: %w", errors.New("read tcp: connection reset by peer")), ambiguous: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := IsAmbiguous(test.err); got != test.ambiguous {
+				t.Errorf("expected %v, got %v", test.ambiguous, got)
+			}
+		})
+	}
+}
+
+func TestNonIdempotent(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		retries bool
+	}{
+		{name: "throttling is retried", err: &types.ProvisionedThroughputExceededException{}, retries: true},
+		{name: "dial failure is retried", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, retries: true},
+		{name: "connection reset is not retried", err: &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}},
+		{name: "validation is not retried", err: errors.New("validation failed")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := 0
+			err := Do(context.Background(), NonIdempotent(Backoff{MaxAttempts: 3}), func(ctx context.Context) error {
+				attempts++
+				return test.err
+			})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			expected := 1
+			if test.retries {
+				expected = 3
+			}
+			if attempts != expected {
+				t.Errorf("expected %d attempts, got %d", expected, attempts)
+			}
+		})
+	}
+}
+
+func TestZeroValueBackoff(t *testing.T) {
+	var b Backoff
+	throttled := &types.ProvisionedThroughputExceededException{}
+
+	attempts := 0
+	for attempt := 1; attempt <= 10; attempt++ {
+		delay, ok := b.NextDelay(attempt, 0, throttled)
+		if !ok {
+			break
+		}
+		attempts++
+
+		if ceiling := DefaultInitialDelay << (attempt - 1); delay > ceiling {
+			t.Errorf("attempt %d delay = %v, want at most %v", attempt, delay, ceiling)
+		}
+	}
+	if attempts != DefaultMaxAttempts-1 {
+		t.Errorf("retries = %d, want %d", attempts, DefaultMaxAttempts-1)
+	}
+
+	if _, ok := b.NextDelay(1, DefaultMaxElapsed, throttled); ok {
+		t.Error("expected no retry once the default elapsed budget is spent")
+	}
+
+	sawDelay := false
+	for i := 0; i < 20 && !sawDelay; i++ {
+		sawDelay = b.Delay(1) > 0
+	}
+	if !sawDelay {
+		t.Error("expected the zero value to wait between attempts")
+	}
+}
@@ -0,0 +1,185 @@
+package table
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/client"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/retry"
+	"time"
+)
+
+const DefaultTableWait = 5 * time.Minute
+
+func (db DB) GetClient() (*client.Wrapper, error) {
+	if db.Client != nil {
+		return db.Client, nil
+	}
+	return client.GetClient()
+}
+
+func (db DB) CreateTable(ctx context.Context, definition TableDefinition) (TableDescription, error) {
+	wrapper, err := db.GetClient()
+	if err != nil {
+		return TableDescription{}, err
+	}
+
+	input, err := definition.createTableInput()
+	if err != nil {
+		return TableDescription{}, err
+	}
+
+	log.Debugf("[%s] DynamoDB CREATE TABLE", definition.TableName)
+
+	var output *dynamodb.CreateTableOutput
+	err = callWithRetry(ctx, db.retryPolicy(), wrapper, func(ctx context.Context) error {
+		var err error
+		output, err = wrapper.AWSClient.CreateTable(ctx, input)
+		return err
+	})
+	if err != nil {
+		return TableDescription{}, dberrors.New("CreateTable", definition.TableName, "", err)
+	}
+
+	if definition.TTLAttribute == "" {
+		return newTableDescription(output.TableDescription), nil
+	}
+
+	if err := db.WaitUntilTableActive(ctx, definition.TableName, DefaultTableWait); err != nil {
+		return TableDescription{}, err
+	}
+
+	if err := db.updateTimeToLive(ctx, definition.TableName, definition.TTLAttribute, true); err != nil {
+		return TableDescription{}, err
+	}
+
+	return db.DescribeTable(ctx, definition.TableName)
+}
+
+func (db DB) DescribeTable(ctx context.Context, tableName string) (TableDescription, error) {
+	wrapper, err := db.GetClient()
+	if err != nil {
+		return TableDescription{}, err
+	}
+
+	output, err := describeTable(ctx, db.retryPolicy(), wrapper, tableName)
+	if err != nil {
+		return TableDescription{}, err
+	}
+	description := newTableDescription(output)
+
+	var ttl *dynamodb.DescribeTimeToLiveOutput
+	err = callWithRetry(ctx, db.retryPolicy(), wrapper, func(ctx context.Context) error {
+		var err error
+		ttl, err = wrapper.AWSClient.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{TableName: aws.String(tableName)})
+		return err
+	})
+	if err != nil {
+		return TableDescription{}, dberrors.New("DescribeTimeToLive", tableName, "", err)
+	}
+
+	if ttl.TimeToLiveDescription != nil {
+		description.TTLAttribute = aws.ToString(ttl.TimeToLiveDescription.AttributeName)
+		description.TTLStatus = string(ttl.TimeToLiveDescription.TimeToLiveStatus)
+	}
+
+	return description, nil
+}
+
+func (db DB) UpdateTable(ctx context.Context, tableName string, update TableUpdate) (TableDescription, error) {
+	wrapper, err := db.GetClient()
+	if err != nil {
+		return TableDescription{}, err
+	}
+
+	if update.changesTable() {
+		input, err := update.updateTableInput(tableName)
+		if err != nil {
+			return TableDescription{}, err
+		}
+
+		log.Debugf("[%s] DynamoDB UPDATE TABLE", tableName)
+
+		err = callWithRetry(ctx, db.retryPolicy(), wrapper, func(ctx context.Context) error {
+			_, err := wrapper.AWSClient.UpdateTable(ctx, input)
+			return err
+		})
+		if err != nil {
+			return TableDescription{}, dberrors.New("UpdateTable", tableName, "", err)
+		}
+	}
+
+	if update.TTLAttribute != nil {
+		attribute, enabled := *update.TTLAttribute, *update.TTLAttribute != ""
+		if !enabled {
+			current, err := db.DescribeTable(ctx, tableName)
+			if err != nil {
+				return TableDescription{}, err
+			}
+			attribute = current.TTLAttribute
+		}
+
+		if attribute != "" {
+			if err := db.updateTimeToLive(ctx, tableName, attribute, enabled); err != nil {
+				return TableDescription{}, err
+			}
+		}
+	}
+
+	return db.DescribeTable(ctx, tableName)
+}
+
+func (db DB) DeleteTable(ctx context.Context, tableName string) error {
+	wrapper, err := db.GetClient()
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("[%s] DynamoDB DELETE TABLE", tableName)
+
+	err = callWithRetry(ctx, db.retryPolicy(), wrapper, func(ctx context.Context) error {
+		_, err := wrapper.AWSClient.DeleteTable(ctx, &dynamodb.DeleteTableInput{TableName: aws.String(tableName)})
+		return err
+	})
+	if err != nil {
+		return dberrors.New("DeleteTable", tableName, "", err)
+	}
+	return nil
+}
+
+func (db DB) updateTimeToLive(ctx context.Context, tableName, attribute string, enabled bool) error {
+	wrapper, err := db.GetClient()
+	if err != nil {
+		return err
+	}
+
+	err = callWithRetry(ctx, db.retryPolicy(), wrapper, func(ctx context.Context) error {
+		_, err := wrapper.AWSClient.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+			TableName: aws.String(tableName),
+			TimeToLiveSpecification: &types.TimeToLiveSpecification{
+				AttributeName: aws.String(attribute),
+				Enabled:       aws.Bool(enabled),
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return dberrors.New("UpdateTimeToLive", tableName, "", err)
+	}
+	return nil
+}
+
+func describeTable(ctx context.Context, policy retry.Policy, wrapper *client.Wrapper, tableName string) (*types.TableDescription, error) {
+	var output *dynamodb.DescribeTableOutput
+	err := callWithRetry(ctx, policy, wrapper, func(ctx context.Context) error {
+		var err error
+		output, err = wrapper.AWSClient.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)})
+		return err
+	})
+	if err != nil {
+		return nil, dberrors.New("DescribeTable", tableName, "", err)
+	}
+	return output.Table, nil
+}
@@ -0,0 +1,129 @@
+package table_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jhmachado/dynamodb/client"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/memdb"
+	"github.com/jhmachado/dynamodb/table"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func indexNames(indexes []table.IndexDescription) []string {
+	names := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		names = append(names, index.IndexName)
+	}
+	return names
+}
+
+func TestTableLifecycle(t *testing.T) {
+	ctx := context.Background()
+	db := table.NewDB(client.NewWithAPI(memdb.New()))
+	sk := "sk"
+
+	created, err := db.CreateTable(ctx, table.TableDefinition{
+		TableName:      "sessions",
+		KeySchema:      table.KeySchema{PkName: "pk", SkName: &sk, SkType: table.AttributeTypeNumber},
+		AttributeTypes: map[string]table.AttributeType{"status": table.AttributeTypeString},
+		GlobalIndexes:  []table.IndexDefinition{{IndexName: "by-status", KeySchema: table.KeySchema{PkName: "status"}}},
+		TTLAttribute:   "expiresAt",
+	})
+	if err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+
+	if created.TableName != "sessions" || created.Status != "ACTIVE" || created.BillingMode != table.BillingModePayPerRequest {
+		t.Errorf("CreateTable() = %+v, want an active on-demand sessions table", created)
+	}
+	if created.KeySchema.PkName != "pk" || created.KeySchema.SkName == nil || *created.KeySchema.SkName != "sk" {
+		t.Errorf("KeySchema = %+v, want pk/sk", created.KeySchema)
+	}
+	if created.TTLAttribute != "expiresAt" || created.TTLStatus != "ENABLED" {
+		t.Errorf("TTL = %q %q, want expiresAt ENABLED", created.TTLAttribute, created.TTLStatus)
+	}
+	if got := indexNames(created.GlobalIndexes); !reflect.DeepEqual(got, []string{"by-status"}) {
+		t.Errorf("GlobalIndexes = %v, want [by-status]", got)
+	}
+
+	described, err := db.DescribeTable(ctx, "sessions")
+	if err != nil {
+		t.Fatalf("DescribeTable() error = %v", err)
+	}
+	if !reflect.DeepEqual(described, created) {
+		t.Errorf("DescribeTable() = %+v, want %+v", described, created)
+	}
+
+	disableTTL := ""
+	updated, err := db.UpdateTable(ctx, "sessions", table.TableUpdate{
+		CreateGlobalIndexes: []table.IndexDefinition{{IndexName: "by-owner", KeySchema: table.KeySchema{PkName: "owner"}}},
+		DeleteGlobalIndexes: []string{"by-status"},
+		TTLAttribute:        &disableTTL,
+	})
+	if err != nil {
+		t.Fatalf("UpdateTable() error = %v", err)
+	}
+	if updated.TTLStatus != "DISABLED" {
+		t.Errorf("TTLStatus = %q, want DISABLED", updated.TTLStatus)
+	}
+	if got := indexNames(updated.GlobalIndexes); !reflect.DeepEqual(got, []string{"by-owner"}) {
+		t.Errorf("GlobalIndexes = %v, want [by-owner]", got)
+	}
+
+	if err := db.WaitUntilIndexActive(ctx, "sessions", "by-owner", time.Second); err != nil {
+		t.Errorf("WaitUntilIndexActive() error = %v", err)
+	}
+	if err := db.WaitUntilIndexDeleted(ctx, "sessions", "by-status", time.Second); err != nil {
+		t.Errorf("WaitUntilIndexDeleted() error = %v", err)
+	}
+
+	enableTTL := "ttl"
+	updated, err = db.UpdateTable(ctx, "sessions", table.TableUpdate{TTLAttribute: &enableTTL})
+	if err != nil {
+		t.Fatalf("UpdateTable() error = %v", err)
+	}
+	if updated.TTLAttribute != "ttl" || updated.TTLStatus != "ENABLED" {
+		t.Errorf("TTL = %q %q, want ttl ENABLED", updated.TTLAttribute, updated.TTLStatus)
+	}
+
+	if err := db.DeleteTable(ctx, "sessions"); err != nil {
+		t.Fatalf("DeleteTable() error = %v", err)
+	}
+	if err := db.WaitUntilTableDeleted(ctx, "sessions", time.Second); err != nil {
+		t.Errorf("WaitUntilTableDeleted() error = %v", err)
+	}
+	if _, err := db.DescribeTable(ctx, "sessions"); !errors.Is(err, dberrors.ErrTableNotFound) {
+		t.Errorf("DescribeTable() error = %v, want %v", err, dberrors.ErrTableNotFound)
+	}
+	if err := db.DeleteTable(ctx, "sessions"); !errors.Is(err, dberrors.ErrTableNotFound) {
+		t.Errorf("DeleteTable() error = %v, want %v", err, dberrors.ErrTableNotFound)
+	}
+}
+
+func TestCreateTableValidation(t *testing.T) {
+	db := table.NewDB(client.NewWithAPI(memdb.New()))
+
+	tests := []struct {
+		name       string
+		definition table.TableDefinition
+	}{
+		{name: "missing partition key", definition: table.TableDefinition{TableName: "invalid"}},
+		{name: "provisioned without throughput", definition: table.TableDefinition{TableName: "invalid", KeySchema: table.KeySchema{PkName: "pk"}, BillingMode: table.BillingModeProvisioned}},
+		{name: "local index on another partition key", definition: table.TableDefinition{
+			TableName:    "invalid",
+			KeySchema:    table.KeySchema{PkName: "pk"},
+			LocalIndexes: []table.IndexDefinition{{IndexName: "local", KeySchema: table.KeySchema{PkName: "other"}}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := db.CreateTable(context.Background(), tt.definition); !errors.Is(err, dberrors.ErrValidation) {
+				t.Errorf("CreateTable() error = %v, want %v", err, dberrors.ErrValidation)
+			}
+		})
+	}
+}
@@ -6,16 +6,31 @@ import (
 
 func Write(ctx context.Context, t Table, items []interface{}, inputOptions InputOptions) (WriteReport, error) {
 	manager := &WriteManager{
-		tableName: t.TableName,
-		keySchema: t.KeySchema,
+		tableName:   t.TableName,
+		keySchema:   t.KeySchema,
+		client:      t.Client,
+		retryPolicy: t.retryPolicy(),
 	}
 
 	return manager.Write(ctx, items, inputOptions)
 }
 
+func Delete(ctx context.Context, t Table, keys []PrimaryKey, inputOptions InputOptions) (DeleteReport, error) {
+	manager := &WriteManager{
+		tableName:   t.TableName,
+		keySchema:   t.KeySchema,
+		client:      t.Client,
+		retryPolicy: t.retryPolicy(),
+	}
+
+	return manager.Delete(ctx, keys, inputOptions)
+}
+
 func Execute(ctx context.Context, t Table, stmts []PartiQLCommand, inputOptions InputOptions) (ExecutionReport, error) {
 	manager := &WriteManager{
-		tableName: t.TableName,
+		tableName:   t.TableName,
+		client:      t.Client,
+		retryPolicy: t.retryPolicy(),
 	}
 
 	return manager.Execute(ctx, stmts, inputOptions)
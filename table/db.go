@@ -0,0 +1,40 @@
+package table
+
+import (
+	"github.com/jhmachado/dynamodb/client"
+	"github.com/jhmachado/dynamodb/retry"
+)
+
+type DB struct {
+	Client      *client.Wrapper
+	RetryPolicy retry.Policy
+}
+
+func NewDB(c *client.Wrapper) DB {
+	return DB{Client: c}
+}
+
+func (db DB) WithRetryPolicy(policy retry.Policy) DB {
+	db.RetryPolicy = policy
+	return db
+}
+
+func (db DB) retryPolicy() retry.Policy {
+	return db.RetryPolicy
+}
+
+func (db DB) Table(tableName string, keySchema KeySchema, resolver EntityResolver) Table {
+	return Table{
+		TableName:      tableName,
+		KeySchema:      keySchema,
+		EntityResolver: resolver,
+		Client:         db.Client,
+		RetryPolicy:    db.RetryPolicy,
+	}
+}
+
+func (db DB) Index(tableName, indexName string, keySchema KeySchema, resolver EntityResolver) Table {
+	t := db.Table(tableName, keySchema, resolver)
+	t.IndexName = &indexName
+	return t
+}
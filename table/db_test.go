@@ -0,0 +1,102 @@
+package table_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jhmachado/dynamodb/client"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/memdb"
+	"github.com/jhmachado/dynamodb/table"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+type userRecord struct {
+	Pk     string `dynamodbav:"pk"`
+	Status string `dynamodbav:"status"`
+}
+
+func newUsersDB(t *testing.T) table.DB {
+	t.Helper()
+
+	db := table.NewDB(client.NewWithAPI(memdb.New()))
+	_, err := db.CreateTable(context.Background(), table.TableDefinition{
+		TableName:     "users",
+		KeySchema:     table.KeySchema{PkName: "pk"},
+		GlobalIndexes: []table.IndexDefinition{{IndexName: "by-status", KeySchema: table.KeySchema{PkName: "status"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	return db
+}
+
+func userKeys(t *testing.T, tbl table.Table) []string {
+	t.Helper()
+
+	entities, err := tbl.Scan(context.Background(), table.InputOptions{})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	keys := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		keys = append(keys, entity.(map[string]interface{})["pk"].(string))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestDBUsesItsOwnClient(t *testing.T) {
+	if _, err := client.GetClient(); !errors.Is(err, dberrors.ErrClientNotInitialized) {
+		t.Fatalf("GetClient() error = %v, want no global client", err)
+	}
+
+	ctx := context.Background()
+	first, second := newUsersDB(t), newUsersDB(t)
+	users := first.Table("users", table.KeySchema{PkName: "pk"}, nil)
+	others := second.Table("users", table.KeySchema{PkName: "pk"}, nil)
+
+	items := []interface{}{
+		userRecord{Pk: "a", Status: "active"},
+		userRecord{Pk: "b", Status: "active"},
+		userRecord{Pk: "c", Status: "blocked"},
+	}
+	if _, err := table.Write(ctx, users, items, nil); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if _, err := table.Delete(ctx, users, []table.PrimaryKey{stringKey{Pk: "b"}}, nil); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	_, err := table.Execute(ctx, users, []table.PartiQLCommand{
+		{Statement: `UPDATE "users" SET status = ? WHERE pk = ?`, Tokens: []interface{}{"active", "c"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if got := userKeys(t, users); !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Errorf("items in the first DB = %v, want [a c]", got)
+	}
+	if got := userKeys(t, others); len(got) != 0 {
+		t.Errorf("items in the second DB = %v, want none", got)
+	}
+
+	byStatus := first.Index("users", "by-status", table.KeySchema{PkName: "status"}, nil)
+	active, err := byStatus.Query(ctx, "active", table.InputOptions{})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(active) != 2 {
+		t.Errorf("Query() on the index = %v, want the two active users", active)
+	}
+
+	unbound := table.Table{TableName: "users", KeySchema: table.KeySchema{PkName: "pk"}}
+	if _, err := table.Write(ctx, unbound, items, nil); !errors.Is(err, dberrors.ErrClientNotInitialized) {
+		t.Errorf("Write() without a client error = %v, want %v", err, dberrors.ErrClientNotInitialized)
+	}
+	if _, err := (table.DB{}).DescribeTable(ctx, "users"); !errors.Is(err, dberrors.ErrClientNotInitialized) {
+		t.Errorf("DescribeTable() without a client error = %v, want %v", err, dberrors.ErrClientNotInitialized)
+	}
+}
@@ -1,7 +1,7 @@
 package table
 
 import (
-	"errors"
+	"fmt"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/jhmachado/dynamodb/util"
@@ -24,7 +24,7 @@ func (d *EntitiesDecoder) AttributeMapsToEntities(items []map[string]types.Attri
 
 	err = attributevalue.UnmarshalListOfMaps(items, &entities)
 	if err != nil {
-		return nil, errors.New("failed to deserialize dynamodb items")
+		return nil, fmt.Errorf("failed to deserialize dynamodb items: %w", err)
 	}
 
 	return entities, nil
@@ -38,23 +38,15 @@ func (d *EntitiesDecoder) ResolveZeroEntities(items []map[string]types.Attribute
 
 	pkEntityIndices := make([]int, 0)
 	for _, item := range items {
-		pk := item[d.KeySchema.PkName].(*types.AttributeValueMemberS).Value
-		var sk interface{}
-		var err error
-
-		if d.KeySchema.SkName != nil {
-			av := item[*d.KeySchema.SkName]
-			sk, err = util.CastAttributeValue(av)
+		var primaryKey PrimaryKey = util.MemoryOnlyPrimaryKey{}
+		if d.EntityResolver != nil {
+			var err error
+			primaryKey, err = GetPrimaryKeyFromAvMap(item, d.KeySchema)
 			if err != nil {
 				return nil, err
 			}
 		}
 
-		primaryKey := util.MemoryOnlyPrimaryKey{
-			Pk: pk,
-			Sk: sk,
-		}
-
 		zeroEntity, isPkEntity := d.ResolveZeroEntity(primaryKey)
 		if isPkEntity {
 			pkEntityIndices = append(pkEntityIndices, len(zeroEntities))
@@ -81,3 +73,20 @@ func (d *EntitiesDecoder) ResolveZeroEntity(primaryKey PrimaryKey) (interface{},
 
 	return zeroEntity, isPkEntity
 }
+
+func (d *EntitiesDecoder) AttributeMapToEntity(primaryKey PrimaryKey, item map[string]types.AttributeValue) (interface{}, error) {
+	entity, _ := d.ResolveZeroEntity(primaryKey)
+
+	if m, ok := entity.(map[string]interface{}); ok {
+		if err := attributevalue.UnmarshalMap(item, &m); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+
+	if err := attributevalue.UnmarshalMap(item, entity); err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,408 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"time"
+)
+
+type AttributeType string
+
+const (
+	AttributeTypeString AttributeType = "S"
+	AttributeTypeNumber AttributeType = "N"
+	AttributeTypeBinary AttributeType = "B"
+)
+
+type BillingMode string
+
+const (
+	BillingModePayPerRequest BillingMode = "PAY_PER_REQUEST"
+	BillingModeProvisioned   BillingMode = "PROVISIONED"
+)
+
+type ProjectionType string
+
+const (
+	ProjectionAll      ProjectionType = "ALL"
+	ProjectionKeysOnly ProjectionType = "KEYS_ONLY"
+	ProjectionInclude  ProjectionType = "INCLUDE"
+)
+
+type StreamViewType string
+
+const (
+	StreamDisabled       StreamViewType = ""
+	StreamKeysOnly       StreamViewType = "KEYS_ONLY"
+	StreamNewImage       StreamViewType = "NEW_IMAGE"
+	StreamOldImage       StreamViewType = "OLD_IMAGE"
+	StreamNewAndOldImage StreamViewType = "NEW_AND_OLD_IMAGES"
+)
+
+type Throughput struct {
+	ReadCapacityUnits  int64
+	WriteCapacityUnits int64
+}
+
+type IndexDefinition struct {
+	IndexName        string
+	KeySchema        KeySchema
+	Projection       ProjectionType
+	NonKeyAttributes []string
+	Throughput       *Throughput
+}
+
+type TableDefinition struct {
+	TableName      string
+	KeySchema      KeySchema
+	AttributeTypes map[string]AttributeType
+	BillingMode    BillingMode
+	Throughput     *Throughput
+	GlobalIndexes  []IndexDefinition
+	LocalIndexes   []IndexDefinition
+	Stream         StreamViewType
+	TTLAttribute   string
+}
+
+type TableUpdate struct {
+	BillingMode         BillingMode
+	Throughput          *Throughput
+	AttributeTypes      map[string]AttributeType
+	CreateGlobalIndexes []IndexDefinition
+	UpdateGlobalIndexes []IndexDefinition
+	DeleteGlobalIndexes []string
+	Stream              *StreamViewType
+	TTLAttribute        *string
+}
+
+type IndexDescription struct {
+	IndexName  string
+	KeySchema  KeySchema
+	Status     string
+	Projection ProjectionType
+	ItemCount  int64
+	Throughput *Throughput
+}
+
+type TableDescription struct {
+	TableName     string
+	TableArn      string
+	KeySchema     KeySchema
+	Status        string
+	BillingMode   BillingMode
+	Throughput    *Throughput
+	ItemCount     int64
+	GlobalIndexes []IndexDescription
+	LocalIndexes  []IndexDescription
+	Stream        StreamViewType
+	StreamArn     string
+	TTLAttribute  string
+	TTLStatus     string
+	CreatedAt     time.Time
+}
+
+func (d TableDefinition) createTableInput() (*dynamodb.CreateTableInput, error) {
+	if d.TableName == "" || d.KeySchema.PkName == "" {
+		return nil, dberrors.NewKind(dberrors.ErrValidation, "CreateTable", d.TableName, "", errors.New("table name and partition key are required"))
+	}
+
+	billingMode := d.BillingMode
+	if billingMode == "" {
+		billingMode = BillingModePayPerRequest
+	}
+	if billingMode == BillingModeProvisioned && d.Throughput == nil {
+		return nil, dberrors.NewKind(dberrors.ErrValidation, "CreateTable", d.TableName, "", errors.New("throughput is required for provisioned billing mode"))
+	}
+
+	definitions := attributeDefinitions{attributeTypes: d.AttributeTypes}
+	definitions.add(d.KeySchema)
+
+	input := &dynamodb.CreateTableInput{
+		TableName:   aws.String(d.TableName),
+		KeySchema:   keySchemaElements(d.KeySchema),
+		BillingMode: types.BillingMode(billingMode),
+	}
+	if billingMode == BillingModeProvisioned {
+		input.ProvisionedThroughput = d.Throughput.provisioned()
+	}
+
+	for _, index := range d.GlobalIndexes {
+		if err := index.validate(d.TableName); err != nil {
+			return nil, err
+		}
+		definitions.add(index.KeySchema)
+
+		gsi := types.GlobalSecondaryIndex{
+			IndexName:  aws.String(index.IndexName),
+			KeySchema:  keySchemaElements(index.KeySchema),
+			Projection: index.projection(),
+		}
+		if billingMode == BillingModeProvisioned {
+			throughput := index.Throughput
+			if throughput == nil {
+				throughput = d.Throughput
+			}
+			gsi.ProvisionedThroughput = throughput.provisioned()
+		}
+		input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, gsi)
+	}
+
+	for _, index := range d.LocalIndexes {
+		if err := index.validate(d.TableName); err != nil {
+			return nil, err
+		}
+		if index.KeySchema.PkName != d.KeySchema.PkName || index.KeySchema.SkName == nil {
+			return nil, dberrors.NewKind(dberrors.ErrValidation, "CreateTable", d.TableName, "", fmt.Errorf("local index %s must use the table partition key and a sort key", index.IndexName))
+		}
+		definitions.add(index.KeySchema)
+
+		input.LocalSecondaryIndexes = append(input.LocalSecondaryIndexes, types.LocalSecondaryIndex{
+			IndexName:  aws.String(index.IndexName),
+			KeySchema:  keySchemaElements(index.KeySchema),
+			Projection: index.projection(),
+		})
+	}
+
+	if err := definitions.err; err != nil {
+		return nil, dberrors.NewKind(dberrors.ErrValidation, "CreateTable", d.TableName, "", err)
+	}
+	input.AttributeDefinitions = definitions.list
+
+	if d.Stream != StreamDisabled {
+		input.StreamSpecification = &types.StreamSpecification{
+			StreamEnabled:  aws.Bool(true),
+			StreamViewType: types.StreamViewType(d.Stream),
+		}
+	}
+
+	return input, nil
+}
+
+func (u TableUpdate) updateTableInput(tableName string) (*dynamodb.UpdateTableInput, error) {
+	input := &dynamodb.UpdateTableInput{
+		TableName:   aws.String(tableName),
+		BillingMode: types.BillingMode(u.BillingMode),
+	}
+	if u.Throughput != nil {
+		input.ProvisionedThroughput = u.Throughput.provisioned()
+	}
+
+	definitions := attributeDefinitions{attributeTypes: u.AttributeTypes}
+	for _, index := range u.CreateGlobalIndexes {
+		if err := index.validate(tableName); err != nil {
+			return nil, err
+		}
+		definitions.add(index.KeySchema)
+
+		create := &types.CreateGlobalSecondaryIndexAction{
+			IndexName:  aws.String(index.IndexName),
+			KeySchema:  keySchemaElements(index.KeySchema),
+			Projection: index.projection(),
+		}
+		if index.Throughput != nil {
+			create.ProvisionedThroughput = index.Throughput.provisioned()
+		}
+		input.GlobalSecondaryIndexUpdates = append(input.GlobalSecondaryIndexUpdates, types.GlobalSecondaryIndexUpdate{Create: create})
+	}
+
+	if err := definitions.err; err != nil {
+		return nil, dberrors.NewKind(dberrors.ErrValidation, "UpdateTable", tableName, "", err)
+	}
+	input.AttributeDefinitions = definitions.list
+
+	for _, index := range u.UpdateGlobalIndexes {
+		if index.IndexName == "" || index.Throughput == nil {
+			return nil, dberrors.NewKind(dberrors.ErrValidation, "UpdateTable", tableName, "", errors.New("index name and throughput are required to update an index"))
+		}
+		input.GlobalSecondaryIndexUpdates = append(input.GlobalSecondaryIndexUpdates, types.GlobalSecondaryIndexUpdate{
+			Update: &types.UpdateGlobalSecondaryIndexAction{
+				IndexName:             aws.String(index.IndexName),
+				ProvisionedThroughput: index.Throughput.provisioned(),
+			},
+		})
+	}
+
+	for _, name := range u.DeleteGlobalIndexes {
+		input.GlobalSecondaryIndexUpdates = append(input.GlobalSecondaryIndexUpdates, types.GlobalSecondaryIndexUpdate{
+			Delete: &types.DeleteGlobalSecondaryIndexAction{IndexName: aws.String(name)},
+		})
+	}
+
+	if u.Stream != nil {
+		input.StreamSpecification = &types.StreamSpecification{StreamEnabled: aws.Bool(*u.Stream != StreamDisabled)}
+		if *u.Stream != StreamDisabled {
+			input.StreamSpecification.StreamViewType = types.StreamViewType(*u.Stream)
+		}
+	}
+
+	return input, nil
+}
+
+func (u TableUpdate) changesTable() bool {
+	return u.BillingMode != "" || u.Throughput != nil || u.Stream != nil ||
+		len(u.CreateGlobalIndexes) > 0 || len(u.UpdateGlobalIndexes) > 0 || len(u.DeleteGlobalIndexes) > 0
+}
+
+func (i IndexDefinition) validate(tableName string) error {
+	if i.IndexName == "" || i.KeySchema.PkName == "" {
+		return dberrors.NewKind(dberrors.ErrValidation, "CreateIndex", tableName, "", errors.New("index name and partition key are required"))
+	}
+	return nil
+}
+
+func (i IndexDefinition) projection() *types.Projection {
+	projection := &types.Projection{ProjectionType: types.ProjectionType(i.Projection)}
+	if projection.ProjectionType == "" {
+		projection.ProjectionType = types.ProjectionTypeAll
+	}
+	if projection.ProjectionType == types.ProjectionTypeInclude {
+		projection.NonKeyAttributes = i.NonKeyAttributes
+	}
+	return projection
+}
+
+func (t *Throughput) provisioned() *types.ProvisionedThroughput {
+	return &types.ProvisionedThroughput{
+		ReadCapacityUnits:  aws.Int64(t.ReadCapacityUnits),
+		WriteCapacityUnits: aws.Int64(t.WriteCapacityUnits),
+	}
+}
+
+type attributeDefinitions struct {
+	attributeTypes map[string]AttributeType
+	seen           map[string]bool
+	list           []types.AttributeDefinition
+	err            error
+}
+
+func (a *attributeDefinitions) add(keySchema KeySchema) {
+	if a.seen == nil {
+		a.seen = make(map[string]bool)
+	}
+
+	names := []string{keySchema.PkName}
+	keyTypes := []AttributeType{keySchema.PartitionKeyType()}
+	if keySchema.SkName != nil {
+		names = append(names, *keySchema.SkName)
+		keyTypes = append(keyTypes, keySchema.SortKeyType())
+	}
+
+	for i, name := range names {
+		if a.seen[name] {
+			continue
+		}
+		a.seen[name] = true
+
+		attributeType, ok := a.attributeTypes[name]
+		if !ok {
+			attributeType = keyTypes[i]
+		}
+		switch attributeType {
+		case AttributeTypeString, AttributeTypeNumber, AttributeTypeBinary:
+		default:
+			if a.err == nil {
+				a.err = fmt.Errorf("invalid attribute type %q for %s", attributeType, name)
+			}
+			continue
+		}
+
+		a.list = append(a.list, types.AttributeDefinition{
+			AttributeName: aws.String(name),
+			AttributeType: types.ScalarAttributeType(attributeType),
+		})
+	}
+}
+
+func keySchemaElements(keySchema KeySchema) []types.KeySchemaElement {
+	elements := []types.KeySchemaElement{{AttributeName: aws.String(keySchema.PkName), KeyType: types.KeyTypeHash}}
+	if keySchema.SkName != nil {
+		elements = append(elements, types.KeySchemaElement{AttributeName: aws.String(*keySchema.SkName), KeyType: types.KeyTypeRange})
+	}
+	return elements
+}
+
+func keySchemaFromElements(elements []types.KeySchemaElement, definitions []types.AttributeDefinition) KeySchema {
+	attributeTypes := make(map[string]AttributeType, len(definitions))
+	for _, definition := range definitions {
+		attributeTypes[aws.ToString(definition.AttributeName)] = AttributeType(definition.AttributeType)
+	}
+
+	var keySchema KeySchema
+	for _, element := range elements {
+		name := aws.ToString(element.AttributeName)
+		switch element.KeyType {
+		case types.KeyTypeHash:
+			keySchema.PkName = name
+			keySchema.PkType = attributeTypes[name]
+		case types.KeyTypeRange:
+			keySchema.SkName = aws.String(name)
+			keySchema.SkType = attributeTypes[name]
+		}
+	}
+	return keySchema
+}
+
+func throughputFromDescription(d *types.ProvisionedThroughputDescription) *Throughput {
+	if d == nil || (aws.ToInt64(d.ReadCapacityUnits) == 0 && aws.ToInt64(d.WriteCapacityUnits) == 0) {
+		return nil
+	}
+	return &Throughput{
+		ReadCapacityUnits:  aws.ToInt64(d.ReadCapacityUnits),
+		WriteCapacityUnits: aws.ToInt64(d.WriteCapacityUnits),
+	}
+}
+
+func newTableDescription(d *types.TableDescription) TableDescription {
+	description := TableDescription{
+		TableName:   aws.ToString(d.TableName),
+		TableArn:    aws.ToString(d.TableArn),
+		KeySchema:   keySchemaFromElements(d.KeySchema, d.AttributeDefinitions),
+		Status:      string(d.TableStatus),
+		BillingMode: BillingModeProvisioned,
+		Throughput:  throughputFromDescription(d.ProvisionedThroughput),
+		ItemCount:   aws.ToInt64(d.ItemCount),
+		StreamArn:   aws.ToString(d.LatestStreamArn),
+		CreatedAt:   aws.ToTime(d.CreationDateTime),
+	}
+
+	if d.BillingModeSummary != nil && d.BillingModeSummary.BillingMode != "" {
+		description.BillingMode = BillingMode(d.BillingModeSummary.BillingMode)
+	}
+
+	if d.StreamSpecification != nil && aws.ToBool(d.StreamSpecification.StreamEnabled) {
+		description.Stream = StreamViewType(d.StreamSpecification.StreamViewType)
+	}
+
+	for _, gsi := range d.GlobalSecondaryIndexes {
+		index := IndexDescription{
+			IndexName:  aws.ToString(gsi.IndexName),
+			KeySchema:  keySchemaFromElements(gsi.KeySchema, d.AttributeDefinitions),
+			Status:     string(gsi.IndexStatus),
+			ItemCount:  aws.ToInt64(gsi.ItemCount),
+			Throughput: throughputFromDescription(gsi.ProvisionedThroughput),
+		}
+		if gsi.Projection != nil {
+			index.Projection = ProjectionType(gsi.Projection.ProjectionType)
+		}
+		description.GlobalIndexes = append(description.GlobalIndexes, index)
+	}
+
+	for _, lsi := range d.LocalSecondaryIndexes {
+		index := IndexDescription{
+			IndexName: aws.ToString(lsi.IndexName),
+			KeySchema: keySchemaFromElements(lsi.KeySchema, d.AttributeDefinitions),
+			Status:    string(types.IndexStatusActive),
+			ItemCount: aws.ToInt64(lsi.ItemCount),
+		}
+		if lsi.Projection != nil {
+			index.Projection = ProjectionType(lsi.Projection.ProjectionType)
+		}
+		description.LocalIndexes = append(description.LocalIndexes, index)
+	}
+
+	return description
+}
@@ -0,0 +1,86 @@
+package table
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/jhmachado/dynamodb/dberrors"
+)
+
+func deleteItem(ctx context.Context, table Table, primaryKey PrimaryKey, inputOptions InputOptions) (interface{}, error) {
+	client, err := table.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	formattedPk := FormatPrimaryKey(primaryKey, &table.KeySchema)
+	log.Debugf("[%s] DynamoDB DELETE with primary key, %s", table.CollectionName(), formattedPk)
+
+	deleteItemInput, err := buildDeleteItemInput(table, primaryKey, inputOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var output *dynamodb.DeleteItemOutput
+	err = callWithWriteRetry(ctx, table.retryPolicy(), client, func(ctx context.Context) error {
+		var err error
+		output, err = client.AWSClient.DeleteItem(ctx, deleteItemInput)
+		return err
+	})
+	if err != nil {
+		return nil, dberrors.New("DeleteItem", table.TableName, formattedPk, err)
+	}
+
+	if len(output.Attributes) == 0 {
+		return nil, nil
+	}
+
+	decoder := EntitiesDecoder{
+		EntityResolver: table.EntityResolver,
+		KeySchema:      table.KeySchema,
+	}
+
+	entity, err := decoder.AttributeMapToEntity(primaryKey, output.Attributes)
+	if err != nil {
+		return nil, dberrors.NewKind(dberrors.ErrUnmarshal, "DeleteItem", table.TableName, formattedPk, err)
+	}
+
+	return entity, nil
+}
+
+func buildDeleteItemInput(table Table, primaryKey PrimaryKey, inputOptions InputOptions) (*dynamodb.DeleteItemInput, error) {
+	avs, err := attributevalue.MarshalMap(primaryKey)
+	if err != nil {
+		return nil, dberrors.NewKind(dberrors.ErrMarshal, "DeleteItem", table.TableName, FormatPrimaryKey(primaryKey, &table.KeySchema), err)
+	}
+
+	deleteItemInput := &dynamodb.DeleteItemInput{
+		TableName: &table.TableName,
+		Key:       avs,
+	}
+
+	if filter, ok := inputOptions[conditionExpression]; ok {
+		expr := filter.(string)
+		deleteItemInput.ConditionExpression = &expr
+	}
+
+	if tvs, ok := inputOptions[optTokenValues]; ok {
+		avs, err := attributevalue.MarshalMap(tvs.(map[string]interface{}))
+		if err != nil {
+			return nil, dberrors.NewKind(dberrors.ErrMarshal, "DeleteItem", table.TableName, "", err)
+		}
+		deleteItemInput.ExpressionAttributeValues = avs
+	}
+
+	if substitutions, ok := inputOptions[optTokenNameSubstitutions]; ok {
+		deleteItemInput.ExpressionAttributeNames = substitutions.(map[string]string)
+	}
+
+	rv, err := itemReturnValues("DeleteItem", table.TableName, inputOptions)
+	if err != nil {
+		return nil, err
+	}
+	deleteItemInput.ReturnValues = rv
+
+	return deleteItemInput, nil
+}
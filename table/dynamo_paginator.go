@@ -6,14 +6,18 @@ import (
 	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/jhmachado/dynamodb/client"
-	"github.com/jhmachado/dynamodb/util"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/retry"
 )
 
 type DynamoPaginator struct {
-	queryPaginator  *ddb.QueryPaginator
-	scanPaginator   *ddb.ScanPaginator
-	entitiesDecoder EntitiesDecoder
-	logOpts         []string
+	queryPaginator     *ddb.QueryPaginator
+	scanPaginator      *ddb.ScanPaginator
+	statementPaginator *statementPaginator
+	entitiesDecoder    EntitiesDecoder
+	logOpts            []string
+	clientWrapper      *client.Wrapper
+	retryPolicy        retry.Policy
 }
 
 func (p *DynamoPaginator) HasMorePages() bool {
@@ -21,33 +25,64 @@ func (p *DynamoPaginator) HasMorePages() bool {
 		return p.queryPaginator.HasMorePages()
 	}
 
+	if p.statementPaginator != nil {
+		return p.statementPaginator.HasMorePages()
+	}
+
 	return p.scanPaginator.HasMorePages()
 }
 
 func (p *DynamoPaginator) NextPage(ctx context.Context) ([]interface{}, error) {
-	clientWrapper, err := client.GetClient()
+	clientWrapper := p.clientWrapper
+	if clientWrapper == nil {
+		var err error
+		clientWrapper, err = client.GetClient()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var items []map[string]types.AttributeValue
+	err := callWithRetry(ctx, p.retryPolicy, clientWrapper, func(ctx context.Context) error {
+		var err error
+		items, err = p.extractItems(ctx)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	dbCtx, cancel := util.BuildDBContext(ctx, clientWrapper.TimeoutsMs)
-	if cancel != nil {
-		defer cancel()
+	entities, err := p.entitiesDecoder.AttributeMapsToEntities(items)
+	if err != nil {
+		return nil, dberrors.NewKind(dberrors.ErrUnmarshal, "NextPage", p.collectionName(), p.partitionKey(), err)
 	}
 
-	items, err := p.extractItems(dbCtx)
-	if err != nil {
-		return nil, err
+	return entities, nil
+}
+
+func (p DynamoPaginator) wrapError(op string, err error) error {
+	return dberrors.New(op, p.collectionName(), p.partitionKey(), err)
+}
+
+func (p DynamoPaginator) collectionName() string {
+	if len(p.logOpts) > 0 {
+		return p.logOpts[0]
 	}
+	return ""
+}
 
-	return p.entitiesDecoder.AttributeMapsToEntities(items)
+func (p DynamoPaginator) partitionKey() string {
+	if len(p.logOpts) > 1 {
+		return p.logOpts[1]
+	}
+	return ""
 }
 
 func (p DynamoPaginator) extractItems(dbCtx context.Context) ([]map[string]types.AttributeValue, error) {
 	if p.queryPaginator != nil {
 		queryOutput, err := p.queryPaginator.NextPage(dbCtx)
 		if err != nil {
-			return nil, errors.New(util.FormatErrorMessage("pagination failed for query", p.logOpts))
+			return nil, p.wrapError("Query", err)
 		}
 
 		return queryOutput.Items, nil
@@ -56,45 +91,56 @@ func (p DynamoPaginator) extractItems(dbCtx context.Context) ([]map[string]types
 	if p.scanPaginator != nil {
 		scanOutput, err := p.scanPaginator.NextPage(dbCtx)
 		if err != nil {
-			return nil, errors.New(util.FormatErrorMessage("pagination failed for scan", p.logOpts))
+			return nil, p.wrapError("Scan", err)
 		}
 
 		return scanOutput.Items, nil
 	}
 
+	if p.statementPaginator != nil {
+		statementOutput, err := p.statementPaginator.NextPage(dbCtx)
+		if err != nil {
+			return nil, p.wrapError("ExecuteStatement", err)
+		}
+
+		return statementOutput.Items, nil
+	}
+
 	return nil, errors.New("no paginator provided")
 }
 
 func NewQueryPaginator(
-	client *ddb.Client,
+	api client.DynamoDBAPI,
 	resolver EntityResolver,
 	keySchema KeySchema,
 	opts *ddb.QueryInput,
 	logOpts []string,
 ) Paginator {
 	return &DynamoPaginator{
-		queryPaginator: ddb.NewQueryPaginator(client, opts),
+		queryPaginator: ddb.NewQueryPaginator(api, opts),
 		entitiesDecoder: EntitiesDecoder{
 			EntityResolver: resolver,
 			KeySchema:      keySchema,
 		},
-		logOpts: logOpts,
+		logOpts:       logOpts,
+		clientWrapper: client.NewWithAPI(api),
 	}
 }
 
 func NewScanPaginator(
-	client *ddb.Client,
+	api client.DynamoDBAPI,
 	resolver EntityResolver,
 	keySchema KeySchema,
 	opts *ddb.ScanInput,
 	logOpts []string,
 ) Paginator {
 	return &DynamoPaginator{
-		scanPaginator: ddb.NewScanPaginator(client, opts),
+		scanPaginator: ddb.NewScanPaginator(api, opts),
 		entitiesDecoder: EntitiesDecoder{
 			EntityResolver: resolver,
 			KeySchema:      keySchema,
 		},
-		logOpts: logOpts,
+		logOpts:       logOpts,
+		clientWrapper: client.NewWithAPI(api),
 	}
 }
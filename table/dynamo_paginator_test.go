@@ -0,0 +1,85 @@
+package table_test
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/client"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/dynamodbtest"
+	"github.com/jhmachado/dynamodb/memdb"
+	"github.com/jhmachado/dynamodb/table"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestPaginatorsWithInjectedAPI(t *testing.T) {
+	if _, err := client.GetClient(); !errors.Is(err, dberrors.ErrClientNotInitialized) {
+		t.Fatalf("GetClient() error = %v, want the global client to be unset", err)
+	}
+
+	sk := "sk"
+	db := memdb.New()
+	tbl := dynamodbtest.NewTable(t, table.Table{TableName: "pages", KeySchema: table.KeySchema{PkName: "pk", SkName: &sk}}, dynamodbtest.WithMemDB(db))
+	dynamodbtest.Seed(t, tbl,
+		compositeKey{Pk: "a", Sk: "1"},
+		compositeKey{Pk: "a", Sk: "2"},
+		compositeKey{Pk: "a", Sk: "3"},
+		compositeKey{Pk: "b", Sk: "1"},
+	)
+
+	tests := []struct {
+		name      string
+		paginator table.Paginator
+		want      []string
+	}{
+		{
+			name: "query",
+			paginator: table.NewQueryPaginator(db, nil, tbl.KeySchema, &ddb.QueryInput{
+				TableName:                 aws.String(tbl.TableName),
+				KeyConditionExpression:    aws.String("pk = :pk"),
+				ExpressionAttributeValues: map[string]types.AttributeValue{":pk": &types.AttributeValueMemberS{Value: "a"}},
+				Limit:                     aws.Int32(2),
+			}, []string{tbl.TableName, "a"}),
+			want: []string{"a#1", "a#2", "a#3"},
+		},
+		{
+			name: "scan",
+			paginator: table.NewScanPaginator(db, nil, tbl.KeySchema, &ddb.ScanInput{
+				TableName: aws.String(tbl.TableName),
+				Limit:     aws.Int32(3),
+			}, []string{tbl.TableName}),
+			want: []string{"a#1", "a#2", "a#3", "b#1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			pages := 0
+			for tt.paginator.HasMorePages() {
+				page, err := tt.paginator.NextPage(context.Background())
+				if err != nil {
+					t.Fatalf("NextPage() error = %v", err)
+				}
+				pages++
+
+				for _, entity := range page {
+					item := entity.(map[string]interface{})
+					got = append(got, item["pk"].(string)+"#"+item["sk"].(string))
+				}
+			}
+
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("items = %v, want %v", got, tt.want)
+			}
+			if pages < 2 {
+				t.Errorf("pages = %d, want the results split across pages", pages)
+			}
+		})
+	}
+}
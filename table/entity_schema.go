@@ -0,0 +1,353 @@
+package table
+
+import (
+	"fmt"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const entityTagName = "dynamo"
+
+type keyAttribute struct {
+	name          string
+	attributeType AttributeType
+}
+
+type entityKeys struct {
+	entity  string
+	pk      *keyAttribute
+	sk      *keyAttribute
+	global  map[string]*entityIndexKeys
+	local   map[string]*keyAttribute
+	defined map[string]AttributeType
+}
+
+type entityIndexKeys struct {
+	pk *keyAttribute
+	sk *keyAttribute
+}
+
+func RegisterEntities(tableName string, entities ...interface{}) (TableDefinition, error) {
+	definition := TableDefinition{
+		TableName:      tableName,
+		AttributeTypes: make(map[string]AttributeType),
+	}
+
+	if len(entities) == 0 {
+		return definition, registrationError(tableName, "at least one entity is required")
+	}
+
+	var table *entityKeys
+	global := make(map[string]*entityIndexKeys)
+	globalOwners := make(map[string]string)
+	local := make(map[string]*keyAttribute)
+	localOwners := make(map[string]string)
+
+	for _, entity := range entities {
+		keys, err := reflectEntityKeys(tableName, entity)
+		if err != nil {
+			return definition, err
+		}
+
+		if table == nil {
+			table = keys
+		} else {
+			if err := compareKey(tableName, "partition key", table.entity, keys.entity, table.pk, keys.pk); err != nil {
+				return definition, err
+			}
+			if err := compareKey(tableName, "sort key", table.entity, keys.entity, table.sk, keys.sk); err != nil {
+				return definition, err
+			}
+		}
+
+		for name, index := range keys.global {
+			existing, ok := global[name]
+			if !ok {
+				global[name] = index
+				globalOwners[name] = keys.entity
+				continue
+			}
+			if err := compareKey(tableName, "partition key of index "+name, globalOwners[name], keys.entity, existing.pk, index.pk); err != nil {
+				return definition, err
+			}
+			if err := compareKey(tableName, "sort key of index "+name, globalOwners[name], keys.entity, existing.sk, index.sk); err != nil {
+				return definition, err
+			}
+		}
+
+		for name, sk := range keys.local {
+			existing, ok := local[name]
+			if !ok {
+				local[name] = sk
+				localOwners[name] = keys.entity
+				continue
+			}
+			if err := compareKey(tableName, "sort key of index "+name, localOwners[name], keys.entity, existing, sk); err != nil {
+				return definition, err
+			}
+		}
+
+		for name, attributeType := range keys.defined {
+			if existing, ok := definition.AttributeTypes[name]; ok && existing != attributeType {
+				return definition, registrationError(tableName, "entity %s declares key attribute %s as %s, another entity declares it as %s", keys.entity, name, attributeType, existing)
+			}
+			definition.AttributeTypes[name] = attributeType
+		}
+	}
+
+	definition.KeySchema = table.keySchema()
+
+	for _, name := range sortedIndexNames(global) {
+		index := global[name]
+		if index.pk == nil {
+			return definition, registrationError(tableName, "index %s has no partition key", name)
+		}
+		definition.GlobalIndexes = append(definition.GlobalIndexes, IndexDefinition{
+			IndexName: name,
+			KeySchema: attributesKeySchema(index.pk, index.sk),
+		})
+	}
+
+	for _, name := range sortedIndexNames(local) {
+		definition.LocalIndexes = append(definition.LocalIndexes, IndexDefinition{
+			IndexName: name,
+			KeySchema: attributesKeySchema(table.pk, local[name]),
+		})
+	}
+
+	return definition, nil
+}
+
+func (d TableDefinition) IndexKeySchema(indexName string) (KeySchema, bool) {
+	for _, index := range d.GlobalIndexes {
+		if index.IndexName == indexName {
+			return index.KeySchema, true
+		}
+	}
+	for _, index := range d.LocalIndexes {
+		if index.IndexName == indexName {
+			return index.KeySchema, true
+		}
+	}
+	return KeySchema{}, false
+}
+
+func reflectEntityKeys(tableName string, entity interface{}) (*entityKeys, error) {
+	t := reflect.TypeOf(entity)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, registrationError(tableName, "entity %T is not a struct", entity)
+	}
+
+	keys := &entityKeys{
+		entity:  t.String(),
+		global:  make(map[string]*entityIndexKeys),
+		local:   make(map[string]*keyAttribute),
+		defined: make(map[string]AttributeType),
+	}
+
+	if err := keys.collect(tableName, t); err != nil {
+		return nil, err
+	}
+
+	if keys.pk == nil {
+		return nil, registrationError(tableName, "entity %s has no field tagged %s:\"pk\"", keys.entity, entityTagName)
+	}
+	if len(keys.local) > 0 && keys.sk == nil {
+		return nil, registrationError(tableName, "entity %s declares local indexes without a table sort key", keys.entity)
+	}
+
+	return keys, nil
+}
+
+func (k *entityKeys) collect(tableName string, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name, skip := attributeName(field)
+		if skip {
+			continue
+		}
+
+		tag, tagged := field.Tag.Lookup(entityTagName)
+		if field.Anonymous && !tagged {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := k.collect(tableName, embedded); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if !tagged || tag == "" || !field.IsExported() {
+			continue
+		}
+
+		attributeType, ok := keyAttributeType(field)
+		if !ok {
+			return registrationError(tableName, "field %s.%s of type %s cannot be used as a key attribute", k.entity, field.Name, field.Type)
+		}
+		attribute := &keyAttribute{name: name, attributeType: attributeType}
+
+		for _, role := range strings.Split(tag, ";") {
+			if err := k.assign(tableName, field.Name, strings.TrimSpace(role), attribute); err != nil {
+				return err
+			}
+		}
+		k.defined[name] = attributeType
+	}
+
+	return nil
+}
+
+func (k *entityKeys) assign(tableName, fieldName, role string, attribute *keyAttribute) error {
+	parts := strings.Split(role, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	set := func(target **keyAttribute, what string) error {
+		if *target != nil {
+			return registrationError(tableName, "entity %s declares more than one %s (%s and %s)", k.entity, what, (*target).name, attribute.name)
+		}
+		*target = attribute
+		return nil
+	}
+
+	switch {
+	case role == "pk":
+		return set(&k.pk, "partition key")
+	case role == "sk":
+		return set(&k.sk, "sort key")
+	case strings.HasPrefix(parts[0], "gsi="):
+		indexName := strings.TrimPrefix(parts[0], "gsi=")
+		if indexName == "" || len(parts) != 2 {
+			break
+		}
+
+		index, ok := k.global[indexName]
+		if !ok {
+			index = &entityIndexKeys{}
+			k.global[indexName] = index
+		}
+
+		switch parts[1] {
+		case "pk":
+			return set(&index.pk, "partition key for index "+indexName)
+		case "sk":
+			return set(&index.sk, "sort key for index "+indexName)
+		}
+	case strings.HasPrefix(parts[0], "lsi="):
+		indexName := strings.TrimPrefix(parts[0], "lsi=")
+		if indexName == "" || len(parts) > 2 || (len(parts) == 2 && parts[1] != "sk") {
+			break
+		}
+
+		sk := k.local[indexName]
+		if err := set(&sk, "sort key for index "+indexName); err != nil {
+			return err
+		}
+		k.local[indexName] = sk
+		return nil
+	}
+
+	return registrationError(tableName, "invalid %s tag %q on field %s.%s", entityTagName, role, k.entity, fieldName)
+}
+
+func (k *entityKeys) keySchema() KeySchema {
+	return attributesKeySchema(k.pk, k.sk)
+}
+
+func attributesKeySchema(pk, sk *keyAttribute) KeySchema {
+	keySchema := KeySchema{PkName: pk.name, PkType: pk.attributeType}
+	if sk != nil {
+		name := sk.name
+		keySchema.SkName = &name
+		keySchema.SkType = sk.attributeType
+	}
+	return keySchema
+}
+
+func attributeName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("dynamodbav")
+	if tag == "-" {
+		return "", true
+	}
+
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, false
+	}
+	return field.Name, false
+}
+
+func keyAttributeType(field reflect.StructField) (AttributeType, bool) {
+	t := field.Type
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	asString := false
+	for _, option := range strings.Split(field.Tag.Get("dynamodbav"), ",")[1:] {
+		if option == "string" {
+			asString = true
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return AttributeTypeString, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if asString {
+			return AttributeTypeString, true
+		}
+		return AttributeTypeNumber, true
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return AttributeTypeBinary, true
+		}
+	}
+
+	return "", false
+}
+
+func compareKey(tableName, what, firstEntity, secondEntity string, first, second *keyAttribute) error {
+	switch {
+	case first == nil && second == nil:
+		return nil
+	case first == nil || second == nil:
+		return registrationError(tableName, "entities %s and %s disagree on the %s: %s vs %s", firstEntity, secondEntity, what, describeKey(first), describeKey(second))
+	case first.name != second.name || first.attributeType != second.attributeType:
+		return registrationError(tableName, "entities %s and %s disagree on the %s: %s vs %s", firstEntity, secondEntity, what, describeKey(first), describeKey(second))
+	}
+	return nil
+}
+
+func describeKey(attribute *keyAttribute) string {
+	if attribute == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%s (%s)", attribute.name, attribute.attributeType)
+}
+
+func sortedIndexNames[T any](indexes map[string]T) []string {
+	names := make([]string, 0, len(indexes))
+	for name := range indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func registrationError(tableName, format string, args ...interface{}) error {
+	return dberrors.NewKind(dberrors.ErrValidation, "RegisterEntities", tableName, "", fmt.Errorf(format, args...))
+}
@@ -0,0 +1,243 @@
+package table_test
+
+import (
+	"errors"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/table"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type orderEntity struct {
+	Customer string `dynamodbav:"customer" dynamo:"pk"`
+	Created  int64  `dynamodbav:"created" dynamo:"sk;lsi=by-created"`
+	Status   string `dynamodbav:"status" dynamo:"gsi=by-status,pk"`
+	Total    int    `dynamodbav:"total" dynamo:"gsi=by-status,sk; lsi=by-total,sk"`
+	Note     string `dynamodbav:"note"`
+}
+
+type invoiceEntity struct {
+	Customer string `dynamodbav:"customer" dynamo:"pk"`
+	Created  int64  `dynamodbav:"created" dynamo:"sk"`
+	Ref      []byte `dynamodbav:"ref" dynamo:"gsi=by-ref,pk"`
+}
+
+type auditFields struct {
+	Owner string `dynamodbav:"owner" dynamo:"gsi=by-owner,pk"`
+}
+
+type auditedEntity struct {
+	auditFields
+	Customer string `dynamodbav:"customer" dynamo:"pk"`
+	Created  int64  `dynamodbav:"created" dynamo:"sk"`
+}
+
+func TestRegisterEntities(t *testing.T) {
+	sk := func(name string) *string { return &name }
+
+	definition, err := table.RegisterEntities("orders", orderEntity{}, &invoiceEntity{}, auditedEntity{})
+	if err != nil {
+		t.Fatalf("RegisterEntities() error = %v", err)
+	}
+
+	wantKeySchema := table.KeySchema{PkName: "customer", PkType: table.AttributeTypeString, SkName: sk("created"), SkType: table.AttributeTypeNumber}
+	if !reflect.DeepEqual(definition.KeySchema, wantKeySchema) {
+		t.Errorf("KeySchema = %+v, want %+v", definition.KeySchema, wantKeySchema)
+	}
+
+	wantGlobal := []table.IndexDefinition{
+		{IndexName: "by-owner", KeySchema: table.KeySchema{PkName: "owner", PkType: table.AttributeTypeString}},
+		{IndexName: "by-ref", KeySchema: table.KeySchema{PkName: "ref", PkType: table.AttributeTypeBinary}},
+		{IndexName: "by-status", KeySchema: table.KeySchema{PkName: "status", PkType: table.AttributeTypeString, SkName: sk("total"), SkType: table.AttributeTypeNumber}},
+	}
+	if !reflect.DeepEqual(definition.GlobalIndexes, wantGlobal) {
+		t.Errorf("GlobalIndexes = %+v, want %+v", definition.GlobalIndexes, wantGlobal)
+	}
+
+	wantLocal := []table.IndexDefinition{
+		{IndexName: "by-created", KeySchema: wantKeySchema},
+		{IndexName: "by-total", KeySchema: table.KeySchema{PkName: "customer", PkType: table.AttributeTypeString, SkName: sk("total"), SkType: table.AttributeTypeNumber}},
+	}
+	if !reflect.DeepEqual(definition.LocalIndexes, wantLocal) {
+		t.Errorf("LocalIndexes = %+v, want %+v", definition.LocalIndexes, wantLocal)
+	}
+
+	wantTypes := map[string]table.AttributeType{
+		"customer": table.AttributeTypeString,
+		"created":  table.AttributeTypeNumber,
+		"status":   table.AttributeTypeString,
+		"total":    table.AttributeTypeNumber,
+		"ref":      table.AttributeTypeBinary,
+		"owner":    table.AttributeTypeString,
+	}
+	if !reflect.DeepEqual(definition.AttributeTypes, wantTypes) {
+		t.Errorf("AttributeTypes = %v, want %v", definition.AttributeTypes, wantTypes)
+	}
+
+	if got, ok := definition.IndexKeySchema("by-total"); !ok || !reflect.DeepEqual(got, wantLocal[1].KeySchema) {
+		t.Errorf("IndexKeySchema(by-total) = %+v, %v", got, ok)
+	}
+	if _, ok := definition.IndexKeySchema("missing"); ok {
+		t.Error("IndexKeySchema(missing) found an index")
+	}
+}
+
+func TestRegisterEntitiesErrors(t *testing.T) {
+	type noKey struct {
+		Name string `dynamodbav:"name"`
+	}
+	type twoPartitionKeys struct {
+		A string `dynamo:"pk"`
+		B string `dynamo:"pk"`
+	}
+	type unsupportedKey struct {
+		A bool `dynamo:"pk"`
+	}
+	type stringPk struct {
+		Pk string `dynamodbav:"pk" dynamo:"pk"`
+	}
+	type numberPk struct {
+		Pk int `dynamodbav:"pk" dynamo:"pk"`
+	}
+	type renamedPk struct {
+		ID string `dynamodbav:"id" dynamo:"pk"`
+	}
+	type withSk struct {
+		Pk string `dynamodbav:"pk" dynamo:"pk"`
+		Sk string `dynamodbav:"sk" dynamo:"sk"`
+	}
+	type localWithoutSk struct {
+		Pk    string `dynamodbav:"pk" dynamo:"pk"`
+		Score int    `dynamodbav:"score" dynamo:"lsi=by-score"`
+	}
+	type statusIndexString struct {
+		Pk     string `dynamodbav:"pk" dynamo:"pk"`
+		Status string `dynamodbav:"status" dynamo:"gsi=by-status,pk"`
+	}
+	type statusIndexNumber struct {
+		Pk     string `dynamodbav:"pk" dynamo:"pk"`
+		Status int    `dynamodbav:"status" dynamo:"gsi=by-status,pk"`
+	}
+	type statusIndexWithSk struct {
+		Pk     string `dynamodbav:"pk" dynamo:"pk"`
+		Status string `dynamodbav:"status" dynamo:"gsi=by-status,pk"`
+		At     int    `dynamodbav:"at" dynamo:"gsi=by-status,sk"`
+	}
+	type globalWithoutPk struct {
+		Pk string `dynamodbav:"pk" dynamo:"pk"`
+		At int    `dynamodbav:"at" dynamo:"gsi=by-at,sk"`
+	}
+	type localScoreNumber struct {
+		Pk    string `dynamodbav:"pk" dynamo:"pk"`
+		Sk    string `dynamodbav:"sk" dynamo:"sk"`
+		Score int    `dynamodbav:"score" dynamo:"lsi=by-score"`
+	}
+	type localScoreString struct {
+		Pk    string `dynamodbav:"pk" dynamo:"pk"`
+		Sk    string `dynamodbav:"sk" dynamo:"sk"`
+		Score string `dynamodbav:"score" dynamo:"lsi=by-score"`
+	}
+	type sharedAttributeNumber struct {
+		Pk    string `dynamodbav:"pk" dynamo:"pk"`
+		Owner int    `dynamodbav:"owner" dynamo:"gsi=by-a,pk"`
+	}
+	type sharedAttributeString struct {
+		Pk    string `dynamodbav:"pk" dynamo:"pk"`
+		Owner string `dynamodbav:"owner" dynamo:"gsi=by-b,pk"`
+	}
+
+	tests := []struct {
+		name     string
+		entities []interface{}
+		wantErr  string
+	}{
+		{name: "no entities", wantErr: "at least one entity"},
+		{name: "not a struct", entities: []interface{}{"orders"}, wantErr: "is not a struct"},
+		{name: "missing partition key", entities: []interface{}{noKey{}}, wantErr: "has no field tagged"},
+		{name: "duplicate partition key", entities: []interface{}{twoPartitionKeys{}}, wantErr: "more than one partition key"},
+		{name: "unsupported key type", entities: []interface{}{unsupportedKey{}}, wantErr: "cannot be used as a key attribute"},
+		{name: "local index without sort key", entities: []interface{}{localWithoutSk{}}, wantErr: "local indexes without a table sort key"},
+		{name: "global index without partition key", entities: []interface{}{globalWithoutPk{}}, wantErr: "index by-at has no partition key"},
+		{name: "partition key type", entities: []interface{}{stringPk{}, numberPk{}}, wantErr: "disagree on the partition key: pk (S) vs pk (N)"},
+		{name: "partition key name", entities: []interface{}{stringPk{}, renamedPk{}}, wantErr: "disagree on the partition key: pk (S) vs id (S)"},
+		{name: "sort key presence", entities: []interface{}{withSk{}, stringPk{}}, wantErr: "disagree on the sort key: sk (S) vs none"},
+		{name: "global index key type", entities: []interface{}{statusIndexString{}, statusIndexNumber{}}, wantErr: "disagree on the partition key of index by-status"},
+		{name: "global index sort key", entities: []interface{}{statusIndexWithSk{}, statusIndexString{}}, wantErr: "disagree on the sort key of index by-status: at (N) vs none"},
+		{name: "local index sort key", entities: []interface{}{localScoreNumber{}, localScoreString{}}, wantErr: "disagree on the sort key of index by-score"},
+		{name: "shared attribute type", entities: []interface{}{sharedAttributeNumber{}, sharedAttributeString{}}, wantErr: "declares key attribute owner as S"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := table.RegisterEntities("orders", tt.entities...)
+			if !errors.Is(err, dberrors.ErrValidation) {
+				t.Fatalf("RegisterEntities() error = %v, want ErrValidation", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RegisterEntities() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegisterEntitiesInvalidTags(t *testing.T) {
+	type emptyGlobalName struct {
+		Pk string `dynamo:"pk"`
+		A  string `dynamo:"gsi=,pk"`
+	}
+	type globalWithoutRole struct {
+		Pk string `dynamo:"pk"`
+		A  string `dynamo:"gsi=by-a"`
+	}
+	type globalUnknownRole struct {
+		Pk string `dynamo:"pk"`
+		A  string `dynamo:"gsi=by-a,range"`
+	}
+	type localPartitionKey struct {
+		Pk string `dynamo:"pk"`
+		Sk string `dynamo:"sk"`
+		A  string `dynamo:"lsi=by-a,pk"`
+	}
+	type unknownRole struct {
+		Pk string `dynamo:"pk"`
+		A  string `dynamo:"hash"`
+	}
+	type duplicateGlobalKey struct {
+		Pk string `dynamo:"pk"`
+		A  string `dynamo:"gsi=by-a,pk"`
+		B  string `dynamo:"gsi=by-a,pk"`
+	}
+	type duplicateLocalKey struct {
+		Pk string `dynamo:"pk"`
+		Sk string `dynamo:"sk"`
+		A  string `dynamo:"lsi=by-a"`
+		B  string `dynamo:"lsi=by-a,sk"`
+	}
+
+	tests := []struct {
+		name    string
+		entity  interface{}
+		wantErr string
+	}{
+		{name: "empty global index name", entity: emptyGlobalName{}, wantErr: `invalid dynamo tag "gsi=,pk" on field`},
+		{name: "global index without role", entity: globalWithoutRole{}, wantErr: `invalid dynamo tag "gsi=by-a"`},
+		{name: "global index unknown role", entity: globalUnknownRole{}, wantErr: `invalid dynamo tag "gsi=by-a,range"`},
+		{name: "local index partition key", entity: localPartitionKey{}, wantErr: `invalid dynamo tag "lsi=by-a,pk"`},
+		{name: "unknown role", entity: unknownRole{}, wantErr: `invalid dynamo tag "hash"`},
+		{name: "duplicate global index key", entity: duplicateGlobalKey{}, wantErr: "more than one partition key for index by-a"},
+		{name: "duplicate local index key", entity: duplicateLocalKey{}, wantErr: "more than one sort key for index by-a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := table.RegisterEntities("orders", tt.entity)
+			if !errors.Is(err, dberrors.ErrValidation) {
+				t.Fatalf("RegisterEntities() error = %v, want ErrValidation", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RegisterEntities() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package table
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/client"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"strings"
+)
+
+type statementPaginator struct {
+	client    client.DynamoDBAPI
+	input     *dynamodb.ExecuteStatementInput
+	nextToken *string
+	firstPage bool
+}
+
+func newStatementPaginator(client client.DynamoDBAPI, input *dynamodb.ExecuteStatementInput) *statementPaginator {
+	return &statementPaginator{
+		client:    client,
+		input:     input,
+		firstPage: true,
+	}
+}
+
+func (p *statementPaginator) HasMorePages() bool {
+	return p.firstPage || p.nextToken != nil
+}
+
+func (p *statementPaginator) NextPage(ctx context.Context) (*dynamodb.ExecuteStatementOutput, error) {
+	input := *p.input
+	input.NextToken = p.nextToken
+
+	output, err := p.client.ExecuteStatement(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	p.firstPage = false
+	p.nextToken = output.NextToken
+	return output, nil
+}
+
+func executeStatement(ctx context.Context, table Table, partiQL PartiQLCommand, inputOptions InputOptions) ([]interface{}, error) {
+	client, err := table.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := buildExecuteStatementInput(table, partiQL, inputOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("[%s] DynamoDB execute statement: %s", table.CollectionName(), partiQL.Statement)
+
+	total := input.Limit
+	paginator := newExecuteStatementPaginator(table, client, input)
+
+	entities := make([]interface{}, 0)
+	for paginator.HasMorePages() {
+		if total != nil {
+			input.Limit = aws.Int32(*total - int32(len(entities)))
+		}
+
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, page...)
+
+		if total != nil && len(entities) >= int(*total) {
+			entities = entities[:*total]
+			break
+		}
+	}
+
+	log.Debugf("Result set size: %d", len(entities))
+
+	return entities, nil
+}
+
+func paginatedExecuteStatement(table Table, partiQL PartiQLCommand, inputOptions InputOptions) (Paginator, error) {
+	client, err := table.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := buildExecuteStatementInput(table, partiQL, inputOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Limit == nil {
+		val := int32(DefaultPageSize)
+		input.Limit = &val
+	}
+
+	return newExecuteStatementPaginator(table, client, input), nil
+}
+
+func newExecuteStatementPaginator(table Table, wrapper *client.Wrapper, input *dynamodb.ExecuteStatementInput) *DynamoPaginator {
+	policy := table.retryPolicy()
+	if !isReadStatement(aws.ToString(input.Statement)) {
+		policy = writeRetryPolicy(policy, wrapper)
+	}
+
+	return &DynamoPaginator{
+		statementPaginator: newStatementPaginator(wrapper.AWSClient, input),
+		entitiesDecoder: EntitiesDecoder{
+			EntityResolver: table.EntityResolver,
+			KeySchema:      table.KeySchema,
+		},
+		logOpts:       []string{table.CollectionName()},
+		clientWrapper: wrapper,
+		retryPolicy:   policy,
+	}
+}
+
+func isReadStatement(statement string) bool {
+	fields := strings.Fields(statement)
+	return len(fields) > 0 && strings.EqualFold(fields[0], "SELECT")
+}
+
+func buildExecuteStatementInput(table Table, partiQL PartiQLCommand, inputOptions InputOptions) (*dynamodb.ExecuteStatementInput, error) {
+	parameters, err := statementParameters(partiQL, encoderOptions(inputOptions))
+	if err != nil {
+		return nil, dberrors.NewKind(dberrors.ErrMarshal, "ExecuteStatement", table.CollectionName(), "", err)
+	}
+
+	input := &dynamodb.ExecuteStatementInput{
+		Statement:  aws.String(partiQL.Statement),
+		Parameters: parameters,
+	}
+
+	if limit, ok := inputOptions[limit]; ok {
+		val := int32(limit.(int))
+		input.Limit = &val
+	}
+
+	if tf, ok := inputOptions[consistentRead]; ok {
+		input.ConsistentRead = aws.Bool(tf.(bool))
+	}
+
+	return input, nil
+}
+
+func statementParameters(partiQL PartiQLCommand, optFns []func(*attributevalue.EncoderOptions)) ([]types.AttributeValue, error) {
+	parameters := make([]types.AttributeValue, 0, len(partiQL.Tokens))
+	for i, token := range partiQL.Tokens {
+		if av, ok := token.(types.AttributeValue); ok {
+			parameters = append(parameters, av)
+			continue
+		}
+
+		av, err := attributevalue.MarshalWithOptions(token, optFns...)
+		if err == nil && av == nil {
+			err = fmt.Errorf("unsupported type %T", token)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal token %d (%T) of statement %q: %w", i, token, partiQL.Statement, err)
+		}
+		parameters = append(parameters, av)
+	}
+
+	if len(parameters) == 0 {
+		return nil, nil
+	}
+	return parameters, nil
+}
+
+func encoderOptions(inputOptions InputOptions) []func(*attributevalue.EncoderOptions) {
+	if optFns, ok := inputOptions[optEncoderOptions]; ok {
+		return optFns.([]func(*attributevalue.EncoderOptions))
+	}
+	return nil
+}
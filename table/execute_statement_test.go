@@ -0,0 +1,229 @@
+package table_test
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/jhmachado/dynamodb/dynamodbtest"
+	"github.com/jhmachado/dynamodb/memdb"
+	"github.com/jhmachado/dynamodb/table"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type parameterOwner struct {
+	Name string `dynamodbav:"name" json:"owner_name"`
+}
+
+type parameterItem struct {
+	Pk    string            `dynamodbav:"pk"`
+	Ratio float64           `dynamodbav:"ratio"`
+	Count int64             `dynamodbav:"count"`
+	At    time.Time         `dynamodbav:"at"`
+	Blob  []byte            `dynamodbav:"blob"`
+	Tags  map[string]string `dynamodbav:"tags"`
+	Owner parameterOwner    `dynamodbav:"owner"`
+}
+
+type statementRecorder struct {
+	*memdb.DB
+	mu     sync.Mutex
+	limits []int32
+	tokens int
+}
+
+func (r *statementRecorder) ExecuteStatement(ctx context.Context, in *ddb.ExecuteStatementInput, optFns ...func(*ddb.Options)) (*ddb.ExecuteStatementOutput, error) {
+	r.mu.Lock()
+	r.limits = append(r.limits, aws.ToInt32(in.Limit))
+	if in.NextToken != nil {
+		r.tokens++
+	}
+	r.mu.Unlock()
+
+	return r.DB.ExecuteStatement(ctx, in, optFns...)
+}
+
+func newStatementTable(t *testing.T) (table.TypedTable[scoredItem], *statementRecorder) {
+	t.Helper()
+
+	sk := "sk"
+	recorder := &statementRecorder{DB: memdb.New()}
+	tbl := table.NewTypedTable[scoredItem](dynamodbtest.NewTable(t, table.Table{TableName: "statements", KeySchema: table.KeySchema{PkName: "pk", SkName: &sk, SkType: table.AttributeTypeNumber}}, dynamodbtest.WithMemDB(recorder.DB)))
+	tbl.Table.Client.AWSClient = recorder
+
+	dynamodbtest.Seed(t, tbl.Table,
+		scoredItem{PK: "a", SK: 1, Status: "open", Score: 10},
+		scoredItem{PK: "a", SK: 2, Status: "closed", Score: 20},
+		scoredItem{PK: "a", SK: 3, Status: "open", Score: 30},
+		scoredItem{PK: "a", SK: 4, Status: "open", Score: 40},
+		scoredItem{PK: "a", SK: 5, Status: "open", Score: 50},
+	)
+	return tbl, recorder
+}
+
+func TestExecuteStatementLimitCapsRemainingPages(t *testing.T) {
+	tbl, recorder := newStatementTable(t)
+
+	statement := table.PartiQLCommand{
+		Statement: `SELECT * FROM "` + tbl.Table.TableName + `" WHERE pk = ? AND status = ?`,
+		Tokens:    []interface{}{"a", "open"},
+	}
+	items, err := tbl.ExecuteStatement(context.Background(), statement, table.InputOptions{"Limit": 3})
+	if err != nil {
+		t.Fatalf("ExecuteStatement() error = %v", err)
+	}
+
+	want := []scoredItem{
+		{PK: "a", SK: 1, Status: "open", Score: 10},
+		{PK: "a", SK: 3, Status: "open", Score: 30},
+		{PK: "a", SK: 4, Status: "open", Score: 40},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("ExecuteStatement() = %+v, want %+v", items, want)
+	}
+	if want := []int32{3, 1}; !reflect.DeepEqual(recorder.limits, want) {
+		t.Errorf("requested limits = %v, want %v", recorder.limits, want)
+	}
+}
+
+func TestExecuteStatementFollowsNextToken(t *testing.T) {
+	tbl, recorder := newStatementTable(t)
+
+	paginator, err := tbl.PaginatedExecuteStatement(table.PartiQLCommand{
+		Statement: `SELECT * FROM "` + tbl.Table.TableName + `" WHERE pk = ?`,
+		Tokens:    []interface{}{"a"},
+	}, table.InputOptions{"Limit": 2})
+	if err != nil {
+		t.Fatalf("PaginatedExecuteStatement() error = %v", err)
+	}
+
+	var pages [][]int
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			t.Fatalf("NextPage() error = %v", err)
+		}
+
+		sks := make([]int, 0, len(page))
+		for _, item := range page {
+			sks = append(sks, item.SK)
+		}
+		pages = append(pages, sks)
+	}
+
+	if want := [][]int{{1, 2}, {3, 4}, {5}}; !reflect.DeepEqual(pages, want) {
+		t.Errorf("pages = %v, want %v", pages, want)
+	}
+	if recorder.tokens != 2 {
+		t.Errorf("requests with a NextToken = %d, want 2", recorder.tokens)
+	}
+	if want := []int32{2, 2, 2}; !reflect.DeepEqual(recorder.limits, want) {
+		t.Errorf("requested page sizes = %v, want %v", recorder.limits, want)
+	}
+}
+
+func TestExecuteStatementDecodesEntities(t *testing.T) {
+	tbl, _ := newStatementTable(t)
+
+	items, err := tbl.ExecuteStatement(context.Background(), table.PartiQLCommand{
+		Statement: `SELECT * FROM "` + tbl.Table.TableName + `" WHERE pk = ? AND sk >= ?`,
+		Tokens:    []interface{}{"a", 4},
+	}, table.InputOptions{})
+	if err != nil {
+		t.Fatalf("ExecuteStatement() error = %v", err)
+	}
+
+	want := []scoredItem{
+		{PK: "a", SK: 4, Status: "open", Score: 40},
+		{PK: "a", SK: 5, Status: "open", Score: 50},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("ExecuteStatement() = %+v, want %+v", items, want)
+	}
+
+	untyped, err := tbl.Table.ExecuteStatement(context.Background(), table.PartiQLCommand{
+		Statement: `SELECT pk, sk FROM "` + tbl.Table.TableName + `" WHERE pk = ? AND sk = ?`,
+		Tokens:    []interface{}{"a", 5},
+	}, table.InputOptions{})
+	if err != nil {
+		t.Fatalf("ExecuteStatement() error = %v", err)
+	}
+	if want := []interface{}{&scoredItem{PK: "a", SK: 5}}; !reflect.DeepEqual(untyped, want) {
+		t.Errorf("ExecuteStatement() = %#v, want %#v", untyped, want)
+	}
+}
+
+func TestExecuteStatementAcceptsPutValuesAsParameters(t *testing.T) {
+	tbl := dynamodbtest.NewTable(t, table.Table{TableName: "parameters", KeySchema: table.KeySchema{PkName: "pk"}})
+
+	item := parameterItem{
+		Pk:    "put",
+		Ratio: 0.25,
+		Count: 1 << 40,
+		At:    time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+		Blob:  []byte{0, 1, 2},
+		Tags:  map[string]string{"env": "prod"},
+		Owner: parameterOwner{Name: "ana"},
+	}
+	if err := tbl.Put(context.Background(), item, table.InputOptions{}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	_, err := tbl.ExecuteStatement(context.Background(), table.PartiQLCommand{
+		Statement: `INSERT INTO "` + tbl.TableName + `" VALUE {'pk': ?, 'ratio': ?, 'count': ?, 'at': ?, 'blob': ?, 'tags': ?, 'owner': ?}`,
+		Tokens:    []interface{}{"statement", item.Ratio, item.Count, item.At, item.Blob, item.Tags, item.Owner},
+	}, table.InputOptions{})
+	if err != nil {
+		t.Fatalf("ExecuteStatement() error = %v", err)
+	}
+
+	dynamodbtest.AssertItem(t, tbl, stringKey{Pk: "put"}, item)
+	item.Pk = "statement"
+	dynamodbtest.AssertItem(t, tbl, stringKey{Pk: "statement"}, item)
+}
+
+func TestExecuteStatementAppliesEncoderOptions(t *testing.T) {
+	tbl := dynamodbtest.NewTable(t, table.Table{TableName: "parameters", KeySchema: table.KeySchema{PkName: "pk"}})
+
+	opts := table.InputOptions{}
+	if err := table.WithEncoderOptions(func(o *attributevalue.EncoderOptions) { o.TagKey = "json" })(opts); err != nil {
+		t.Fatalf("WithEncoderOptions() error = %v", err)
+	}
+
+	_, err := tbl.ExecuteStatement(context.Background(), table.PartiQLCommand{
+		Statement: `INSERT INTO "` + tbl.TableName + `" VALUE {'pk': ?, 'owner': ?}`,
+		Tokens:    []interface{}{"a", parameterOwner{Name: "ana"}},
+	}, opts)
+	if err != nil {
+		t.Fatalf("ExecuteStatement() error = %v", err)
+	}
+
+	dynamodbtest.AssertItem(t, tbl, stringKey{Pk: "a"}, map[string]interface{}{
+		"pk":    "a",
+		"owner": map[string]interface{}{"owner_name": "ana"},
+	})
+}
+
+func TestExecuteStatementReportsFailingParameter(t *testing.T) {
+	tbl := dynamodbtest.NewTable(t, table.Table{TableName: "parameters", KeySchema: table.KeySchema{PkName: "pk"}})
+
+	_, err := tbl.ExecuteStatement(context.Background(), table.PartiQLCommand{
+		Statement: `UPDATE "` + tbl.TableName + `" SET score = ? WHERE pk = ?`,
+		Tokens:    []interface{}{make(chan int), "a"},
+	}, table.InputOptions{})
+	if err == nil || !strings.Contains(err.Error(), "token 0 (chan int)") {
+		t.Errorf("ExecuteStatement() error = %v, want it to name token 0", err)
+	}
+
+	_, err = tbl.ExecuteStatement(context.Background(), table.PartiQLCommand{
+		Statement: `UPDATE "` + tbl.TableName + `" SET score = ? WHERE pk = ?`,
+		Tokens:    []interface{}{1, func() {}},
+	}, table.InputOptions{})
+	if err == nil || !strings.Contains(err.Error(), "token 1 (func())") {
+		t.Errorf("ExecuteStatement() error = %v, want it to name token 1", err)
+	}
+}
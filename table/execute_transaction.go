@@ -0,0 +1,99 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/dberrors"
+)
+
+func ExecuteTransaction(ctx context.Context, t Table, stmts []PartiQLCommand, inputOptions InputOptions) ([]interface{}, error) {
+	if len(stmts) == 0 {
+		return []interface{}{}, nil
+	}
+
+	if len(stmts) > MaxTransactionItems {
+		return nil, dberrors.NewKind(dberrors.ErrValidation, "ExecuteTransaction", t.TableName, "", fmt.Errorf("a transaction supports at most %d statements", MaxTransactionItems))
+	}
+
+	client, err := t.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	optFns := encoderOptions(inputOptions)
+	statements := make([]types.ParameterizedStatement, 0, len(stmts))
+	operations := make([]string, 0, len(stmts))
+	for _, stmt := range stmts {
+		parameters, err := statementParameters(stmt, optFns)
+		if err != nil {
+			return nil, dberrors.NewKind(dberrors.ErrMarshal, "ExecuteTransaction", t.TableName, "", err)
+		}
+
+		statements = append(statements, types.ParameterizedStatement{
+			Statement:  aws.String(stmt.Statement),
+			Parameters: parameters,
+		})
+		operations = append(operations, stmt.Statement)
+	}
+
+	input := &ddb.ExecuteTransactionInput{
+		TransactStatements: statements,
+		ClientRequestToken: clientRequestToken(inputOptions),
+	}
+	policy := t.retryPolicy()
+	if input.ClientRequestToken == nil {
+		policy = writeRetryPolicy(policy, client)
+	}
+
+	log.Debugf("[%s] DynamoDB EXECUTE TRANSACTION with %d statements", t.TableName, len(stmts))
+
+	var output *ddb.ExecuteTransactionOutput
+	err = callWithRetry(ctx, policy, client, func(ctx context.Context) error {
+		var err error
+		output, err = client.AWSClient.ExecuteTransaction(ctx, input)
+		return err
+	})
+	if err != nil {
+		var canceled *types.TransactionCanceledException
+		if errors.As(err, &canceled) {
+			err = dberrors.NewTransactionCanceledError(canceled, operations)
+		}
+		return nil, dberrors.New("ExecuteTransaction", t.TableName, "", err)
+	}
+
+	return decodeItemResponses(t, "ExecuteTransaction", output.Responses)
+}
+
+func decodeItemResponses(t Table, op string, responses []types.ItemResponse) ([]interface{}, error) {
+	items := make([]map[string]types.AttributeValue, 0, len(responses))
+	for _, response := range responses {
+		if len(response.Item) > 0 {
+			items = append(items, response.Item)
+		}
+	}
+
+	decoder := EntitiesDecoder{
+		EntityResolver: t.EntityResolver,
+		KeySchema:      t.KeySchema,
+	}
+
+	entities, err := decoder.AttributeMapsToEntities(items)
+	if err != nil {
+		return nil, dberrors.NewKind(dberrors.ErrUnmarshal, op, t.TableName, "", err)
+	}
+
+	results := make([]interface{}, len(responses))
+	next := 0
+	for i, response := range responses {
+		if len(response.Item) > 0 {
+			results[i] = entities[next]
+			next++
+		}
+	}
+
+	return results, nil
+}
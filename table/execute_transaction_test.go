@@ -0,0 +1,136 @@
+package table_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/dynamodbtest"
+	"github.com/jhmachado/dynamodb/table"
+	"reflect"
+	"testing"
+)
+
+func newTransactionTable(t *testing.T) table.Table {
+	t.Helper()
+
+	tbl := dynamodbtest.NewTable(t, table.Table{TableName: "migrations", KeySchema: table.KeySchema{PkName: "pk"}})
+	dynamodbtest.Seed(t, tbl, namedItem{PK: "a", Name: "first"}, namedItem{PK: "c", Name: "third"})
+	return tbl
+}
+
+func TestExecuteTransactionMapsCancellationReasonsToStatements(t *testing.T) {
+	tbl := newTransactionTable(t)
+
+	insertNew := `INSERT INTO "` + tbl.TableName + `" VALUE {'pk': ?, 'name': ?}`
+	insertExisting := `INSERT INTO "` + tbl.TableName + `" VALUE {'pk': ?, 'name': ?}`
+	guardedUpdate := `UPDATE "` + tbl.TableName + `" SET name = ? WHERE pk = ? AND name = ?`
+
+	_, err := table.ExecuteTransaction(context.Background(), tbl, []table.PartiQLCommand{
+		{Statement: insertNew, Tokens: []interface{}{"b", "second"}},
+		{Statement: insertExisting, Tokens: []interface{}{"a", "again"}},
+		{Statement: guardedUpdate, Tokens: []interface{}{"renamed", "c", "other"}},
+	}, table.InputOptions{})
+
+	var canceled *dberrors.TransactionCanceledError
+	if !errors.As(err, &canceled) {
+		t.Fatalf("ExecuteTransaction() error = %v, want a TransactionCanceledError", err)
+	}
+	if !errors.Is(err, dberrors.ErrConditionalCheckFailed) {
+		t.Errorf("errors.Is(ErrConditionalCheckFailed) = false for %v", err)
+	}
+
+	got := make([]string, 0, len(canceled.Reasons))
+	for _, reason := range canceled.Reasons {
+		got = append(got, reason.Operation+" => "+reason.Code)
+	}
+	want := []string{
+		insertNew + " => None",
+		insertExisting + " => DuplicateItem",
+		guardedUpdate + " => ConditionalCheckFailed",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Reasons = %q, want %q", got, want)
+	}
+
+	dynamodbtest.AssertItemNotExists(t, tbl, stringKey{Pk: "b"})
+	dynamodbtest.AssertItem(t, tbl, stringKey{Pk: "c"}, namedItem{PK: "c", Name: "third"})
+}
+
+func TestExecuteTransactionClientRequestToken(t *testing.T) {
+	tbl := newTransactionTable(t)
+
+	statements := []table.PartiQLCommand{
+		{Statement: `INSERT INTO "` + tbl.TableName + `" VALUE {'pk': ?, 'name': ?}`, Tokens: []interface{}{"b", "second"}},
+		{Statement: `DELETE FROM "` + tbl.TableName + `" WHERE pk = ?`, Tokens: []interface{}{"a"}},
+	}
+
+	opts := table.InputOptions{}
+	if err := table.WithClientRequestToken("migration-1")(opts); err != nil {
+		t.Fatalf("WithClientRequestToken() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := table.ExecuteTransaction(context.Background(), tbl, statements, opts); err != nil {
+			t.Fatalf("ExecuteTransaction() attempt %d error = %v", i+1, err)
+		}
+	}
+
+	dynamodbtest.AssertTableContains(t, tbl, namedItem{PK: "b", Name: "second"}, namedItem{PK: "c", Name: "third"})
+
+	_, err := table.ExecuteTransaction(context.Background(), tbl, statements, table.InputOptions{})
+	if !errors.Is(err, dberrors.ErrTransactionCanceled) {
+		t.Errorf("ExecuteTransaction() without a token error = %v, want %v", err, dberrors.ErrTransactionCanceled)
+	}
+
+	if err := table.WithClientRequestToken("")(table.InputOptions{}); err == nil {
+		t.Error("WithClientRequestToken(\"\") error = nil, want an error")
+	}
+}
+
+func TestExecuteTransactionReturnsReadsInStatementOrder(t *testing.T) {
+	tbl := newTransactionTable(t)
+
+	selectItem := `SELECT * FROM "` + tbl.TableName + `" WHERE pk = ?`
+	items, err := table.ExecuteTransaction(context.Background(), tbl, []table.PartiQLCommand{
+		{Statement: selectItem, Tokens: []interface{}{"c"}},
+		{Statement: selectItem, Tokens: []interface{}{"missing"}},
+		{Statement: selectItem, Tokens: []interface{}{"a"}},
+	}, table.InputOptions{})
+	if err != nil {
+		t.Fatalf("ExecuteTransaction() error = %v", err)
+	}
+
+	want := []interface{}{
+		map[string]interface{}{"pk": "c", "name": "third"},
+		nil,
+		map[string]interface{}{"pk": "a", "name": "first"},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("ExecuteTransaction() = %#v, want %#v", items, want)
+	}
+}
+
+func TestWriteTransactionClientRequestToken(t *testing.T) {
+	tbl := newTransactionTable(t)
+
+	opts := table.InputOptions{}
+	if err := table.WithClientRequestToken("write-1")(opts); err != nil {
+		t.Fatalf("WithClientRequestToken() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		tx := table.NewWriteTransaction()
+		if err := tx.Put(tbl, namedItem{PK: "b", Name: "second"}, nil); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+		if err := tx.Delete(tbl, stringKey{Pk: "a"}, nil); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := tx.Commit(context.Background(), opts); err != nil {
+			t.Fatalf("Commit() attempt %d error = %v", i+1, err)
+		}
+		dynamodbtest.Seed(t, tbl, namedItem{PK: "a", Name: "restored"})
+	}
+
+	dynamodbtest.AssertItem(t, tbl, stringKey{Pk: "a"}, namedItem{PK: "a", Name: "restored"})
+}
@@ -2,36 +2,35 @@ package table
 
 import (
 	"context"
-	"errors"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
-	db "github.com/jhmachado/dynamodb/client"
-	"github.com/jhmachado/dynamodb/util"
+	"github.com/jhmachado/dynamodb/dberrors"
 	"reflect"
 )
 
 func get(ctx context.Context, table Table, primaryKey PrimaryKey, inputOptions InputOptions) (interface{}, error) {
-	client, err := db.GetClient()
+	client, err := table.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	log.Debugf("[%s] DynamoDB GET with primary key, %s", table.CollectionName(), FormatPrimaryKey(primaryKey, &table.KeySchema))
+	formattedPk := FormatPrimaryKey(primaryKey, &table.KeySchema)
+	log.Debugf("[%s] DynamoDB GET with primary key, %s", table.CollectionName(), formattedPk)
 
 	getItemInput, err := buildGetItemInput(table, primaryKey, inputOptions)
 	if err != nil {
 		return nil, err
 	}
 
-	dbCtx, cancel := util.BuildDBContext(ctx, client.TimeoutsMs)
-	if cancel != nil {
-		defer cancel()
-	}
-
-	output, err := client.AWSClient.GetItem(dbCtx, getItemInput)
+	var output *dynamodb.GetItemOutput
+	err = callWithRetry(ctx, table.retryPolicy(), client, func(ctx context.Context) error {
+		var err error
+		output, err = client.AWSClient.GetItem(ctx, getItemInput)
+		return err
+	})
 	if err != nil {
-		return nil, errors.New("failed to retrieve item")
+		return nil, dberrors.New("GetItem", table.TableName, formattedPk, err)
 	}
 
 	if output.Item == nil {
@@ -42,15 +41,11 @@ func get(ctx context.Context, table Table, primaryKey PrimaryKey, inputOptions I
 		EntityResolver: table.EntityResolver,
 		KeySchema:      table.KeySchema,
 	}
-	entity, _ := decoder.ResolveZeroEntity(primaryKey)
-	log.Debugf("resolved entity type: %s", reflect.TypeOf(entity).String())
-
-	err = attributevalue.UnmarshalMap(output.Item, entity)
+	entity, err := decoder.AttributeMapToEntity(primaryKey, output.Item)
 	if err != nil {
-		logOptions := []string{table.CollectionName(), FormatPrimaryKey(primaryKey, &table.KeySchema)}
-		message := util.FormatErrorMessage("failed to deserialize db item", logOptions)
-		return nil, errors.New(message)
+		return nil, dberrors.NewKind(dberrors.ErrUnmarshal, "GetItem", table.TableName, formattedPk, err)
 	}
+	log.Debugf("resolved entity type: %s", reflect.TypeOf(entity).String())
 
 	return entity, nil
 }
@@ -58,7 +53,7 @@ func get(ctx context.Context, table Table, primaryKey PrimaryKey, inputOptions I
 func buildGetItemInput(table Table, primaryKey PrimaryKey, inputOptions InputOptions) (*dynamodb.GetItemInput, error) {
 	avs, err := attributevalue.MarshalMap(primaryKey)
 	if err != nil {
-		return nil, errors.New("failed to marshal primary key")
+		return nil, dberrors.NewKind(dberrors.ErrMarshal, "GetItem", table.TableName, FormatPrimaryKey(primaryKey, &table.KeySchema), err)
 	}
 
 	getItemInput := &dynamodb.GetItemInput{
@@ -0,0 +1,254 @@
+package table
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	db "github.com/jhmachado/dynamodb/client"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/retry"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const MaxKeysPerBatchGet = 100
+
+type batchGetReport struct {
+	Items           []map[string]types.AttributeValue
+	UnprocessedKeys int
+	Errors          []error
+}
+
+func getMany(ctx context.Context, table Table, keys []PrimaryKey, inputOptions InputOptions) ([]interface{}, error) {
+	entities := make([]interface{}, len(keys))
+	if len(keys) == 0 {
+		return entities, nil
+	}
+
+	client, err := table.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("[%s] DynamoDB batch GET key count: %d", table.TableName, len(keys))
+
+	positions := make(map[string][]int)
+	uniqueKeys := make([]map[string]types.AttributeValue, 0, len(keys))
+
+	for i, primaryKey := range keys {
+		avs, err := attributevalue.MarshalMap(primaryKey)
+		if err != nil {
+			return nil, dberrors.NewKind(dberrors.ErrMarshal, "BatchGetItem", table.TableName, FormatPrimaryKey(primaryKey, &table.KeySchema), err)
+		}
+
+		identity, err := primaryKeyIdentity(avs, table.KeySchema)
+		if err != nil {
+			return nil, dberrors.NewKind(dberrors.ErrValidation, "BatchGetItem", table.TableName, FormatPrimaryKey(primaryKey, &table.KeySchema), err)
+		}
+
+		if _, ok := positions[identity]; !ok {
+			uniqueKeys = append(uniqueKeys, avs)
+		}
+		positions[identity] = append(positions[identity], i)
+	}
+
+	keysAndAttributes := buildKeysAndAttributes(inputOptions)
+	maxThreads, initBackoffDelay, maxRetries, err := parseBatchOptions(inputOptions)
+	if err != nil {
+		return nil, dberrors.NewKind(dberrors.ErrValidation, "BatchGetItem", table.TableName, "", err)
+	}
+
+	decoder := EntitiesDecoder{
+		EntityResolver: table.EntityResolver,
+		KeySchema:      table.KeySchema,
+	}
+
+	ch := make(chan *batchGetReport)
+	var errs []error
+	unprocessedKeys := 0
+
+	launched := dispatchBatches(ctx, len(uniqueKeys), MaxKeysPerBatchGet, maxThreads, func(start, end int) {
+		log.Debugf("Lauching thread to read batch of %d keys.", end-start)
+		go getBatch(ctx, client, table.retryPolicy(), table.TableName, uniqueKeys[start:end], keysAndAttributes, ch, initBackoffDelay, maxRetries)
+	}, func() {
+		batchReport := <-ch
+		log.Debug("Finished reading a batch.")
+
+		errs = append(errs, batchReport.Errors...)
+		unprocessedKeys += batchReport.UnprocessedKeys
+
+		for _, item := range batchReport.Items {
+			identity, err := primaryKeyIdentity(item, table.KeySchema)
+			if err != nil {
+				err = fmt.Errorf("projections must include the key attributes: %w", err)
+				errs = append(errs, dberrors.NewKind(dberrors.ErrValidation, "BatchGetItem", table.TableName, "", err))
+				continue
+			}
+
+			indices := positions[identity]
+			if len(indices) == 0 {
+				continue
+			}
+
+			for _, i := range indices {
+				entity, err := decoder.AttributeMapToEntity(keys[i], item)
+				if err != nil {
+					formattedPk := FormatPrimaryKey(keys[i], &table.KeySchema)
+					errs = append(errs, dberrors.NewKind(dberrors.ErrUnmarshal, "BatchGetItem", table.TableName, formattedPk, err))
+					break
+				}
+				entities[i] = entity
+			}
+		}
+	})
+
+	if launched < len(uniqueKeys) {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			errs = append(errs, dberrors.New("BatchGetItem", table.TableName, "", ctxErr))
+		}
+		unprocessedKeys += len(uniqueKeys) - launched
+	}
+
+	if len(errs) > 0 || unprocessedKeys > 0 {
+		return entities, createBatchGetError(table.TableName, errs, unprocessedKeys, len(uniqueKeys))
+	}
+
+	return entities, nil
+}
+
+func getBatch(
+	ctx context.Context,
+	client *db.Wrapper,
+	policy retry.Policy,
+	tableName string,
+	keys []map[string]types.AttributeValue,
+	keysAndAttributes types.KeysAndAttributes,
+	outCh chan *batchGetReport,
+	initBackoffDelay int,
+	maxRetries int,
+) {
+	report := &batchGetReport{}
+	backoff := unprocessedBackoff(initBackoffDelay, maxRetries)
+	begin := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		request := keysAndAttributes
+		request.Keys = keys
+
+		var out *ddb.BatchGetItemOutput
+		err := callWithRetry(ctx, policy, client, func(ctx context.Context) error {
+			var err error
+			out, err = client.AWSClient.BatchGetItem(ctx, &ddb.BatchGetItemInput{
+				RequestItems: map[string]types.KeysAndAttributes{
+					tableName: request,
+				},
+			})
+			return err
+		})
+		if err != nil {
+			report.Errors = append(report.Errors, dberrors.New("BatchGetItem", tableName, "", err))
+			outCh <- report
+			return
+		}
+
+		report.Items = append(report.Items, out.Responses[tableName]...)
+
+		keys = out.UnprocessedKeys[tableName].Keys
+		if len(keys) == 0 {
+			break
+		}
+
+		delay, ok := backoff.NextDelay(attempt, time.Since(begin), nil)
+		if !ok {
+			break
+		}
+		if err := retry.Sleep(ctx, delay); err != nil {
+			report.Errors = append(report.Errors, dberrors.New("BatchGetItem", tableName, "", err))
+			break
+		}
+	}
+
+	report.UnprocessedKeys = len(keys)
+	outCh <- report
+}
+
+func buildKeysAndAttributes(inputOptions InputOptions) types.KeysAndAttributes {
+	keysAndAttributes := types.KeysAndAttributes{}
+
+	if tf, ok := inputOptions[consistentRead]; ok {
+		keysAndAttributes.ConsistentRead = aws.Bool(tf.(bool))
+	}
+
+	if projections, ok := inputOptions[projections]; ok {
+		keysAndAttributes.ProjectionExpression = projections.(*string)
+	}
+
+	if substitutions, ok := inputOptions[optTokenNameSubstitutions]; ok {
+		keysAndAttributes.ExpressionAttributeNames = substitutions.(map[string]string)
+	}
+
+	return keysAndAttributes
+}
+
+func primaryKeyIdentity(avs map[string]types.AttributeValue, schema KeySchema) (string, error) {
+	var identity strings.Builder
+	if err := writeKeyIdentity(&identity, avs, "partition", schema.PkName, schema.PkType); err != nil {
+		return "", err
+	}
+	if schema.SkName != nil {
+		if err := writeKeyIdentity(&identity, avs, "sort", *schema.SkName, schema.SkType); err != nil {
+			return "", err
+		}
+	}
+
+	return identity.String(), nil
+}
+
+func writeKeyIdentity(identity *strings.Builder, avs map[string]types.AttributeValue, role, name string, expected AttributeType) error {
+	value, attributeType, err := keyAttributeValue(avs, role, name, expected)
+	if err != nil {
+		return err
+	}
+
+	var raw string
+	switch attributeType {
+	case AttributeTypeNumber:
+		raw = canonicalNumber(value.(string))
+	case AttributeTypeBinary:
+		raw = string(value.([]byte))
+	default:
+		raw = value.(string)
+	}
+
+	fmt.Fprintf(identity, "%s%d:%s", attributeType, len(raw), raw)
+	return nil
+}
+
+func canonicalNumber(number string) string {
+	r, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return number
+	}
+	return r.RatString()
+}
+
+func createBatchGetError(tableName string, errs []error, unprocessedKeys, totalKeys int) error {
+	var kind error
+	if unprocessedKeys > 0 {
+		kind = dberrors.ErrUnprocessed
+	}
+
+	return &dberrors.Error{
+		Op:    "BatchGetItem",
+		Table: tableName,
+		Kind:  kind,
+		Err: &dberrors.MultiError{
+			Summary: fmt.Sprintf("batch get partially succeeded or had error(s): unprocessed keys: %d/%d", unprocessedKeys, totalKeys),
+			Errors:  errs,
+		},
+	}
+}
@@ -0,0 +1,91 @@
+package table_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/dynamodbtest"
+	"github.com/jhmachado/dynamodb/memdb"
+	"github.com/jhmachado/dynamodb/table"
+	"testing"
+)
+
+type namedItem struct {
+	PK   string `dynamodbav:"pk"`
+	Name string `dynamodbav:"name"`
+}
+
+func TestGetManyReturnsPartialResults(t *testing.T) {
+	throttleBatchGet := memdb.WithThrottle(func(operation string) bool { return operation == "BatchGetItem" })
+	tbl := table.NewTypedTable[namedItem](dynamodbtest.NewTable(t, table.Table{TableName: "partial", KeySchema: table.KeySchema{PkName: "pk"}}, dynamodbtest.WithMemDB(memdb.New(throttleBatchGet))))
+	dynamodbtest.Seed(t, tbl.Table, namedItem{PK: "a", Name: "alpha"}, namedItem{PK: "b", Name: "beta"}, namedItem{PK: "c", Name: "gamma"}, namedItem{PK: "d", Name: "delta"})
+
+	keys := []table.PrimaryKey{stringKey{Pk: "a"}, stringKey{Pk: "b"}, stringKey{Pk: "c"}, stringKey{Pk: "d"}}
+	got, err := tbl.GetMany(context.Background(), keys, table.InputOptions{"BatchWriteMaxRetries": 0})
+	if !errors.Is(err, dberrors.ErrUnprocessed) {
+		t.Fatalf("GetMany() error = %v, want %v", err, dberrors.ErrUnprocessed)
+	}
+
+	if len(got) != len(keys) {
+		t.Fatalf("GetMany() returned %d results, want %d", len(got), len(keys))
+	}
+
+	found := 0
+	for i, item := range got {
+		if item == nil {
+			continue
+		}
+		found++
+		if item.PK != keys[i].PK() {
+			t.Errorf("result %d has key %q, want %q", i, item.PK, keys[i].PK())
+		}
+	}
+	if found != 2 {
+		t.Errorf("GetMany() returned %d items, want the 2 processed keys", found)
+	}
+}
+
+type compositeItem struct {
+	PK   string `dynamodbav:"pk"`
+	SK   string `dynamodbav:"sk"`
+	Name string `dynamodbav:"name"`
+}
+
+func TestGetManyDistinguishesKeysWithSeparators(t *testing.T) {
+	sk := "sk"
+	tbl := table.NewTypedTable[compositeItem](dynamodbtest.NewTable(t, table.Table{TableName: "separators", KeySchema: table.KeySchema{PkName: "pk", SkName: &sk}}))
+	dynamodbtest.Seed(t, tbl.Table, compositeItem{PK: "a, b", SK: "c", Name: "first"}, compositeItem{PK: "a", SK: "b, c", Name: "second"})
+
+	got, err := tbl.GetMany(context.Background(), []table.PrimaryKey{compositeKey{Pk: "a, b", Sk: "c"}, compositeKey{Pk: "a", Sk: "b, c"}}, nil)
+	if err != nil {
+		t.Fatalf("GetMany() error = %v", err)
+	}
+
+	for i, want := range []string{"first", "second"} {
+		if got[i] == nil || got[i].Name != want {
+			t.Errorf("result %d = %+v, want %q", i, got[i], want)
+		}
+	}
+}
+
+func TestGetManyDecodesDuplicateKeysIndependently(t *testing.T) {
+	tbl := table.NewTypedTable[namedItem](dynamodbtest.NewTable(t, table.Table{TableName: "duplicates", KeySchema: table.KeySchema{PkName: "pk"}}))
+	dynamodbtest.Seed(t, tbl.Table, namedItem{PK: "a", Name: "alpha"})
+
+	got, err := tbl.GetMany(context.Background(), []table.PrimaryKey{stringKey{Pk: "a"}, stringKey{Pk: "b"}, stringKey{Pk: "a"}}, nil)
+	if err != nil {
+		t.Fatalf("GetMany() error = %v", err)
+	}
+
+	if got[0] == nil || got[1] != nil || got[2] == nil {
+		t.Fatalf("GetMany() = %v, want results at positions 0 and 2 only", got)
+	}
+	if got[0] == got[2] {
+		t.Fatal("duplicate keys share the same entity")
+	}
+
+	got[0].Name = "changed"
+	if got[2].Name != "alpha" {
+		t.Errorf("modifying one result changed its duplicate to %q", got[2].Name)
+	}
+}
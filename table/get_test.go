@@ -0,0 +1,36 @@
+package table_test
+
+import (
+	"context"
+	"github.com/jhmachado/dynamodb/dynamodbtest"
+	"github.com/jhmachado/dynamodb/table"
+	"reflect"
+	"testing"
+)
+
+func TestGetWithoutEntityResolver(t *testing.T) {
+	tbl := dynamodbtest.NewTable(t, table.Table{TableName: "untyped", KeySchema: table.KeySchema{PkName: "pk"}})
+	dynamodbtest.Seed(t, tbl, map[string]interface{}{"pk": "a", "name": "alpha"})
+
+	tests := []struct {
+		name string
+		key  stringKey
+		want interface{}
+	}{
+		{name: "existing item", key: stringKey{Pk: "a"}, want: map[string]interface{}{"pk": "a", "name": "alpha"}},
+		{name: "missing item", key: stringKey{Pk: "b"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tbl.Get(context.Background(), tt.key, nil)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
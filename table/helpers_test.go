@@ -0,0 +1,26 @@
+package table_test
+
+type stringKey struct {
+	Pk string `dynamodbav:"pk"`
+}
+
+func (k stringKey) PK() interface{} {
+	return k.Pk
+}
+
+func (k stringKey) SK() interface{} {
+	return nil
+}
+
+type compositeKey struct {
+	Pk string `dynamodbav:"pk"`
+	Sk string `dynamodbav:"sk"`
+}
+
+func (k compositeKey) PK() interface{} {
+	return k.Pk
+}
+
+func (k compositeKey) SK() interface{} {
+	return k.Sk
+}
@@ -3,6 +3,11 @@ package table
 import (
 	"errors"
 	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/expression"
 	"strconv"
 	"strings"
 )
@@ -20,7 +25,19 @@ const optPartitionKeyFilter = "PartitionKeyFilter"
 const optTokenValues = "TokenValues"
 const optTokenNameSubstitutions = "TokenNameSubstitutions"
 const projections = "Projections"
+const returnValues = "ReturnValues"
 const limit = "Limit"
+const progressCallback = "ProgressCallback"
+const optEncoderOptions = "EncoderOptions"
+const optClientRequestToken = "ClientRequestToken"
+
+const (
+	ReturnValuesNone       = "NONE"
+	ReturnValuesAllOld     = "ALL_OLD"
+	ReturnValuesUpdatedOld = "UPDATED_OLD"
+	ReturnValuesAllNew     = "ALL_NEW"
+	ReturnValuesUpdatedNew = "UPDATED_NEW"
+)
 
 type InputOptionsFunc func(kvs map[string]interface{}) error
 
@@ -71,6 +88,172 @@ func WithExpression(expressionType, expression string, tokenValues ...interface{
 	}
 }
 
+func WithCondition(expressionType string, condition expression.Condition) InputOptionsFunc {
+	return func(kvs map[string]interface{}) error {
+		builder := newExpressionBuilder(kvs)
+
+		expr, err := builder.Condition(condition)
+		if err != nil {
+			return err
+		}
+
+		if existing, ok := kvs[expressionType]; ok && existing.(string) != "" {
+			expr = fmt.Sprintf("(%s) AND (%s)", existing.(string), expr)
+		}
+
+		kvs[expressionType] = expr
+		mergeExpressionTokens(kvs, builder)
+
+		return nil
+	}
+}
+
+func WithFilter(condition expression.Condition) InputOptionsFunc {
+	return WithCondition(attributesFilter, condition)
+}
+
+func WithConditionExpression(condition expression.Condition) InputOptionsFunc {
+	return WithCondition(conditionExpression, condition)
+}
+
+func WithSortKeyCondition(condition expression.Condition) InputOptionsFunc {
+	return WithCondition(sortKeyFilter, condition)
+}
+
+func WithUpdate(update expression.Update) InputOptionsFunc {
+	return func(kvs map[string]interface{}) error {
+		if existing, ok := kvs[updateExpression]; ok && existing.(string) != "" {
+			return errors.New("update expression already set: " + existing.(string))
+		}
+
+		builder := newExpressionBuilder(kvs)
+
+		expr, err := builder.Update(update)
+		if err != nil {
+			return err
+		}
+
+		kvs[updateExpression] = expr
+		mergeExpressionTokens(kvs, builder)
+
+		return nil
+	}
+}
+
+func newExpressionBuilder(kvs map[string]interface{}) *expression.Builder {
+	var names map[string]string
+	if substitutions, ok := kvs[optTokenNameSubstitutions]; ok {
+		names = substitutions.(map[string]string)
+	}
+
+	var values map[string]interface{}
+	if tvs, ok := kvs[optTokenValues]; ok {
+		values = tvs.(map[string]interface{})
+	}
+
+	return expression.NewBuilder(names, values)
+}
+
+func mergeExpressionTokens(kvs map[string]interface{}, builder *expression.Builder) {
+	if len(builder.Names()) > 0 {
+		if _, ok := kvs[optTokenNameSubstitutions]; !ok {
+			kvs[optTokenNameSubstitutions] = make(map[string]string)
+		}
+
+		substMap := kvs[optTokenNameSubstitutions].(map[string]string)
+		for token, name := range builder.Names() {
+			substMap[token] = name
+		}
+	}
+
+	if len(builder.Values()) > 0 {
+		if _, ok := kvs[optTokenValues]; !ok {
+			kvs[optTokenValues] = make(map[string]interface{})
+		}
+
+		tvMap := kvs[optTokenValues].(map[string]interface{})
+		for token, value := range builder.Values() {
+			tvMap[token] = value
+		}
+	}
+}
+
+func WithReturnValues(rv string) InputOptionsFunc {
+	return func(kvs map[string]interface{}) error {
+		switch rv {
+		case ReturnValuesNone, ReturnValuesAllOld, ReturnValuesUpdatedOld, ReturnValuesAllNew, ReturnValuesUpdatedNew:
+			kvs[returnValues] = rv
+			return nil
+		default:
+			return errors.New("unsupported return values option: " + rv)
+		}
+	}
+}
+
+func WithProgress(callback func(WriteProgress)) InputOptionsFunc {
+	return func(kvs map[string]interface{}) error {
+		if callback == nil {
+			return errors.New("progress callback is nil")
+		}
+		kvs[progressCallback] = callback
+		return nil
+	}
+}
+
+func WithEncoderOptions(optFns ...func(*attributevalue.EncoderOptions)) InputOptionsFunc {
+	return func(kvs map[string]interface{}) error {
+		existing, _ := kvs[optEncoderOptions].([]func(*attributevalue.EncoderOptions))
+		merged := make([]func(*attributevalue.EncoderOptions), 0, len(existing)+len(optFns))
+		kvs[optEncoderOptions] = append(append(merged, existing...), optFns...)
+		return nil
+	}
+}
+
+func WithClientRequestToken(token string) InputOptionsFunc {
+	return func(kvs map[string]interface{}) error {
+		if token == "" {
+			return errors.New("client request token is empty")
+		}
+		kvs[optClientRequestToken] = token
+		return nil
+	}
+}
+
+func clientRequestToken(inputOptions InputOptions) *string {
+	if token, ok := inputOptions[optClientRequestToken]; ok {
+		return aws.String(token.(string))
+	}
+	return nil
+}
+
+func itemReturnValues(op, tableName string, inputOptions InputOptions) (types.ReturnValue, error) {
+	rv, ok := inputOptions[returnValues]
+	if !ok {
+		return "", nil
+	}
+
+	switch rv.(string) {
+	case ReturnValuesNone, ReturnValuesAllOld:
+		return types.ReturnValue(rv.(string)), nil
+	default:
+		return "", dberrors.NewKind(dberrors.ErrValidation, op, tableName, "", fmt.Errorf("return values option %s is not supported by %s", rv, op))
+	}
+}
+
+func withDefaultReturnValues(inputOptions InputOptions, rv string) InputOptions {
+	if _, ok := inputOptions[returnValues]; ok {
+		return inputOptions
+	}
+
+	opts := make(InputOptions, len(inputOptions)+1)
+	for k, v := range inputOptions {
+		opts[k] = v
+	}
+	opts[returnValues] = rv
+
+	return opts
+}
+
 func replaceCharTokensWithCounters(expressionType, expression string) (string, int) {
 	numTokens := 0
 	counter := 0
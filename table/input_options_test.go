@@ -0,0 +1,92 @@
+package table_test
+
+import (
+	"context"
+	"github.com/jhmachado/dynamodb/dynamodbtest"
+	"github.com/jhmachado/dynamodb/expression"
+	"github.com/jhmachado/dynamodb/table"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+type scoredItem struct {
+	PK     string `dynamodbav:"pk"`
+	SK     int    `dynamodbav:"sk"`
+	Status string `dynamodbav:"status"`
+	Score  int    `dynamodbav:"score"`
+}
+
+func TestQueryWithComposedConditions(t *testing.T) {
+	sk := "sk"
+	tbl := table.NewTypedTable[scoredItem](dynamodbtest.NewTable(t, table.Table{TableName: "scores", KeySchema: table.KeySchema{PkName: "pk", SkName: &sk, SkType: table.AttributeTypeNumber}}))
+	dynamodbtest.Seed(t, tbl.Table,
+		scoredItem{PK: "a", SK: 1, Status: "open", Score: 10},
+		scoredItem{PK: "a", SK: 2, Status: "closed", Score: 20},
+		scoredItem{PK: "a", SK: 3, Status: "open", Score: 30},
+		scoredItem{PK: "a", SK: 4, Status: "open", Score: 40},
+		scoredItem{PK: "b", SK: 1, Status: "open", Score: 50},
+	)
+
+	tests := []struct {
+		name    string
+		options []table.InputOptionsFunc
+		want    []int
+	}{
+		{
+			name:    "sort key condition",
+			options: []table.InputOptionsFunc{table.WithSortKeyCondition(expression.Between(expression.Name("sk"), 2, 3))},
+			want:    []int{2, 3},
+		},
+		{
+			name: "sort key condition and filter",
+			options: []table.InputOptionsFunc{
+				table.WithSortKeyCondition(expression.GreaterThan(expression.Name("sk"), 1)),
+				table.WithFilter(expression.Equal(expression.Name("status"), "open")),
+			},
+			want: []int{3, 4},
+		},
+		{
+			name: "repeated filters are combined",
+			options: []table.InputOptionsFunc{
+				table.WithFilter(expression.Equal(expression.Name("status"), "open")),
+				table.WithFilter(expression.LessThan(expression.Name("score"), 40)),
+			},
+			want: []int{1, 3},
+		},
+		{
+			name: "builder filter alongside a raw expression",
+			options: []table.InputOptionsFunc{
+				table.WithExpression("SortKeyFilter", "sk >= ?", 2),
+				table.WithFilter(expression.Equal(expression.Name("status"), "open")),
+			},
+			want: []int{3, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := table.InputOptions{}
+			for _, option := range tt.options {
+				if err := option(opts); err != nil {
+					t.Fatalf("option error = %v", err)
+				}
+			}
+
+			items, err := tbl.Query(context.Background(), "a", opts)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+
+			got := make([]int, 0, len(items))
+			for _, item := range items {
+				got = append(got, item.SK)
+			}
+			sort.Ints(got)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Query() sort keys = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
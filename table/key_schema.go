@@ -3,4 +3,23 @@ package table
 type KeySchema struct {
 	PkName string
 	SkName *string
+	PkType AttributeType
+	SkType AttributeType
+}
+
+func (k KeySchema) PartitionKeyType() AttributeType {
+	if k.PkType == "" {
+		return AttributeTypeString
+	}
+	return k.PkType
+}
+
+func (k KeySchema) SortKeyType() AttributeType {
+	if k.SkName == nil {
+		return ""
+	}
+	if k.SkType == "" {
+		return AttributeTypeString
+	}
+	return k.SkType
 }
@@ -1,49 +1,41 @@
 package table
 
 import (
-	"errors"
 	"fmt"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/jhmachado/dynamodb/util"
 )
 
 type PrimaryKey interface {
-	PK() string
+	PK() interface{}
 	SK() interface{}
 }
 
 func FormatPrimaryKey(key PrimaryKey, schema *KeySchema) string {
 	if schema != nil {
 		if schema.SkName == nil {
-			return key.PK()
+			return fmt.Sprint(key.PK())
 		}
-		return fmt.Sprintf("[%s: %s, %s: %s]", schema.PkName, key.PK(), *schema.SkName, key.SK())
+		return fmt.Sprintf("[%s: %v, %s: %v]", schema.PkName, key.PK(), *schema.SkName, key.SK())
 	}
 
 	if key.SK() == nil {
-		return key.PK()
+		return fmt.Sprint(key.PK())
 	}
 
-	return fmt.Sprintf("[%s, %s]", key.PK(), key.SK())
+	return fmt.Sprintf("[%v, %v]", key.PK(), key.SK())
 }
 
 func GetPrimaryKeyFromAvMap(avs map[string]types.AttributeValue, schema KeySchema) (PrimaryKey, error) {
-	av, ok := avs[schema.PkName]
-	if !ok {
-		return nil, errors.New("partition key not found in attribute values map")
+	pk, _, err := keyAttributeValue(avs, "partition", schema.PkName, schema.PkType)
+	if err != nil {
+		return nil, err
 	}
 
-	pk := av.(*types.AttributeValueMemberS).Value
 	var sk interface{}
-
 	if schema.SkName != nil {
-		av, ok = avs[*schema.SkName]
-		if !ok {
-			return nil, errors.New("sort key not found in attribute values map")
-		}
-
-		var err error
-		sk, err = util.CastAttributeValue(av)
+		sk, _, err = keyAttributeValue(avs, "sort", *schema.SkName, schema.SkType)
 		if err != nil {
 			return nil, err
 		}
@@ -54,3 +46,39 @@ func GetPrimaryKeyFromAvMap(avs map[string]types.AttributeValue, schema KeySchem
 		Sk: sk,
 	}, nil
 }
+
+func keyAttributeValue(avs map[string]types.AttributeValue, role, name string, expected AttributeType) (interface{}, AttributeType, error) {
+	av, ok := avs[name]
+	if !ok || av == nil {
+		return nil, "", fmt.Errorf("%s key %s not found in attribute values map", role, name)
+	}
+
+	var value interface{}
+	var actual AttributeType
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		value, actual = v.Value, AttributeTypeString
+	case *types.AttributeValueMemberN:
+		value, actual = v.Value, AttributeTypeNumber
+	case *types.AttributeValueMemberB:
+		value, actual = v.Value, AttributeTypeBinary
+	default:
+		return nil, "", fmt.Errorf("%s key %s has unsupported attribute value type %T", role, name, av)
+	}
+
+	if expected != "" && expected != actual {
+		return nil, "", fmt.Errorf("%s key %s has type %s, expected %s", role, name, actual, expected)
+	}
+
+	return value, actual, nil
+}
+
+func checkKeyValue(role, name string, expected AttributeType, value interface{}) error {
+	av, err := attributevalue.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = keyAttributeValue(map[string]types.AttributeValue{name: av}, role, name, expected)
+	return err
+}
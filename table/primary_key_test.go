@@ -0,0 +1,181 @@
+package table_test
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/dynamodbtest"
+	"github.com/jhmachado/dynamodb/table"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type numberKeyItem struct {
+	Pk   []byte `dynamodbav:"pk"`
+	Sk   int    `dynamodbav:"sk"`
+	Name string `dynamodbav:"name"`
+}
+
+type sortKeyResolver struct {
+	seen []string
+}
+
+func (r *sortKeyResolver) CreateZeroEntity(primaryKey table.PrimaryKey) (interface{}, bool, error) {
+	r.seen = append(r.seen, primaryKey.SK().(string))
+	return &numberKeyItem{}, true, nil
+}
+
+func (r *sortKeyResolver) JoinEntities(topEntity, relatedEntity interface{}, sk interface{}) error {
+	return nil
+}
+
+func TestGetPrimaryKeyFromAvMap(t *testing.T) {
+	sk := "sk"
+	s := func(v string) types.AttributeValue { return &types.AttributeValueMemberS{Value: v} }
+	n := func(v string) types.AttributeValue { return &types.AttributeValueMemberN{Value: v} }
+	b := func(v string) types.AttributeValue { return &types.AttributeValueMemberB{Value: []byte(v)} }
+
+	tests := []struct {
+		name   string
+		schema table.KeySchema
+		avs    map[string]types.AttributeValue
+		wantPk interface{}
+		wantSk interface{}
+	}{
+		{
+			name:   "string partition key",
+			schema: table.KeySchema{PkName: "pk"},
+			avs:    map[string]types.AttributeValue{"pk": s("a"), "other": n("1")},
+			wantPk: "a",
+		},
+		{
+			name:   "number partition key",
+			schema: table.KeySchema{PkName: "pk", PkType: table.AttributeTypeNumber},
+			avs:    map[string]types.AttributeValue{"pk": n("42")},
+			wantPk: "42",
+		},
+		{
+			name:   "binary partition key",
+			schema: table.KeySchema{PkName: "pk", PkType: table.AttributeTypeBinary},
+			avs:    map[string]types.AttributeValue{"pk": b("ab")},
+			wantPk: []byte("ab"),
+		},
+		{
+			name:   "number sort key",
+			schema: table.KeySchema{PkName: "pk", SkName: &sk, SkType: table.AttributeTypeNumber},
+			avs:    map[string]types.AttributeValue{"pk": s("a"), "sk": n("1.5")},
+			wantPk: "a",
+			wantSk: "1.5",
+		},
+		{
+			name:   "binary sort key",
+			schema: table.KeySchema{PkName: "pk", SkName: &sk, SkType: table.AttributeTypeBinary},
+			avs:    map[string]types.AttributeValue{"pk": n("7"), "sk": b("xy")},
+			wantPk: "7",
+			wantSk: []byte("xy"),
+		},
+		{
+			name:   "untyped schema accepts any key type",
+			schema: table.KeySchema{PkName: "pk", SkName: &sk},
+			avs:    map[string]types.AttributeValue{"pk": b("ab"), "sk": n("3")},
+			wantPk: []byte("ab"),
+			wantSk: "3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := table.GetPrimaryKeyFromAvMap(tt.avs, tt.schema)
+			if err != nil {
+				t.Fatalf("GetPrimaryKeyFromAvMap() error = %v", err)
+			}
+			if !reflect.DeepEqual(key.PK(), tt.wantPk) {
+				t.Errorf("PK() = %#v, want %#v", key.PK(), tt.wantPk)
+			}
+			if !reflect.DeepEqual(key.SK(), tt.wantSk) {
+				t.Errorf("SK() = %#v, want %#v", key.SK(), tt.wantSk)
+			}
+		})
+	}
+}
+
+func TestGetPrimaryKeyFromAvMapErrors(t *testing.T) {
+	sk := "sk"
+
+	tests := []struct {
+		name    string
+		schema  table.KeySchema
+		avs     map[string]types.AttributeValue
+		wantErr string
+	}{
+		{
+			name:    "missing partition key",
+			schema:  table.KeySchema{PkName: "pk"},
+			avs:     map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "a"}},
+			wantErr: "partition key pk not found",
+		},
+		{
+			name:    "missing sort key",
+			schema:  table.KeySchema{PkName: "pk", SkName: &sk},
+			avs:     map[string]types.AttributeValue{"pk": &types.AttributeValueMemberS{Value: "a"}},
+			wantErr: "sort key sk not found",
+		},
+		{
+			name:    "wrong partition key type",
+			schema:  table.KeySchema{PkName: "pk", PkType: table.AttributeTypeNumber},
+			avs:     map[string]types.AttributeValue{"pk": &types.AttributeValueMemberS{Value: "1"}},
+			wantErr: "partition key pk has type S, expected N",
+		},
+		{
+			name:    "wrong sort key type",
+			schema:  table.KeySchema{PkName: "pk", SkName: &sk, SkType: table.AttributeTypeBinary},
+			avs:     map[string]types.AttributeValue{"pk": &types.AttributeValueMemberS{Value: "a"}, "sk": &types.AttributeValueMemberN{Value: "1"}},
+			wantErr: "sort key sk has type N, expected B",
+		},
+		{
+			name:    "unsupported key type",
+			schema:  table.KeySchema{PkName: "pk"},
+			avs:     map[string]types.AttributeValue{"pk": &types.AttributeValueMemberBOOL{Value: true}},
+			wantErr: "partition key pk has unsupported attribute value type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := table.GetPrimaryKeyFromAvMap(tt.avs, tt.schema)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("GetPrimaryKeyFromAvMap() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEntityResolverReceivesNumericSortKeysAsStrings(t *testing.T) {
+	sk := "sk"
+	resolver := &sortKeyResolver{}
+	tbl := dynamodbtest.NewTable(t, table.Table{
+		TableName:      "numeric",
+		KeySchema:      table.KeySchema{PkName: "pk", PkType: table.AttributeTypeBinary, SkName: &sk, SkType: table.AttributeTypeNumber},
+		EntityResolver: resolver,
+	})
+	dynamodbtest.Seed(t, tbl,
+		numberKeyItem{Pk: []byte("a"), Sk: 2, Name: "two"},
+		numberKeyItem{Pk: []byte("a"), Sk: 10, Name: "ten"},
+	)
+
+	entities, err := tbl.Query(context.Background(), []byte("a"), table.InputOptions{})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+
+	want := []interface{}{
+		&numberKeyItem{Pk: []byte("a"), Sk: 2, Name: "two"},
+		&numberKeyItem{Pk: []byte("a"), Sk: 10, Name: "ten"},
+	}
+	if !reflect.DeepEqual(entities, want) {
+		t.Errorf("Query() = %v, want %v", entities, want)
+	}
+	if !reflect.DeepEqual(resolver.seen, []string{"2", "10"}) {
+		t.Errorf("resolved sort keys = %v, want [2 10]", resolver.seen)
+	}
+}
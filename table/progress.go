@@ -0,0 +1,30 @@
+package table
+
+type batchOutcome[R any] struct {
+	report  *R
+	written int
+	retries int
+}
+
+type progressTracker struct {
+	progress WriteProgress
+	callback func(WriteProgress)
+}
+
+func newProgressTracker(inputOptions InputOptions, total int) *progressTracker {
+	tracker := &progressTracker{progress: WriteProgress{TotalItems: total}}
+	if callback, ok := inputOptions[progressCallback]; ok {
+		tracker.callback = callback.(func(WriteProgress))
+	}
+	return tracker
+}
+
+func (t *progressTracker) batchCompleted(written, retries int) {
+	t.progress.BatchesCompleted++
+	t.progress.ItemsWritten += written
+	t.progress.Retries += retries
+
+	if t.callback != nil {
+		t.callback(t.progress)
+	}
+}
@@ -2,46 +2,60 @@ package table
 
 import (
 	"context"
-	"errors"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
-	db "github.com/jhmachado/dynamodb/client"
-	"github.com/jhmachado/dynamodb/util"
+	"github.com/jhmachado/dynamodb/dberrors"
 )
 
-func put(ctx context.Context, table Table, item interface{}, inputOptions InputOptions) error {
-	client, err := db.GetClient()
+func put(ctx context.Context, table Table, item interface{}, inputOptions InputOptions) (interface{}, error) {
+	client, err := table.GetClient()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	itemValues, err := attributevalue.MarshalMap(item)
 	if err != nil {
-		return errors.New("failed to marshal item")
+		return nil, dberrors.NewKind(dberrors.ErrMarshal, "PutItem", table.TableName, "", err)
 	}
 
+	formattedPk := ""
 	key, _ := GetPrimaryKeyFromAvMap(itemValues, table.KeySchema)
 	if key != nil {
-		log.Debugf("[%s] DynamoDB PUT with primay key, %s", table.CollectionName(), FormatPrimaryKey(key, &table.KeySchema))
+		formattedPk = FormatPrimaryKey(key, &table.KeySchema)
+		log.Debugf("[%s] DynamoDB PUT with primay key, %s", table.CollectionName(), formattedPk)
 	}
 
 	putItemInput, err := buildPutInput(table, itemValues, inputOptions)
 	if err != nil {
+		return nil, err
+	}
+
+	var output *dynamodb.PutItemOutput
+	err = callWithWriteRetry(ctx, table.retryPolicy(), client, func(ctx context.Context) error {
+		var err error
+		output, err = client.AWSClient.PutItem(ctx, putItemInput)
 		return err
+	})
+	if err != nil {
+		return nil, dberrors.New("PutItem", table.TableName, formattedPk, err)
 	}
 
-	dbCtx, cancel := util.BuildDBContext(ctx, client.TimeoutsMs)
-	if cancel != nil {
-		defer cancel()
+	if len(output.Attributes) == 0 || key == nil {
+		return nil, nil
 	}
 
-	_, err = client.AWSClient.PutItem(dbCtx, putItemInput)
+	decoder := EntitiesDecoder{
+		EntityResolver: table.EntityResolver,
+		KeySchema:      table.KeySchema,
+	}
+
+	entity, err := decoder.AttributeMapToEntity(key, output.Attributes)
 	if err != nil {
-		return errors.New("failed to put item into table: " + table.TableName)
+		return nil, dberrors.NewKind(dberrors.ErrUnmarshal, "PutItem", table.TableName, formattedPk, err)
 	}
 
-	return nil
+	return entity, nil
 }
 
 func buildPutInput(table Table, itemValues map[string]types.AttributeValue, inputOptions InputOptions) (*dynamodb.PutItemInput, error) {
@@ -58,7 +72,7 @@ func buildPutInput(table Table, itemValues map[string]types.AttributeValue, inpu
 	if tvs, ok := inputOptions[optTokenValues]; ok {
 		avs, err := attributevalue.MarshalMap(tvs.(map[string]interface{}))
 		if err != nil {
-			return nil, errors.New("failed to marshal token values")
+			return nil, dberrors.NewKind(dberrors.ErrMarshal, "PutItem", table.TableName, "", err)
 		}
 		putItemInput.ExpressionAttributeValues = avs
 	}
@@ -67,5 +81,11 @@ func buildPutInput(table Table, itemValues map[string]types.AttributeValue, inpu
 		putItemInput.ExpressionAttributeNames = substitutions.(map[string]string)
 	}
 
+	rv, err := itemReturnValues("PutItem", table.TableName, inputOptions)
+	if err != nil {
+		return nil, err
+	}
+	putItemInput.ReturnValues = rv
+
 	return putItemInput, nil
 }
@@ -0,0 +1,71 @@
+package table_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/table"
+	"testing"
+)
+
+func TestWriteReturnValuesValidation(t *testing.T) {
+	operations := []struct {
+		name string
+		call func(tbl table.Table, opts table.InputOptions) error
+	}{
+		{
+			name: "Put",
+			call: func(tbl table.Table, opts table.InputOptions) error {
+				_, err := tbl.PutAndReturn(context.Background(), map[string]string{"pk": "a"}, opts)
+				return err
+			},
+		},
+		{
+			name: "Delete",
+			call: func(tbl table.Table, opts table.InputOptions) error {
+				_, err := tbl.Delete(context.Background(), stringKey{Pk: "a"}, opts)
+				return err
+			},
+		},
+	}
+
+	tests := []struct {
+		returnValues string
+		valid        bool
+	}{
+		{returnValues: table.ReturnValuesNone, valid: true},
+		{returnValues: table.ReturnValuesAllOld, valid: true},
+		{returnValues: table.ReturnValuesAllNew},
+		{returnValues: table.ReturnValuesUpdatedOld},
+		{returnValues: table.ReturnValuesUpdatedNew},
+	}
+
+	for _, op := range operations {
+		for _, tt := range tests {
+			t.Run(op.name+" "+tt.returnValues, func(t *testing.T) {
+				tbl, api := newFlakyTable(t, false, nil)
+				api.failures = 0
+
+				opts := table.InputOptions{}
+				if err := table.WithReturnValues(tt.returnValues)(opts); err != nil {
+					t.Fatalf("WithReturnValues() error = %v", err)
+				}
+
+				err := op.call(tbl, opts)
+				if tt.valid {
+					if err != nil {
+						t.Fatalf("error = %v", err)
+					}
+					return
+				}
+
+				if !errors.Is(err, dberrors.ErrValidation) {
+					t.Fatalf("error = %v, want %v", err, dberrors.ErrValidation)
+				}
+				if api.calls != 0 {
+					t.Errorf("calls = %d, want the request rejected before reaching DynamoDB", api.calls)
+				}
+			})
+		}
+	}
+}
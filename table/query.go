@@ -7,12 +7,11 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
-	db "github.com/jhmachado/dynamodb/client"
-	"github.com/jhmachado/dynamodb/util"
+	"github.com/jhmachado/dynamodb/dberrors"
 )
 
-func query(ctx context.Context, table Table, partitionKey string, inputOptions InputOptions) ([]interface{}, error) {
-	client, err := db.GetClient()
+func query(ctx context.Context, table Table, partitionKey interface{}, inputOptions InputOptions) ([]interface{}, error) {
+	client, err := table.GetClient()
 	if err != nil {
 		return nil, err
 	}
@@ -24,14 +23,14 @@ func query(ctx context.Context, table Table, partitionKey string, inputOptions I
 
 	log.Debugf("[%s] DynamoDB Query: %s", table.CollectionName(), *queryInput.KeyConditionExpression)
 
-	dbCtx, cancel := util.BuildDBContext(ctx, client.TimeoutsMs)
-	if cancel != nil {
-		defer cancel()
-	}
-
-	queryOutput, err := client.AWSClient.Query(dbCtx, queryInput)
+	var queryOutput *dynamodb.QueryOutput
+	err = callWithRetry(ctx, table.retryPolicy(), client, func(ctx context.Context) error {
+		var err error
+		queryOutput, err = client.AWSClient.Query(ctx, queryInput)
+		return err
+	})
 	if err != nil {
-		return nil, errors.New("query failed: " + err.Error())
+		return nil, dberrors.New("Query", table.CollectionName(), fmt.Sprint(partitionKey), err)
 	}
 
 	log.Debugf("result set size: %d", len(queryOutput.Items))
@@ -41,11 +40,16 @@ func query(ctx context.Context, table Table, partitionKey string, inputOptions I
 		KeySchema:      table.KeySchema,
 	}
 
-	return decoder.AttributeMapsToEntities(queryOutput.Items)
+	entities, err := decoder.AttributeMapsToEntities(queryOutput.Items)
+	if err != nil {
+		return nil, dberrors.NewKind(dberrors.ErrUnmarshal, "Query", table.CollectionName(), fmt.Sprint(partitionKey), err)
+	}
+
+	return entities, nil
 }
 
-func paginatedQuery(table Table, partitionKey string, inputOptions InputOptions) (Paginator, error) {
-	client, err := db.GetClient()
+func paginatedQuery(table Table, partitionKey interface{}, inputOptions InputOptions) (Paginator, error) {
+	client, err := table.GetClient()
 	if err != nil {
 		return nil, err
 	}
@@ -65,15 +69,21 @@ func paginatedQuery(table Table, partitionKey string, inputOptions InputOptions)
 		table.EntityResolver,
 		table.KeySchema,
 		queryInput,
-		[]string{table.CollectionName(), partitionKey},
-	)
+		[]string{table.CollectionName(), fmt.Sprint(partitionKey)},
+	).(*DynamoPaginator)
+	queryPaginator.clientWrapper = client
+	queryPaginator.retryPolicy = table.retryPolicy()
 
 	return queryPaginator, nil
 }
 
-func buildQueryInput(table Table, partitionKey string, inputOptions InputOptions) (*dynamodb.QueryInput, error) {
-	if partitionKey == "" {
-		return nil, errors.New("partition key is empty")
+func buildQueryInput(table Table, partitionKey interface{}, inputOptions InputOptions) (*dynamodb.QueryInput, error) {
+	if partitionKey == nil || partitionKey == "" {
+		return nil, dberrors.NewKind(dberrors.ErrValidation, "Query", table.CollectionName(), "", errors.New("partition key is empty"))
+	}
+
+	if err := checkKeyValue("partition", table.KeySchema.PkName, table.KeySchema.PkType, partitionKey); err != nil {
+		return nil, dberrors.NewKind(dberrors.ErrValidation, "Query", table.CollectionName(), fmt.Sprint(partitionKey), err)
 	}
 
 	queryInput := &dynamodb.QueryInput{
@@ -86,7 +96,7 @@ func buildQueryInput(table Table, partitionKey string, inputOptions InputOptions
 
 	fn := WithExpression(optPartitionKeyFilter, table.KeySchema.PkName+" = ?", partitionKey)
 	if err := fn(inputOptions); err != nil {
-		return nil, err
+		return nil, dberrors.NewKind(dberrors.ErrValidation, "Query", table.CollectionName(), fmt.Sprint(partitionKey), err)
 	}
 
 	expression := inputOptions[optPartitionKeyFilter].(string)
@@ -95,10 +105,16 @@ func buildQueryInput(table Table, partitionKey string, inputOptions InputOptions
 	}
 
 	queryInput.KeyConditionExpression = &expression
+
+	if filter, ok := inputOptions[attributesFilter]; ok {
+		expr := filter.(string)
+		queryInput.FilterExpression = &expr
+	}
+
 	if tvs, ok := inputOptions[optTokenValues]; ok {
 		avs, err := attributevalue.MarshalMap(tvs.(map[string]interface{}))
 		if err != nil {
-			return nil, errors.New("failed to marshal token values")
+			return nil, dberrors.NewKind(dberrors.ErrMarshal, "Query", table.CollectionName(), fmt.Sprint(partitionKey), err)
 		}
 		queryInput.ExpressionAttributeValues = avs
 	}
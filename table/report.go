@@ -6,13 +6,30 @@ type PartiQLCommand struct {
 }
 
 type WriteReport struct {
-	Status         int
-	UnwrittenItems []interface{}
-	Errors         []error
+	Status           int
+	UnwrittenItems   []interface{}
+	UnattemptedItems []interface{}
+	Errors           []error
+}
+
+type DeleteReport struct {
+	Status          int
+	UndeletedKeys   []PrimaryKey
+	UnattemptedKeys []PrimaryKey
+	Errors          []error
+}
+
+type WriteProgress struct {
+	TotalItems       int
+	BatchesCompleted int
+	ItemsWritten     int
+	Retries          int
 }
 
 type ExecutionReport struct {
-	Status           int
-	FailedStatements []PartiQLCommand
-	Errors           []error
+	Status                int
+	FailedStatements      []PartiQLCommand
+	ExhaustedStatements   []PartiQLCommand
+	UnattemptedStatements []PartiQLCommand
+	Errors                []error
 }
@@ -0,0 +1,63 @@
+package table
+
+import (
+	"context"
+	"github.com/jhmachado/dynamodb/client"
+	"github.com/jhmachado/dynamodb/retry"
+	"github.com/jhmachado/dynamodb/util"
+	"time"
+)
+
+func callWithRetry(ctx context.Context, policy retry.Policy, wrapper *client.Wrapper, call func(ctx context.Context) error) error {
+	return retry.Do(ctx, resolveRetryPolicy(policy, wrapper), func(ctx context.Context) error {
+		dbCtx, cancel := util.BuildDBContext(ctx, wrapper.TimeoutsMs)
+		if cancel != nil {
+			defer cancel()
+		}
+		return call(dbCtx)
+	})
+}
+
+func callWithWriteRetry(ctx context.Context, policy retry.Policy, wrapper *client.Wrapper, call func(ctx context.Context) error) error {
+	return callWithRetry(ctx, writeRetryPolicy(policy, wrapper), wrapper, call)
+}
+
+func writeRetryPolicy(policy retry.Policy, wrapper *client.Wrapper) retry.Policy {
+	return retry.NonIdempotent(resolveRetryPolicy(policy, wrapper))
+}
+
+func resolveRetryPolicy(policy retry.Policy, wrapper *client.Wrapper) retry.Policy {
+	if policy != nil {
+		return policy
+	}
+	return retry.ClientPolicy(wrapper != nil && wrapper.SDKRetries)
+}
+
+func unprocessedBackoff(initBackoffDelay, maxRetries int) retry.Backoff {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	backoff := retry.NewBackoff()
+	backoff.MaxAttempts = maxRetries + 1
+	backoff.InitialDelay = time.Duration(initBackoffDelay) * time.Millisecond
+	return backoff
+}
+
+type retryCounter struct {
+	policy  retry.Policy
+	retries int
+}
+
+func (c *retryCounter) NextDelay(attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+	policy := c.policy
+	if policy == nil {
+		policy = retry.DefaultPolicy()
+	}
+
+	delay, ok := policy.NextDelay(attempt, elapsed, err)
+	if ok {
+		c.retries++
+	}
+	return delay, ok
+}
@@ -0,0 +1,152 @@
+package table_test
+
+import (
+	"context"
+	"errors"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/jhmachado/dynamodb/client"
+	"github.com/jhmachado/dynamodb/memdb"
+	"github.com/jhmachado/dynamodb/retry"
+	"github.com/jhmachado/dynamodb/table"
+	"net"
+	"testing"
+)
+
+type flakyAPI struct {
+	*memdb.DB
+	failures int
+	calls    int
+}
+
+func (f *flakyAPI) fail() error {
+	f.calls++
+	if f.calls <= f.failures {
+		return &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+	}
+	return nil
+}
+
+func (f *flakyAPI) GetItem(ctx context.Context, in *ddb.GetItemInput, optFns ...func(*ddb.Options)) (*ddb.GetItemOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return f.DB.GetItem(ctx, in, optFns...)
+}
+
+func (f *flakyAPI) PutItem(ctx context.Context, in *ddb.PutItemInput, optFns ...func(*ddb.Options)) (*ddb.PutItemOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return f.DB.PutItem(ctx, in, optFns...)
+}
+
+func (f *flakyAPI) DeleteItem(ctx context.Context, in *ddb.DeleteItemInput, optFns ...func(*ddb.Options)) (*ddb.DeleteItemOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return f.DB.DeleteItem(ctx, in, optFns...)
+}
+
+func (f *flakyAPI) TransactWriteItems(ctx context.Context, in *ddb.TransactWriteItemsInput, optFns ...func(*ddb.Options)) (*ddb.TransactWriteItemsOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return f.DB.TransactWriteItems(ctx, in, optFns...)
+}
+
+func newFlakyTable(t *testing.T, sdkRetries bool, policy retry.Policy) (table.Table, *flakyAPI) {
+	t.Helper()
+
+	api := &flakyAPI{DB: memdb.New(), failures: 1}
+	wrapper := client.NewWithSDKRetries(api, sdkRetries)
+
+	db := table.NewDB(wrapper).WithRetryPolicy(policy)
+	if _, err := db.CreateTable(context.Background(), table.TableDefinition{TableName: "flaky", KeySchema: table.KeySchema{PkName: "pk"}}); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+
+	return db.Table("flaky", table.KeySchema{PkName: "pk"}, nil), api
+}
+
+func TestRetryOnConnectionErrors(t *testing.T) {
+	fastBackoff := retry.Backoff{MaxAttempts: 3, Retryable: retry.IsRetryable}
+
+	tests := []struct {
+		name       string
+		sdkRetries bool
+		policy     retry.Policy
+		call       func(tbl table.Table) error
+		wantCalls  int
+		wantErr    bool
+	}{
+		{
+			name:   "reads are retried",
+			policy: fastBackoff,
+			call: func(tbl table.Table) error {
+				_, err := tbl.Get(context.Background(), stringKey{Pk: "a"}, nil)
+				return err
+			},
+			wantCalls: 2,
+		},
+		{
+			name:      "writes are not retried on ambiguous errors",
+			policy:    fastBackoff,
+			call:      func(tbl table.Table) error { return tbl.Put(context.Background(), map[string]string{"pk": "a"}, nil) },
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:   "transactions without a request token are not retried",
+			policy: fastBackoff,
+			call: func(tbl table.Table) error {
+				tx := table.NewWriteTransaction()
+				if err := tx.Put(tbl, map[string]string{"pk": "a"}, nil); err != nil {
+					return err
+				}
+				return tx.Commit(context.Background(), nil)
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:   "transactions with a request token are retried",
+			policy: fastBackoff,
+			call: func(tbl table.Table) error {
+				tx := table.NewWriteTransaction()
+				if err := tx.Put(tbl, map[string]string{"pk": "a"}, nil); err != nil {
+					return err
+				}
+				opts := table.InputOptions{}
+				if err := table.WithClientRequestToken("token")(opts); err != nil {
+					return err
+				}
+				return tx.Commit(context.Background(), opts)
+			},
+			wantCalls: 2,
+		},
+		{
+			name:       "the default policy does not stack on SDK retries",
+			sdkRetries: true,
+			call: func(tbl table.Table) error {
+				_, err := tbl.Get(context.Background(), stringKey{Pk: "a"}, nil)
+				return err
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl, api := newFlakyTable(t, tt.sdkRetries, tt.policy)
+
+			err := tt.call(tbl)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if api.calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", api.calls, tt.wantCalls)
+			}
+		})
+	}
+}
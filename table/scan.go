@@ -2,15 +2,13 @@ package table
 
 import (
 	"context"
-	"errors"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
-	db "github.com/jhmachado/dynamodb/client"
-	"github.com/jhmachado/dynamodb/util"
+	"github.com/jhmachado/dynamodb/dberrors"
 )
 
 func scan(ctx context.Context, table Table, inputOptions InputOptions) ([]interface{}, error) {
-	client, err := db.GetClient()
+	client, err := table.GetClient()
 	if err != nil {
 		return nil, err
 	}
@@ -26,14 +24,14 @@ func scan(ctx context.Context, table Table, inputOptions InputOptions) ([]interf
 	}
 	log.Debugf("[%s] DynamoDB scan query: %s", table.CollectionName(), scanQuery)
 
-	dbCtx, cancel := util.BuildDBContext(ctx, client.TimeoutsMs)
-	if cancel != nil {
-		defer cancel()
-	}
-
-	scanOutput, err := client.AWSClient.Scan(dbCtx, scanInput)
+	var scanOutput *dynamodb.ScanOutput
+	err = callWithRetry(ctx, table.retryPolicy(), client, func(ctx context.Context) error {
+		var err error
+		scanOutput, err = client.AWSClient.Scan(ctx, scanInput)
+		return err
+	})
 	if err != nil {
-		return nil, errors.New("scan failed on collection, " + table.CollectionName())
+		return nil, dberrors.New("Scan", table.CollectionName(), "", err)
 	}
 
 	log.Debugf("Result set size: %d", len(scanOutput.Items))
@@ -43,11 +41,16 @@ func scan(ctx context.Context, table Table, inputOptions InputOptions) ([]interf
 		KeySchema:      table.KeySchema,
 	}
 
-	return decoder.AttributeMapsToEntities(scanOutput.Items)
+	entities, err := decoder.AttributeMapsToEntities(scanOutput.Items)
+	if err != nil {
+		return nil, dberrors.NewKind(dberrors.ErrUnmarshal, "Scan", table.CollectionName(), "", err)
+	}
+
+	return entities, nil
 }
 
 func paginatedScan(table Table, inputOptions InputOptions) (Paginator, error) {
-	client, err := db.GetClient()
+	client, err := table.GetClient()
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +71,9 @@ func paginatedScan(table Table, inputOptions InputOptions) (Paginator, error) {
 		table.KeySchema,
 		scanInput,
 		[]string{table.CollectionName()},
-	)
+	).(*DynamoPaginator)
+	scanPaginator.clientWrapper = client
+	scanPaginator.retryPolicy = table.retryPolicy()
 
 	return scanPaginator, nil
 }
@@ -90,7 +95,7 @@ func buildScanInput(table Table, inputOptions InputOptions) (*dynamodb.ScanInput
 	if tvs, ok := inputOptions[optTokenValues]; ok {
 		avs, err := attributevalue.MarshalMap(tvs.(map[string]interface{}))
 		if err != nil {
-			return nil, err
+			return nil, dberrors.NewKind(dberrors.ErrMarshal, "Scan", table.CollectionName(), "", err)
 		}
 
 		scanInput.ExpressionAttributeValues = avs
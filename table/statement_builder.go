@@ -0,0 +1,239 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/expression"
+	"sort"
+	"strings"
+)
+
+type StatementBuilder struct {
+	table      Table
+	kind       string
+	key        PrimaryKey
+	item       interface{}
+	attributes []string
+	sets       []statementAssignment
+	removes    []string
+	conditions []expression.Condition
+}
+
+type statementAssignment struct {
+	path  string
+	value interface{}
+}
+
+func NewSelectStatement(table Table, attributes ...string) StatementBuilder {
+	return StatementBuilder{table: table, kind: "SELECT", attributes: attributes}
+}
+
+func NewInsertStatement(table Table, item interface{}) StatementBuilder {
+	return StatementBuilder{table: table, kind: "INSERT", item: item}
+}
+
+func NewUpdateStatement(table Table, primaryKey PrimaryKey) StatementBuilder {
+	return StatementBuilder{table: table, kind: "UPDATE", key: primaryKey}
+}
+
+func NewDeleteStatement(table Table, primaryKey PrimaryKey) StatementBuilder {
+	return StatementBuilder{table: table, kind: "DELETE", key: primaryKey}
+}
+
+func (s StatementBuilder) WithKey(primaryKey PrimaryKey) StatementBuilder {
+	s.key = primaryKey
+	return s
+}
+
+func (s StatementBuilder) Set(path string, value interface{}) StatementBuilder {
+	sets := make([]statementAssignment, 0, len(s.sets)+1)
+	s.sets = append(append(sets, s.sets...), statementAssignment{path: path, value: value})
+	return s
+}
+
+func (s StatementBuilder) Remove(paths ...string) StatementBuilder {
+	removes := make([]string, 0, len(s.removes)+len(paths))
+	s.removes = append(append(removes, s.removes...), paths...)
+	return s
+}
+
+func (s StatementBuilder) Where(condition expression.Condition) StatementBuilder {
+	conditions := make([]expression.Condition, 0, len(s.conditions)+1)
+	s.conditions = append(append(conditions, s.conditions...), condition)
+	return s
+}
+
+func (s StatementBuilder) Build() (PartiQLCommand, error) {
+	if err := s.validate(); err != nil {
+		return PartiQLCommand{}, dberrors.NewKind(dberrors.ErrValidation, "BuildStatement", s.table.TableName, "", err)
+	}
+
+	b := expression.NewPartiQLBuilder()
+	table := quoteIdentifier(s.table.TableName)
+
+	var statement string
+	var err error
+	switch s.kind {
+	case "SELECT":
+		statement, err = s.buildSelect(b, table)
+	case "INSERT":
+		statement, err = s.buildInsert(b, table)
+	case "UPDATE":
+		statement, err = s.buildUpdate(b, table)
+	case "DELETE":
+		statement, err = s.buildWhere(b, "DELETE FROM "+table)
+	}
+	if err != nil {
+		return PartiQLCommand{}, dberrors.NewKind(dberrors.ErrValidation, "BuildStatement", s.table.TableName, "", err)
+	}
+
+	return PartiQLCommand{Statement: statement, Tokens: b.Parameters()}, nil
+}
+
+func (s StatementBuilder) validate() error {
+	if s.table.TableName == "" {
+		return errors.New("table name is required")
+	}
+
+	if s.table.IndexName != nil && s.kind != "SELECT" {
+		return fmt.Errorf("%s statements cannot target an index", s.kind)
+	}
+
+	switch s.kind {
+	case "SELECT":
+		if len(s.sets) > 0 || len(s.removes) > 0 {
+			return errors.New("SET and REMOVE are only supported on UPDATE statements")
+		}
+	case "INSERT":
+		if s.item == nil {
+			return errors.New("item is required for an INSERT statement")
+		}
+		if s.key != nil || len(s.sets) > 0 || len(s.removes) > 0 || len(s.conditions) > 0 {
+			return errors.New("INSERT statements only support an item")
+		}
+	case "UPDATE", "DELETE":
+		if s.key == nil {
+			return fmt.Errorf("primary key is required for a %s statement", s.kind)
+		}
+		if s.table.KeySchema.SkName != nil && s.key.SK() == nil {
+			return fmt.Errorf("sort key is required for a %s statement", s.kind)
+		}
+		if s.kind == "UPDATE" && len(s.sets) == 0 && len(s.removes) == 0 {
+			return errors.New("UPDATE statements require at least one SET or REMOVE")
+		}
+		if s.kind == "DELETE" && (len(s.sets) > 0 || len(s.removes) > 0) {
+			return errors.New("SET and REMOVE are only supported on UPDATE statements")
+		}
+	default:
+		return fmt.Errorf("unsupported statement kind %q", s.kind)
+	}
+
+	return nil
+}
+
+func (s StatementBuilder) buildSelect(b *expression.Builder, table string) (string, error) {
+	projection := "*"
+	if len(s.attributes) > 0 {
+		paths := make([]string, 0, len(s.attributes))
+		for _, attribute := range s.attributes {
+			path, err := b.Operand(expression.Name(attribute))
+			if err != nil {
+				return "", err
+			}
+			paths = append(paths, path)
+		}
+		projection = strings.Join(paths, ", ")
+	}
+
+	if s.table.IndexName != nil {
+		table += "." + quoteIdentifier(*s.table.IndexName)
+	}
+
+	return s.buildWhere(b, "SELECT "+projection+" FROM "+table)
+}
+
+func (s StatementBuilder) buildInsert(b *expression.Builder, table string) (string, error) {
+	avs, err := attributevalue.MarshalMap(s.item)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := GetPrimaryKeyFromAvMap(avs, s.table.KeySchema); err != nil {
+		return "", err
+	}
+
+	names := make([]string, 0, len(avs))
+	for name := range avs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]string, 0, len(names))
+	for _, name := range names {
+		value, err := b.Operand(expression.Value(avs[name]))
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, "'"+strings.ReplaceAll(name, "'", "''")+"': "+value)
+	}
+
+	return "INSERT INTO " + table + " VALUE {" + strings.Join(fields, ", ") + "}", nil
+}
+
+func (s StatementBuilder) buildUpdate(b *expression.Builder, table string) (string, error) {
+	clauses := make([]string, 0, len(s.sets)+1)
+	for _, set := range s.sets {
+		path, err := b.Operand(expression.Name(set.path))
+		if err != nil {
+			return "", err
+		}
+
+		value, err := b.Operand(expression.Value(set.value))
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, "SET "+path+" = "+value)
+	}
+
+	if len(s.removes) > 0 {
+		paths := make([]string, 0, len(s.removes))
+		for _, remove := range s.removes {
+			path, err := b.Operand(expression.Name(remove))
+			if err != nil {
+				return "", err
+			}
+			paths = append(paths, path)
+		}
+		clauses = append(clauses, "REMOVE "+strings.Join(paths, ", "))
+	}
+
+	return s.buildWhere(b, "UPDATE "+table+" "+strings.Join(clauses, " "))
+}
+
+func (s StatementBuilder) buildWhere(b *expression.Builder, statement string) (string, error) {
+	conditions := make([]expression.Condition, 0, len(s.conditions)+2)
+	if s.key != nil {
+		conditions = append(conditions, expression.Equal(expression.Name(s.table.KeySchema.PkName), s.key.PK()))
+		if s.table.KeySchema.SkName != nil && s.key.SK() != nil {
+			conditions = append(conditions, expression.Equal(expression.Name(*s.table.KeySchema.SkName), s.key.SK()))
+		}
+	}
+	conditions = append(conditions, s.conditions...)
+
+	if len(conditions) == 0 {
+		return statement, nil
+	}
+
+	where, err := b.Condition(expression.And(conditions...))
+	if err != nil {
+		return "", err
+	}
+
+	return statement + " WHERE " + where, nil
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,143 @@
+package table_test
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/dynamodbtest"
+	"github.com/jhmachado/dynamodb/expression"
+	"github.com/jhmachado/dynamodb/table"
+	"reflect"
+	"testing"
+)
+
+func TestStatementBuilder(t *testing.T) {
+	sk := "sk"
+	index := "by.email"
+	quotedIndex := `by"email`
+	users := table.Table{TableName: "users", KeySchema: table.KeySchema{PkName: "pk"}}
+	orders := table.Table{TableName: "orders", KeySchema: table.KeySchema{PkName: "pk", SkName: &sk}}
+
+	tests := []struct {
+		name      string
+		builder   table.StatementBuilder
+		want      string
+		wantToken []interface{}
+	}{
+		{
+			name:    "select everything",
+			builder: table.NewSelectStatement(users),
+			want:    `SELECT * FROM "users"`,
+		},
+		{
+			name:      "select by partition key",
+			builder:   table.NewSelectStatement(users, "name", "address.city").WithKey(stringKey{Pk: "a"}),
+			want:      `SELECT "name", "address"."city" FROM "users" WHERE "pk" = ?`,
+			wantToken: []interface{}{"a"},
+		},
+		{
+			name:      "key predicate precedes conditions",
+			builder:   table.NewSelectStatement(orders).Where(expression.GreaterThan(expression.Name("total"), 10)).WithKey(compositeKey{Pk: "a", Sk: "b"}),
+			want:      `SELECT * FROM "orders" WHERE "pk" = ? AND "sk" = ? AND "total" > ?`,
+			wantToken: []interface{}{"a", "b", 10},
+		},
+		{
+			name:      "index targeting",
+			builder:   table.NewSelectStatement(dynamodbtest.IndexTable(users, index, table.KeySchema{PkName: "email"})).Where(expression.Equal(expression.Name("email"), "x@y")),
+			want:      `SELECT * FROM "users"."by.email" WHERE "email" = ?`,
+			wantToken: []interface{}{"x@y"},
+		},
+		{
+			name:    "quoted index name",
+			builder: table.NewSelectStatement(dynamodbtest.IndexTable(users, quotedIndex, table.KeySchema{PkName: "email"})),
+			want:    `SELECT * FROM "users"."by""email"`,
+		},
+		{
+			name:      "dotted table name",
+			builder:   table.NewDeleteStatement(table.Table{TableName: "orders.v2", KeySchema: table.KeySchema{PkName: "pk"}}, stringKey{Pk: "a"}),
+			want:      `DELETE FROM "orders.v2" WHERE "pk" = ?`,
+			wantToken: []interface{}{"a"},
+		},
+		{
+			name:      "insert item",
+			builder:   table.NewInsertStatement(orders, map[string]interface{}{"pk": "a", "sk": "b", "it's": 1}),
+			want:      `INSERT INTO "orders" VALUE {'it''s': ?, 'pk': ?, 'sk': ?}`,
+			wantToken: []interface{}{&types.AttributeValueMemberN{Value: "1"}, &types.AttributeValueMemberS{Value: "a"}, &types.AttributeValueMemberS{Value: "b"}},
+		},
+		{
+			name:      "update tokens follow clause order",
+			builder:   table.NewUpdateStatement(orders, compositeKey{Pk: "a", Sk: "b"}).Set("name", "x").Remove("old").Set("count", 2).Where(expression.AttributeExists(expression.Name("name"))),
+			want:      `UPDATE "orders" SET "name" = ? SET "count" = ? REMOVE "old" WHERE "pk" = ? AND "sk" = ? AND "name" IS NOT MISSING`,
+			wantToken: []interface{}{"x", 2, "a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, err := tt.builder.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if command.Statement != tt.want {
+				t.Errorf("Statement = %s, want %s", command.Statement, tt.want)
+			}
+			if tt.wantToken != nil && !reflect.DeepEqual(command.Tokens, tt.wantToken) {
+				t.Errorf("Tokens = %#v, want %#v", command.Tokens, tt.wantToken)
+			}
+		})
+	}
+}
+
+func TestStatementBuilderErrors(t *testing.T) {
+	sk := "sk"
+	users := table.Table{TableName: "users", KeySchema: table.KeySchema{PkName: "pk"}}
+	orders := table.Table{TableName: "orders", KeySchema: table.KeySchema{PkName: "pk", SkName: &sk}}
+
+	tests := []struct {
+		name    string
+		builder table.StatementBuilder
+	}{
+		{name: "missing table name", builder: table.NewSelectStatement(table.Table{})},
+		{name: "delete on an index", builder: table.NewDeleteStatement(dynamodbtest.IndexTable(users, "by-email", table.KeySchema{PkName: "email"}), stringKey{Pk: "a"})},
+		{name: "set on select", builder: table.NewSelectStatement(users).Set("a", 1)},
+		{name: "insert without item", builder: table.NewInsertStatement(users, nil)},
+		{name: "insert with key", builder: table.NewInsertStatement(users, stringKey{Pk: "a"}).WithKey(stringKey{Pk: "a"})},
+		{name: "insert without key attribute", builder: table.NewInsertStatement(users, map[string]interface{}{"name": "a"})},
+		{name: "update without key", builder: table.NewUpdateStatement(users, nil).Set("a", 1)},
+		{name: "update without sort key", builder: table.NewUpdateStatement(orders, stringKey{Pk: "a"}).Set("a", 1)},
+		{name: "update without actions", builder: table.NewUpdateStatement(users, stringKey{Pk: "a"})},
+		{name: "delete with set", builder: table.NewDeleteStatement(users, stringKey{Pk: "a"}).Set("a", 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.builder.Build(); !errors.Is(err, dberrors.ErrValidation) {
+				t.Errorf("Build() error = %v, want ErrValidation", err)
+			}
+		})
+	}
+}
+
+func TestStatementBuilderDottedTableName(t *testing.T) {
+	tbl := dynamodbtest.NewTable(t, table.Table{TableName: "orders.v2", KeySchema: table.KeySchema{PkName: "pk"}})
+	ctx := context.Background()
+
+	insert, err := table.NewInsertStatement(tbl, map[string]interface{}{"pk": "a", "name": "alpha"}).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if _, err := tbl.ExecuteStatement(ctx, insert, table.InputOptions{}); err != nil {
+		t.Fatalf("ExecuteStatement(%s) error = %v", insert.Statement, err)
+	}
+	dynamodbtest.AssertItem(t, tbl, stringKey{Pk: "a"}, map[string]interface{}{"pk": "a", "name": "alpha"})
+
+	remove, err := table.NewDeleteStatement(tbl, stringKey{Pk: "a"}).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if _, err := tbl.ExecuteStatement(ctx, remove, table.InputOptions{}); err != nil {
+		t.Fatalf("ExecuteStatement(%s) error = %v", remove.Statement, err)
+	}
+	dynamodbtest.AssertItemNotExists(t, tbl, stringKey{Pk: "a"})
+}
@@ -2,7 +2,9 @@ package table
 
 import (
 	"context"
+	"github.com/jhmachado/dynamodb/client"
 	"github.com/jhmachado/dynamodb/logger"
+	"github.com/jhmachado/dynamodb/retry"
 )
 
 const DefaultPageSize = 50
@@ -18,6 +20,29 @@ type Table struct {
 	IndexName      *string
 	KeySchema      KeySchema
 	EntityResolver EntityResolver
+	Client         *client.Wrapper
+	RetryPolicy    retry.Policy
+}
+
+func (t Table) GetClient() (*client.Wrapper, error) {
+	if t.Client != nil {
+		return t.Client, nil
+	}
+	return client.GetClient()
+}
+
+func (t Table) WithClient(c *client.Wrapper) Table {
+	t.Client = c
+	return t
+}
+
+func (t Table) WithRetryPolicy(policy retry.Policy) Table {
+	t.RetryPolicy = policy
+	return t
+}
+
+func (t Table) retryPolicy() retry.Policy {
+	return t.RetryPolicy
 }
 
 func (t Table) GetEntityResolver() EntityResolver {
@@ -39,20 +64,38 @@ func (t Table) Get(ctx context.Context, primaryKey PrimaryKey, inputOptions Inpu
 	return get(ctx, t, primaryKey, inputOptions)
 }
 
+func (t Table) GetMany(ctx context.Context, keys []PrimaryKey, inputOptions InputOptions) ([]interface{}, error) {
+	return getMany(ctx, t, keys, inputOptions)
+}
+
 func (t Table) Put(ctx context.Context, item interface{}, inputOptions InputOptions) error {
-	return put(ctx, t, item, inputOptions)
+	_, err := put(ctx, t, item, inputOptions)
+	return err
 }
 
-func (t Table) Query(ctx context.Context, partitionKey string, inputOptions InputOptions) ([]interface{}, error) {
+func (t Table) PutAndReturn(ctx context.Context, item interface{}, inputOptions InputOptions) (interface{}, error) {
+	return put(ctx, t, item, withDefaultReturnValues(inputOptions, ReturnValuesAllOld))
+}
+
+func (t Table) Delete(ctx context.Context, primaryKey PrimaryKey, inputOptions InputOptions) (interface{}, error) {
+	return deleteItem(ctx, t, primaryKey, inputOptions)
+}
+
+func (t Table) Query(ctx context.Context, partitionKey interface{}, inputOptions InputOptions) ([]interface{}, error) {
 	return query(ctx, t, partitionKey, inputOptions)
 }
 
-func (t Table) PaginatedQuery(partitionKey string, inputOptions InputOptions) (Paginator, error) {
+func (t Table) PaginatedQuery(partitionKey interface{}, inputOptions InputOptions) (Paginator, error) {
 	return paginatedQuery(t, partitionKey, inputOptions)
 }
 
 func (t Table) Update(ctx context.Context, primaryKey PrimaryKey, inputOptions InputOptions) error {
-	return updateItem(ctx, t, primaryKey, inputOptions)
+	_, err := updateItem(ctx, t, primaryKey, inputOptions)
+	return err
+}
+
+func (t Table) UpdateAndReturn(ctx context.Context, primaryKey PrimaryKey, inputOptions InputOptions) (interface{}, error) {
+	return updateItem(ctx, t, primaryKey, withDefaultReturnValues(inputOptions, ReturnValuesAllNew))
 }
 
 func (t Table) Scan(ctx context.Context, inputOptions InputOptions) ([]interface{}, error) {
@@ -62,3 +105,11 @@ func (t Table) Scan(ctx context.Context, inputOptions InputOptions) ([]interface
 func (t Table) PaginatedScan(inputOptions InputOptions) (Paginator, error) {
 	return paginatedScan(t, inputOptions)
 }
+
+func (t Table) ExecuteStatement(ctx context.Context, partiQL PartiQLCommand, inputOptions InputOptions) ([]interface{}, error) {
+	return executeStatement(ctx, t, partiQL, inputOptions)
+}
+
+func (t Table) PaginatedExecuteStatement(partiQL PartiQLCommand, inputOptions InputOptions) (Paginator, error) {
+	return paginatedExecuteStatement(t, partiQL, inputOptions)
+}
@@ -0,0 +1,116 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/dberrors"
+)
+
+type TransactGetItem struct {
+	Table        Table
+	PrimaryKey   PrimaryKey
+	InputOptions InputOptions
+}
+
+func TransactGet(ctx context.Context, items []TransactGetItem) ([]interface{}, error) {
+	entities := make([]interface{}, len(items))
+	if len(items) == 0 {
+		return entities, nil
+	}
+
+	if len(items) > MaxTransactionItems {
+		return nil, dberrors.NewKind(dberrors.ErrValidation, "TransactGetItems", "", "", fmt.Errorf("a transaction supports at most %d operations", MaxTransactionItems))
+	}
+
+	for _, item := range items[1:] {
+		if item.Table.Client != items[0].Table.Client {
+			return nil, dberrors.NewKind(dberrors.ErrValidation, "TransactGetItems", item.Table.TableName, "", errors.New("all transaction operations must use the same client"))
+		}
+	}
+
+	client, err := items[0].Table.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("DynamoDB TRANSACT GET with %d items", len(items))
+
+	transactItems := make([]types.TransactGetItem, 0, len(items))
+	operations := make([]string, 0, len(items))
+
+	for _, item := range items {
+		get, err := buildTransactGet(item)
+		if err != nil {
+			return nil, err
+		}
+
+		transactItems = append(transactItems, types.TransactGetItem{Get: get})
+		operations = append(operations, describeOperation("GET", item.Table, item.PrimaryKey))
+	}
+
+	var output *ddb.TransactGetItemsOutput
+	err = callWithRetry(ctx, items[0].Table.retryPolicy(), client, func(ctx context.Context) error {
+		var err error
+		output, err = client.AWSClient.TransactGetItems(ctx, &ddb.TransactGetItemsInput{TransactItems: transactItems})
+		return err
+	})
+	if err != nil {
+		var canceled *types.TransactionCanceledException
+		if errors.As(err, &canceled) {
+			err = dberrors.NewTransactionCanceledError(canceled, operations)
+		}
+		return nil, dberrors.New("TransactGetItems", "", "", err)
+	}
+
+	for i, response := range output.Responses {
+		if i >= len(items) || len(response.Item) == 0 {
+			continue
+		}
+
+		decoder := EntitiesDecoder{
+			EntityResolver: items[i].Table.EntityResolver,
+			KeySchema:      items[i].Table.KeySchema,
+		}
+
+		entity, err := decoder.AttributeMapToEntity(items[i].PrimaryKey, response.Item)
+		if err != nil {
+			formattedPk := FormatPrimaryKey(items[i].PrimaryKey, &items[i].Table.KeySchema)
+			return nil, dberrors.NewKind(dberrors.ErrUnmarshal, "TransactGetItems", items[i].Table.TableName, formattedPk, err)
+		}
+
+		entities[i] = entity
+	}
+
+	return entities, nil
+}
+
+func buildTransactGet(item TransactGetItem) (*types.Get, error) {
+	formattedPk := FormatPrimaryKey(item.PrimaryKey, &item.Table.KeySchema)
+	avs, err := attributevalue.MarshalMap(item.PrimaryKey)
+	if err != nil {
+		return nil, dberrors.NewKind(dberrors.ErrMarshal, "TransactGetItems", item.Table.TableName, formattedPk, err)
+	}
+
+	if _, err := primaryKeyIdentity(avs, item.Table.KeySchema); err != nil {
+		return nil, dberrors.NewKind(dberrors.ErrValidation, "TransactGetItems", item.Table.TableName, formattedPk, err)
+	}
+
+	get := &types.Get{
+		TableName: &item.Table.TableName,
+		Key:       avs,
+	}
+
+	if projections, ok := item.InputOptions[projections]; ok {
+		get.ProjectionExpression = projections.(*string)
+	}
+
+	if substitutions, ok := item.InputOptions[optTokenNameSubstitutions]; ok {
+		get.ExpressionAttributeNames = substitutions.(map[string]string)
+	}
+
+	return get, nil
+}
@@ -0,0 +1,71 @@
+package table_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/dynamodbtest"
+	"github.com/jhmachado/dynamodb/memdb"
+	"github.com/jhmachado/dynamodb/table"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type orderLine struct {
+	Pk  string `dynamodbav:"pk"`
+	Sk  string `dynamodbav:"sk"`
+	Qty int    `dynamodbav:"qty"`
+}
+
+func newTransactGetTables(t *testing.T) (table.Table, table.Table) {
+	t.Helper()
+
+	sk := "sk"
+	db := memdb.New()
+	users := table.NewTypedTable[namedItem](dynamodbtest.NewTable(t, table.Table{TableName: "users", KeySchema: table.KeySchema{PkName: "pk"}}, dynamodbtest.WithMemDB(db))).Table
+	orders := dynamodbtest.NewTable(t, table.Table{TableName: "orders", KeySchema: table.KeySchema{PkName: "pk", SkName: &sk}}, dynamodbtest.WithMemDB(db))
+	orders.Client = users.Client
+
+	dynamodbtest.Seed(t, users, namedItem{PK: "u1", Name: "ana"})
+	dynamodbtest.Seed(t, orders, orderLine{Pk: "a", Sk: "1", Qty: 2}, orderLine{Pk: "a", Sk: "2", Qty: 5})
+	return users, orders
+}
+
+func TestTransactGetReturnsItemsInRequestOrder(t *testing.T) {
+	users, orders := newTransactGetTables(t)
+
+	entities, err := table.TransactGet(context.Background(), []table.TransactGetItem{
+		{Table: orders, PrimaryKey: compositeKey{Pk: "a", Sk: "2"}},
+		{Table: users, PrimaryKey: stringKey{Pk: "missing"}},
+		{Table: users, PrimaryKey: stringKey{Pk: "u1"}},
+		{Table: orders, PrimaryKey: compositeKey{Pk: "a", Sk: "missing"}},
+		{Table: orders, PrimaryKey: compositeKey{Pk: "a", Sk: "1"}},
+	})
+	if err != nil {
+		t.Fatalf("TransactGet() error = %v", err)
+	}
+
+	want := []interface{}{
+		map[string]interface{}{"pk": "a", "sk": "2", "qty": float64(5)},
+		nil,
+		&namedItem{PK: "u1", Name: "ana"},
+		nil,
+		map[string]interface{}{"pk": "a", "sk": "1", "qty": float64(2)},
+	}
+	if !reflect.DeepEqual(entities, want) {
+		t.Errorf("TransactGet() = %#v, want %#v", entities, want)
+	}
+}
+
+func TestTransactGetRejectsIncompleteKeys(t *testing.T) {
+	users, orders := newTransactGetTables(t)
+
+	_, err := table.TransactGet(context.Background(), []table.TransactGetItem{
+		{Table: users, PrimaryKey: stringKey{Pk: "u1"}},
+		{Table: orders, PrimaryKey: stringKey{Pk: "a"}},
+	})
+	if !errors.Is(err, dberrors.ErrValidation) || !strings.Contains(err.Error(), "sort key sk not found") {
+		t.Errorf("TransactGet() error = %v, want a validation error for the missing sort key", err)
+	}
+}
@@ -0,0 +1,231 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"sort"
+	"strings"
+)
+
+const MaxTransactionItems = 100
+
+type WriteTransaction struct {
+	items      []types.TransactWriteItem
+	operations []string
+	table      Table
+}
+
+func NewWriteTransaction() *WriteTransaction {
+	return &WriteTransaction{}
+}
+
+func (tx *WriteTransaction) Put(table Table, item interface{}, inputOptions InputOptions) error {
+	if err := validateTransactionOptions(table, "", "a transactional put", inputOptions, conditionExpression, optTokenValues, optTokenNameSubstitutions); err != nil {
+		return err
+	}
+
+	itemValues, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return dberrors.NewKind(dberrors.ErrMarshal, "TransactWriteItems", table.TableName, "", err)
+	}
+
+	putItemInput, err := buildPutInput(table, itemValues, inputOptions)
+	if err != nil {
+		return err
+	}
+
+	operation := "PUT " + table.TableName
+	if key, _ := GetPrimaryKeyFromAvMap(itemValues, table.KeySchema); key != nil {
+		operation = fmt.Sprintf("%s %s", operation, FormatPrimaryKey(key, &table.KeySchema))
+	}
+
+	return tx.add(table, operation, types.TransactWriteItem{
+		Put: &types.Put{
+			TableName:                 putItemInput.TableName,
+			Item:                      putItemInput.Item,
+			ConditionExpression:       putItemInput.ConditionExpression,
+			ExpressionAttributeNames:  putItemInput.ExpressionAttributeNames,
+			ExpressionAttributeValues: putItemInput.ExpressionAttributeValues,
+		},
+	})
+}
+
+func (tx *WriteTransaction) Update(table Table, primaryKey PrimaryKey, inputOptions InputOptions) error {
+	if err := validateTransactionOptions(table, FormatPrimaryKey(primaryKey, &table.KeySchema), "a transactional update", inputOptions, updateExpression, conditionExpression, optTokenValues, optTokenNameSubstitutions); err != nil {
+		return err
+	}
+
+	updateItemInput, err := buildUpdateItemInput(table, primaryKey, inputOptions)
+	if err != nil {
+		return err
+	}
+
+	if updateItemInput.UpdateExpression == nil {
+		return dberrors.NewKind(dberrors.ErrValidation, "TransactWriteItems", table.TableName, FormatPrimaryKey(primaryKey, &table.KeySchema), errors.New("update expression is required for a transactional update"))
+	}
+
+	return tx.add(table, describeOperation("UPDATE", table, primaryKey), types.TransactWriteItem{
+		Update: &types.Update{
+			TableName:                 updateItemInput.TableName,
+			Key:                       updateItemInput.Key,
+			UpdateExpression:          updateItemInput.UpdateExpression,
+			ConditionExpression:       updateItemInput.ConditionExpression,
+			ExpressionAttributeNames:  updateItemInput.ExpressionAttributeNames,
+			ExpressionAttributeValues: updateItemInput.ExpressionAttributeValues,
+		},
+	})
+}
+
+func (tx *WriteTransaction) Delete(table Table, primaryKey PrimaryKey, inputOptions InputOptions) error {
+	if err := validateTransactionOptions(table, FormatPrimaryKey(primaryKey, &table.KeySchema), "a transactional delete", inputOptions, conditionExpression, optTokenValues, optTokenNameSubstitutions); err != nil {
+		return err
+	}
+
+	deleteItemInput, err := buildDeleteItemInput(table, primaryKey, inputOptions)
+	if err != nil {
+		return err
+	}
+
+	return tx.add(table, describeOperation("DELETE", table, primaryKey), types.TransactWriteItem{
+		Delete: &types.Delete{
+			TableName:                 deleteItemInput.TableName,
+			Key:                       deleteItemInput.Key,
+			ConditionExpression:       deleteItemInput.ConditionExpression,
+			ExpressionAttributeNames:  deleteItemInput.ExpressionAttributeNames,
+			ExpressionAttributeValues: deleteItemInput.ExpressionAttributeValues,
+		},
+	})
+}
+
+func (tx *WriteTransaction) ConditionCheck(table Table, primaryKey PrimaryKey, inputOptions InputOptions) error {
+	conditionCheck, err := buildConditionCheck(table, primaryKey, inputOptions)
+	if err != nil {
+		return err
+	}
+
+	return tx.add(table, describeOperation("CONDITION CHECK", table, primaryKey), types.TransactWriteItem{
+		ConditionCheck: conditionCheck,
+	})
+}
+
+func buildConditionCheck(table Table, primaryKey PrimaryKey, inputOptions InputOptions) (*types.ConditionCheck, error) {
+	formattedPk := FormatPrimaryKey(primaryKey, &table.KeySchema)
+
+	if err := validateTransactionOptions(table, formattedPk, "a condition check", inputOptions, conditionExpression, optTokenValues, optTokenNameSubstitutions); err != nil {
+		return nil, err
+	}
+
+	filter, ok := inputOptions[conditionExpression]
+	if !ok || filter.(string) == "" {
+		return nil, dberrors.NewKind(dberrors.ErrValidation, "TransactWriteItems", table.TableName, formattedPk, errors.New("condition expression is required for a condition check"))
+	}
+	expr := filter.(string)
+
+	key, err := attributevalue.MarshalMap(primaryKey)
+	if err != nil {
+		return nil, dberrors.NewKind(dberrors.ErrMarshal, "TransactWriteItems", table.TableName, formattedPk, err)
+	}
+
+	conditionCheck := &types.ConditionCheck{
+		TableName:           &table.TableName,
+		Key:                 key,
+		ConditionExpression: &expr,
+	}
+
+	if tvs, ok := inputOptions[optTokenValues]; ok {
+		avs, err := attributevalue.MarshalMap(tvs.(map[string]interface{}))
+		if err != nil {
+			return nil, dberrors.NewKind(dberrors.ErrMarshal, "TransactWriteItems", table.TableName, formattedPk, err)
+		}
+		conditionCheck.ExpressionAttributeValues = avs
+	}
+
+	if substitutions, ok := inputOptions[optTokenNameSubstitutions]; ok {
+		conditionCheck.ExpressionAttributeNames = substitutions.(map[string]string)
+	}
+
+	return conditionCheck, nil
+}
+
+func validateTransactionOptions(table Table, formattedPk, operation string, inputOptions InputOptions, allowed ...string) error {
+	options := make([]string, 0, len(inputOptions))
+	for option := range inputOptions {
+		supported := false
+		for _, name := range allowed {
+			if option == name {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			options = append(options, option)
+		}
+	}
+
+	if len(options) > 0 {
+		sort.Strings(options)
+		return dberrors.NewKind(dberrors.ErrValidation, "TransactWriteItems", table.TableName, formattedPk, fmt.Errorf("unsupported options for %s: %s", operation, strings.Join(options, ", ")))
+	}
+	return nil
+}
+
+func (tx *WriteTransaction) Commit(ctx context.Context, inputOptions InputOptions) error {
+	if len(tx.items) == 0 {
+		return nil
+	}
+
+	client, err := tx.table.GetClient()
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("DynamoDB TRANSACT WRITE with %d operations", len(tx.items))
+
+	token := clientRequestToken(inputOptions)
+	policy := tx.table.retryPolicy()
+	if token == nil {
+		policy = writeRetryPolicy(policy, client)
+	}
+
+	err = callWithRetry(ctx, policy, client, func(ctx context.Context) error {
+		_, err := client.AWSClient.TransactWriteItems(ctx, &ddb.TransactWriteItemsInput{
+			TransactItems:      tx.items,
+			ClientRequestToken: token,
+		})
+		return err
+	})
+	if err != nil {
+		var canceled *types.TransactionCanceledException
+		if errors.As(err, &canceled) {
+			err = dberrors.NewTransactionCanceledError(canceled, tx.operations)
+		}
+		return dberrors.New("TransactWriteItems", "", "", err)
+	}
+
+	return nil
+}
+
+func (tx *WriteTransaction) add(table Table, operation string, item types.TransactWriteItem) error {
+	if len(tx.items) >= MaxTransactionItems {
+		return dberrors.NewKind(dberrors.ErrValidation, "TransactWriteItems", "", "", fmt.Errorf("a transaction supports at most %d operations", MaxTransactionItems))
+	}
+
+	if len(tx.items) == 0 {
+		tx.table = table
+	} else if tx.table.Client != table.Client {
+		return dberrors.NewKind(dberrors.ErrValidation, "TransactWriteItems", table.TableName, "", errors.New("all transaction operations must use the same client"))
+	}
+
+	tx.items = append(tx.items, item)
+	tx.operations = append(tx.operations, operation)
+	return nil
+}
+
+func describeOperation(operation string, table Table, primaryKey PrimaryKey) string {
+	return fmt.Sprintf("%s %s %s", operation, table.TableName, FormatPrimaryKey(primaryKey, &table.KeySchema))
+}
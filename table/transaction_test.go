@@ -0,0 +1,174 @@
+package table_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/dynamodbtest"
+	"github.com/jhmachado/dynamodb/expression"
+	"github.com/jhmachado/dynamodb/table"
+	"strings"
+	"testing"
+)
+
+func TestWriteTransactionConditionCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     []table.InputOptionsFunc
+		wantAddErr  error
+		wantCommit  error
+		wantWritten bool
+	}{
+		{
+			name:        "passing condition",
+			options:     []table.InputOptionsFunc{table.WithConditionExpression(expression.AttributeExists(expression.Name("pk")))},
+			wantWritten: true,
+		},
+		{
+			name:       "failing condition",
+			options:    []table.InputOptionsFunc{table.WithConditionExpression(expression.AttributeNotExists(expression.Name("pk")))},
+			wantCommit: dberrors.ErrConditionalCheckFailed,
+		},
+		{
+			name:       "missing condition",
+			wantAddErr: dberrors.ErrValidation,
+		},
+		{
+			name: "return values",
+			options: []table.InputOptionsFunc{
+				table.WithConditionExpression(expression.AttributeExists(expression.Name("pk"))),
+				table.WithReturnValues(table.ReturnValuesAllOld),
+			},
+			wantAddErr: dberrors.ErrValidation,
+		},
+		{
+			name: "update expression",
+			options: []table.InputOptionsFunc{
+				table.WithConditionExpression(expression.AttributeExists(expression.Name("pk"))),
+				table.WithUpdate(expression.NewUpdate().Set("name", "x")),
+			},
+			wantAddErr: dberrors.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := dynamodbtest.NewTable(t, table.Table{TableName: "checks", KeySchema: table.KeySchema{PkName: "pk"}})
+			dynamodbtest.Seed(t, tbl, map[string]string{"pk": "a"})
+
+			opts := table.InputOptions{}
+			for _, option := range tt.options {
+				if err := option(opts); err != nil {
+					t.Fatalf("option error = %v", err)
+				}
+			}
+
+			tx := table.NewWriteTransaction()
+			err := tx.ConditionCheck(tbl, stringKey{Pk: "a"}, opts)
+			if tt.wantAddErr != nil {
+				if !errors.Is(err, tt.wantAddErr) {
+					t.Fatalf("ConditionCheck() error = %v, want %v", err, tt.wantAddErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConditionCheck() error = %v", err)
+			}
+
+			if err := tx.Put(tbl, map[string]string{"pk": "b"}, nil); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+
+			err = tx.Commit(context.Background(), nil)
+			if tt.wantCommit != nil && !errors.Is(err, tt.wantCommit) {
+				t.Fatalf("Commit() error = %v, want %v", err, tt.wantCommit)
+			}
+			if tt.wantCommit == nil && err != nil {
+				t.Fatalf("Commit() error = %v", err)
+			}
+
+			if tt.wantWritten {
+				dynamodbtest.AssertItemExists(t, tbl, stringKey{Pk: "b"})
+			} else {
+				dynamodbtest.AssertItemNotExists(t, tbl, stringKey{Pk: "b"})
+			}
+		})
+	}
+}
+
+func TestWriteTransactionRejectsUnsupportedOptions(t *testing.T) {
+	tbl := dynamodbtest.NewTable(t, table.Table{TableName: "writes", KeySchema: table.KeySchema{PkName: "pk"}})
+	dynamodbtest.Seed(t, tbl, map[string]string{"pk": "a", "name": "first"})
+
+	options := func(optionFns ...table.InputOptionsFunc) table.InputOptions {
+		opts := table.InputOptions{}
+		for _, option := range optionFns {
+			if err := option(opts); err != nil {
+				t.Fatalf("option error = %v", err)
+			}
+		}
+		return opts
+	}
+
+	tests := []struct {
+		name    string
+		add     func(tx *table.WriteTransaction) error
+		wantErr string
+	}{
+		{
+			name: "put with return values",
+			add: func(tx *table.WriteTransaction) error {
+				return tx.Put(tbl, map[string]string{"pk": "a"}, options(table.WithReturnValues(table.ReturnValuesAllOld)))
+			},
+			wantErr: "unsupported options for a transactional put: ReturnValues",
+		},
+		{
+			name: "update with return values",
+			add: func(tx *table.WriteTransaction) error {
+				return tx.Update(tbl, stringKey{Pk: "a"}, options(
+					table.WithUpdate(expression.NewUpdate().Set("name", "x")),
+					table.WithReturnValues(table.ReturnValuesAllNew),
+				))
+			},
+			wantErr: "unsupported options for a transactional update: ReturnValues",
+		},
+		{
+			name: "delete with return values and encoder options",
+			add: func(tx *table.WriteTransaction) error {
+				return tx.Delete(tbl, stringKey{Pk: "a"}, options(
+					table.WithReturnValues(table.ReturnValuesAllOld),
+					table.WithEncoderOptions(),
+				))
+			},
+			wantErr: "unsupported options for a transactional delete: EncoderOptions, ReturnValues",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := table.NewWriteTransaction()
+			err := tt.add(tx)
+			if !errors.Is(err, dberrors.ErrValidation) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want a validation error containing %q", err, tt.wantErr)
+			}
+
+			if err := tx.Commit(context.Background(), nil); err != nil {
+				t.Fatalf("Commit() error = %v", err)
+			}
+			dynamodbtest.AssertItem(t, tbl, stringKey{Pk: "a"}, map[string]string{"pk": "a", "name": "first"})
+		})
+	}
+
+	tx := table.NewWriteTransaction()
+	update := options(
+		table.WithUpdate(expression.NewUpdate().Set("name", "second")),
+		table.WithConditionExpression(expression.AttributeExists(expression.Name("pk"))),
+	)
+	if err := tx.Update(tbl, stringKey{Pk: "a"}, update); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := tx.Commit(context.Background(), nil); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	dynamodbtest.AssertItem(t, tbl, stringKey{Pk: "a"}, map[string]string{"pk": "a", "name": "second"})
+}
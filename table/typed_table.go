@@ -0,0 +1,202 @@
+package table
+
+import (
+	"context"
+	"fmt"
+	"github.com/jhmachado/dynamodb/dberrors"
+)
+
+type TypedTable[T any] struct {
+	Table Table
+}
+
+func NewTypedTable[T any](t Table) TypedTable[T] {
+	t.EntityResolver = typedEntityResolver[T]{}
+	return TypedTable[T]{Table: t}
+}
+
+func (t TypedTable[T]) Get(ctx context.Context, primaryKey PrimaryKey, inputOptions InputOptions) (*T, error) {
+	entity, err := t.Table.Get(ctx, primaryKey, inputOptions)
+	if err != nil || entity == nil {
+		return nil, err
+	}
+
+	return t.cast("GetItem", entity)
+}
+
+func (t TypedTable[T]) GetMany(ctx context.Context, keys []PrimaryKey, inputOptions InputOptions) ([]*T, error) {
+	entities, getErr := t.Table.GetMany(ctx, keys, inputOptions)
+	if entities == nil {
+		return nil, getErr
+	}
+
+	typed := make([]*T, len(entities))
+	for i, entity := range entities {
+		if entity == nil {
+			continue
+		}
+
+		var err error
+		typed[i], err = t.cast("BatchGetItem", entity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return typed, getErr
+}
+
+func (t TypedTable[T]) Put(ctx context.Context, item T, inputOptions InputOptions) error {
+	return t.Table.Put(ctx, item, inputOptions)
+}
+
+func (t TypedTable[T]) PutAndReturn(ctx context.Context, item T, inputOptions InputOptions) (*T, error) {
+	entity, err := t.Table.PutAndReturn(ctx, item, inputOptions)
+	if err != nil || entity == nil {
+		return nil, err
+	}
+
+	return t.cast("PutItem", entity)
+}
+
+func (t TypedTable[T]) Update(ctx context.Context, primaryKey PrimaryKey, inputOptions InputOptions) error {
+	return t.Table.Update(ctx, primaryKey, inputOptions)
+}
+
+func (t TypedTable[T]) UpdateAndReturn(ctx context.Context, primaryKey PrimaryKey, inputOptions InputOptions) (*T, error) {
+	entity, err := t.Table.UpdateAndReturn(ctx, primaryKey, inputOptions)
+	if err != nil || entity == nil {
+		return nil, err
+	}
+
+	return t.cast("UpdateItem", entity)
+}
+
+func (t TypedTable[T]) Delete(ctx context.Context, primaryKey PrimaryKey, inputOptions InputOptions) (*T, error) {
+	entity, err := t.Table.Delete(ctx, primaryKey, inputOptions)
+	if err != nil || entity == nil {
+		return nil, err
+	}
+
+	return t.cast("DeleteItem", entity)
+}
+
+func (t TypedTable[T]) Query(ctx context.Context, partitionKey interface{}, inputOptions InputOptions) ([]T, error) {
+	entities, err := t.Table.Query(ctx, partitionKey, inputOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return castEntities[T](t.Table, "Query", entities)
+}
+
+func (t TypedTable[T]) PaginatedQuery(partitionKey interface{}, inputOptions InputOptions) (TypedPaginator[T], error) {
+	paginator, err := t.Table.PaginatedQuery(partitionKey, inputOptions)
+	if err != nil {
+		return TypedPaginator[T]{}, err
+	}
+
+	return TypedPaginator[T]{paginator: paginator, table: t.Table}, nil
+}
+
+func (t TypedTable[T]) Scan(ctx context.Context, inputOptions InputOptions) ([]T, error) {
+	entities, err := t.Table.Scan(ctx, inputOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return castEntities[T](t.Table, "Scan", entities)
+}
+
+func (t TypedTable[T]) PaginatedScan(inputOptions InputOptions) (TypedPaginator[T], error) {
+	paginator, err := t.Table.PaginatedScan(inputOptions)
+	if err != nil {
+		return TypedPaginator[T]{}, err
+	}
+
+	return TypedPaginator[T]{paginator: paginator, table: t.Table}, nil
+}
+
+func (t TypedTable[T]) ExecuteStatement(ctx context.Context, partiQL PartiQLCommand, inputOptions InputOptions) ([]T, error) {
+	entities, err := t.Table.ExecuteStatement(ctx, partiQL, inputOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return castEntities[T](t.Table, "ExecuteStatement", entities)
+}
+
+func (t TypedTable[T]) PaginatedExecuteStatement(partiQL PartiQLCommand, inputOptions InputOptions) (TypedPaginator[T], error) {
+	paginator, err := t.Table.PaginatedExecuteStatement(partiQL, inputOptions)
+	if err != nil {
+		return TypedPaginator[T]{}, err
+	}
+
+	return TypedPaginator[T]{paginator: paginator, table: t.Table}, nil
+}
+
+func (t TypedTable[T]) Write(ctx context.Context, items []T, inputOptions InputOptions) (WriteReport, error) {
+	untyped := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		untyped = append(untyped, item)
+	}
+
+	return Write(ctx, t.Table, untyped, inputOptions)
+}
+
+func (t TypedTable[T]) cast(op string, entity interface{}) (*T, error) {
+	return castEntity[T](t.Table, op, entity)
+}
+
+type TypedPaginator[T any] struct {
+	paginator Paginator
+	table     Table
+}
+
+func (p TypedPaginator[T]) HasMorePages() bool {
+	return p.paginator.HasMorePages()
+}
+
+func (p TypedPaginator[T]) NextPage(ctx context.Context) ([]T, error) {
+	entities, err := p.paginator.NextPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return castEntities[T](p.table, "NextPage", entities)
+}
+
+type typedEntityResolver[T any] struct{}
+
+func (r typedEntityResolver[T]) CreateZeroEntity(primaryKey PrimaryKey) (interface{}, bool, error) {
+	return new(T), true, nil
+}
+
+func (r typedEntityResolver[T]) JoinEntities(topEntity, relatedEntity interface{}, sk interface{}) error {
+	return nil
+}
+
+func castEntity[T any](table Table, op string, entity interface{}) (*T, error) {
+	switch e := entity.(type) {
+	case *T:
+		return e, nil
+	case T:
+		return &e, nil
+	default:
+		err := fmt.Errorf("unexpected entity type %T, expected %T", entity, (*T)(nil))
+		return nil, dberrors.NewKind(dberrors.ErrUnmarshal, op, table.CollectionName(), "", err)
+	}
+}
+
+func castEntities[T any](table Table, op string, entities []interface{}) ([]T, error) {
+	typed := make([]T, 0, len(entities))
+	for _, entity := range entities {
+		e, err := castEntity[T](table, op, entity)
+		if err != nil {
+			return nil, err
+		}
+		typed = append(typed, *e)
+	}
+
+	return typed, nil
+}
@@ -2,17 +2,16 @@ package table
 
 import (
 	"context"
-	"errors"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
-	db "github.com/jhmachado/dynamodb/client"
-	"github.com/jhmachado/dynamodb/util"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/dberrors"
 )
 
-func updateItem(ctx context.Context, table Table, primaryKey PrimaryKey, inputOptions InputOptions) error {
-	client, err := db.GetClient()
+func updateItem(ctx context.Context, table Table, primaryKey PrimaryKey, inputOptions InputOptions) (interface{}, error) {
+	client, err := table.GetClient()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	formattedPk := FormatPrimaryKey(primaryKey, &table.KeySchema)
@@ -20,22 +19,34 @@ func updateItem(ctx context.Context, table Table, primaryKey PrimaryKey, inputOp
 
 	updateItemInput, err := buildUpdateItemInput(table, primaryKey, inputOptions)
 	if err != nil {
+		return nil, err
+	}
+
+	var output *dynamodb.UpdateItemOutput
+	err = callWithWriteRetry(ctx, table.retryPolicy(), client, func(ctx context.Context) error {
+		var err error
+		output, err = client.AWSClient.UpdateItem(ctx, updateItemInput)
 		return err
+	})
+	if err != nil {
+		return nil, dberrors.New("UpdateItem", table.TableName, formattedPk, err)
 	}
 
-	dbCtx, cancel := util.BuildDBContext(ctx, client.TimeoutsMs)
-	if cancel != nil {
-		defer cancel()
+	if len(output.Attributes) == 0 {
+		return nil, nil
 	}
 
-	_, err = client.AWSClient.UpdateItem(dbCtx, updateItemInput)
+	decoder := EntitiesDecoder{
+		EntityResolver: table.EntityResolver,
+		KeySchema:      table.KeySchema,
+	}
+
+	entity, err := decoder.AttributeMapToEntity(primaryKey, output.Attributes)
 	if err != nil {
-		logOptions := []string{table.CollectionName(), formattedPk}
-		message := util.FormatErrorMessage("failed to update item", logOptions)
-		return errors.New(message)
+		return nil, dberrors.NewKind(dberrors.ErrUnmarshal, "UpdateItem", table.TableName, formattedPk, err)
 	}
 
-	return nil
+	return entity, nil
 }
 
 func buildUpdateItemInput(table Table, primaryKey PrimaryKey, inputOptions InputOptions) (*dynamodb.UpdateItemInput, error) {
@@ -45,7 +56,7 @@ func buildUpdateItemInput(table Table, primaryKey PrimaryKey, inputOptions Input
 
 	avs, err := attributevalue.MarshalMap(primaryKey)
 	if err != nil {
-		return nil, errors.New("failed to marshal primary key, " + err.Error())
+		return nil, dberrors.NewKind(dberrors.ErrMarshal, "UpdateItem", table.TableName, FormatPrimaryKey(primaryKey, &table.KeySchema), err)
 	}
 
 	updateItemInput.Key = avs
@@ -63,7 +74,7 @@ func buildUpdateItemInput(table Table, primaryKey PrimaryKey, inputOptions Input
 	if tvs, ok := inputOptions[optTokenValues]; ok {
 		avs, err := attributevalue.MarshalMap(tvs.(map[string]interface{}))
 		if err != nil {
-			return nil, errors.New("failed to marshal token values, " + err.Error())
+			return nil, dberrors.NewKind(dberrors.ErrMarshal, "UpdateItem", table.TableName, "", err)
 		}
 		updateItemInput.ExpressionAttributeValues = avs
 	}
@@ -72,5 +83,9 @@ func buildUpdateItemInput(table Table, primaryKey PrimaryKey, inputOptions Input
 		updateItemInput.ExpressionAttributeNames = substitutions.(map[string]string)
 	}
 
+	if rv, ok := inputOptions[returnValues]; ok {
+		updateItemInput.ReturnValues = types.ReturnValue(rv.(string))
+	}
+
 	return updateItemInput, nil
 }
@@ -0,0 +1,149 @@
+package table_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/dynamodbtest"
+	"github.com/jhmachado/dynamodb/expression"
+	"github.com/jhmachado/dynamodb/table"
+	"reflect"
+	"testing"
+)
+
+type taggedItem struct {
+	PK   string   `dynamodbav:"pk"`
+	Tags []string `dynamodbav:"tags"`
+}
+
+func TestUpdateListAppend(t *testing.T) {
+	tests := []struct {
+		name   string
+		update expression.Update
+	}{
+		{name: "variadic values", update: expression.NewUpdate().ListAppend("tags", "y", "z")},
+		{name: "single slice", update: expression.NewUpdate().ListAppend("tags", []string{"y", "z"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := table.NewTypedTable[taggedItem](dynamodbtest.NewTable(t, table.Table{TableName: "tags", KeySchema: table.KeySchema{PkName: "pk"}}))
+			dynamodbtest.Seed(t, tbl.Table, taggedItem{PK: "a", Tags: []string{"x"}})
+
+			opts := table.InputOptions{}
+			if err := table.WithUpdate(tt.update)(opts); err != nil {
+				t.Fatalf("WithUpdate() error = %v", err)
+			}
+
+			got, err := tbl.UpdateAndReturn(context.Background(), stringKey{Pk: "a"}, opts)
+			if err != nil {
+				t.Fatalf("UpdateAndReturn() error = %v", err)
+			}
+
+			if want := []string{"x", "y", "z"}; !reflect.DeepEqual(got.Tags, want) {
+				t.Errorf("tags = %v, want %v", got.Tags, want)
+			}
+		})
+	}
+}
+
+type counterItem struct {
+	PK      string            `dynamodbav:"pk"`
+	Count   int               `dynamodbav:"count,omitempty"`
+	Labels  []string          `dynamodbav:"labels,stringset,omitempty"`
+	Meta    map[string]string `dynamodbav:"meta,omitempty"`
+	Created string            `dynamodbav:"created,omitempty"`
+}
+
+func TestUpdateActions(t *testing.T) {
+	seed := counterItem{PK: "a", Count: 1, Labels: []string{"x", "y"}, Meta: map[string]string{"owner": "me", "team": "core"}, Created: "first"}
+
+	tests := []struct {
+		name   string
+		update expression.Update
+		want   counterItem
+	}{
+		{
+			name:   "increment",
+			update: expression.NewUpdate().Increment("count", 2),
+			want:   counterItem{PK: "a", Count: 3, Labels: []string{"x", "y"}, Meta: map[string]string{"owner": "me", "team": "core"}, Created: "first"},
+		},
+		{
+			name:   "set if not exists keeps the current value",
+			update: expression.NewUpdate().SetIfNotExists("created", "second"),
+			want:   seed,
+		},
+		{
+			name:   "set and remove nested attributes",
+			update: expression.NewUpdate().Set("meta.owner", "you").Remove("meta.team"),
+			want:   counterItem{PK: "a", Count: 1, Labels: []string{"x", "y"}, Meta: map[string]string{"owner": "you"}, Created: "first"},
+		},
+		{
+			name:   "add to a set",
+			update: expression.NewUpdate().AddToSet("labels", []string{"z"}),
+			want:   counterItem{PK: "a", Count: 1, Labels: []string{"x", "y", "z"}, Meta: map[string]string{"owner": "me", "team": "core"}, Created: "first"},
+		},
+		{
+			name:   "delete from a set",
+			update: expression.NewUpdate().DeleteFromSet("labels", []string{"x"}),
+			want:   counterItem{PK: "a", Count: 1, Labels: []string{"y"}, Meta: map[string]string{"owner": "me", "team": "core"}, Created: "first"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := table.NewTypedTable[counterItem](dynamodbtest.NewTable(t, table.Table{TableName: "counters", KeySchema: table.KeySchema{PkName: "pk"}}))
+			dynamodbtest.Seed(t, tbl.Table, seed)
+
+			opts := table.InputOptions{}
+			if err := table.WithUpdate(tt.update)(opts); err != nil {
+				t.Fatalf("WithUpdate() error = %v", err)
+			}
+
+			if err := tbl.Update(context.Background(), stringKey{Pk: "a"}, opts); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			dynamodbtest.AssertItem(t, tbl.Table, stringKey{Pk: "a"}, tt.want)
+		})
+	}
+}
+
+func TestUpdateWithCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition expression.Condition
+		wantErr   error
+		wantCount int
+	}{
+		{name: "passing condition", condition: expression.LessThan(expression.Name("count"), 5), wantCount: 2},
+		{name: "failing condition", condition: expression.GreaterThan(expression.Name("count"), 5), wantErr: dberrors.ErrConditionalCheckFailed, wantCount: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := table.NewTypedTable[counterItem](dynamodbtest.NewTable(t, table.Table{TableName: "conditional", KeySchema: table.KeySchema{PkName: "pk"}}))
+			dynamodbtest.Seed(t, tbl.Table, counterItem{PK: "a", Count: 1})
+
+			opts := table.InputOptions{}
+			for _, option := range []table.InputOptionsFunc{
+				table.WithConditionExpression(tt.condition),
+				table.WithUpdate(expression.NewUpdate().Increment("count", 1)),
+			} {
+				if err := option(opts); err != nil {
+					t.Fatalf("option error = %v", err)
+				}
+			}
+
+			err := tbl.Update(context.Background(), stringKey{Pk: "a"}, opts)
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Update() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			dynamodbtest.AssertItem(t, tbl.Table, stringKey{Pk: "a"}, counterItem{PK: "a", Count: tt.wantCount})
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/retry"
+	"time"
+)
+
+const (
+	waiterMinDelay = 200 * time.Millisecond
+	waiterMaxDelay = 5 * time.Second
+)
+
+func (db DB) WaitUntilTableActive(ctx context.Context, tableName string, maxWait time.Duration) error {
+	return db.waitFor(ctx, "WaitUntilTableActive", tableName, maxWait, func(d *types.TableDescription) bool {
+		return d != nil && d.TableStatus == types.TableStatusActive
+	})
+}
+
+func (db DB) WaitUntilTableDeleted(ctx context.Context, tableName string, maxWait time.Duration) error {
+	return db.waitFor(ctx, "WaitUntilTableDeleted", tableName, maxWait, func(d *types.TableDescription) bool {
+		return d == nil
+	})
+}
+
+func (db DB) WaitUntilIndexActive(ctx context.Context, tableName, indexName string, maxWait time.Duration) error {
+	return db.waitFor(ctx, "WaitUntilIndexActive", tableName, maxWait, func(d *types.TableDescription) bool {
+		if d == nil {
+			return false
+		}
+		for _, gsi := range d.GlobalSecondaryIndexes {
+			if aws.ToString(gsi.IndexName) == indexName {
+				return gsi.IndexStatus == types.IndexStatusActive
+			}
+		}
+		for _, lsi := range d.LocalSecondaryIndexes {
+			if aws.ToString(lsi.IndexName) == indexName {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (db DB) WaitUntilIndexDeleted(ctx context.Context, tableName, indexName string, maxWait time.Duration) error {
+	return db.waitFor(ctx, "WaitUntilIndexDeleted", tableName, maxWait, func(d *types.TableDescription) bool {
+		if d == nil {
+			return true
+		}
+		for _, gsi := range d.GlobalSecondaryIndexes {
+			if aws.ToString(gsi.IndexName) == indexName {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (db DB) waitFor(ctx context.Context, op, tableName string, maxWait time.Duration, done func(*types.TableDescription) bool) error {
+	wrapper, err := db.GetClient()
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(maxWait)
+	delay := waiterMinDelay
+
+	for {
+		description, err := describeTable(ctx, db.retryPolicy(), wrapper, tableName)
+		switch {
+		case err == nil || errors.Is(err, dberrors.ErrTableNotFound):
+			if done(description) {
+				return nil
+			}
+		case !retry.IsRetryable(err):
+			return err
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return dberrors.NewKind(dberrors.ErrWaitTimeout, op, tableName, "", fmt.Errorf("state not reached after %s", maxWait))
+		}
+		if delay > remaining {
+			delay = remaining
+		}
+
+		select {
+		case <-ctx.Done():
+			return dberrors.New(op, tableName, "", ctx.Err())
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > waiterMaxDelay {
+			delay = waiterMaxDelay
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package table_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jhmachado/dynamodb/client"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/memdb"
+	"github.com/jhmachado/dynamodb/table"
+	"testing"
+	"time"
+)
+
+func TestWaiterTimesOut(t *testing.T) {
+	db := table.NewDB(client.NewWithAPI(memdb.New()))
+
+	start := time.Now()
+	err := db.WaitUntilTableActive(context.Background(), "missing", 50*time.Millisecond)
+	if !errors.Is(err, dberrors.ErrWaitTimeout) {
+		t.Fatalf("WaitUntilTableActive() error = %v, want %v", err, dberrors.ErrWaitTimeout)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("WaitUntilTableActive() took %s, want it to stop near its 50ms limit", elapsed)
+	}
+}
+
+func TestWaiterStopsOnContextCancellation(t *testing.T) {
+	db := table.NewDB(client.NewWithAPI(memdb.New()))
+	if _, err := db.CreateTable(context.Background(), table.TableDefinition{TableName: "kept", KeySchema: table.KeySchema{PkName: "pk"}}); err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := db.WaitUntilTableDeleted(ctx, "kept", time.Minute)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitUntilTableDeleted() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if errors.Is(err, dberrors.ErrWaitTimeout) {
+		t.Errorf("WaitUntilTableDeleted() error = %v, want a cancellation rather than a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("WaitUntilTableDeleted() took %s, want it to stop when the context ends", elapsed)
+	}
+}
+
+func TestWaiterReturnsOnceStateIsReached(t *testing.T) {
+	db := table.NewDB(client.NewWithAPI(memdb.New()))
+
+	if err := db.WaitUntilTableDeleted(context.Background(), "never-created", time.Second); err != nil {
+		t.Errorf("WaitUntilTableDeleted() error = %v", err)
+	}
+	if err := db.WaitUntilIndexActive(context.Background(), "never-created", "index", 10*time.Millisecond); !errors.Is(err, dberrors.ErrWaitTimeout) {
+		t.Errorf("WaitUntilIndexActive() error = %v, want %v", err, dberrors.ErrWaitTimeout)
+	}
+}
@@ -2,17 +2,14 @@ package table
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	ddb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/jhmachado/dynamodb"
 	"github.com/jhmachado/dynamodb/client"
-	"github.com/jhmachado/dynamodb/util"
-	"reflect"
-	"strconv"
-	"strings"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/retry"
 	"time"
 )
 
@@ -22,8 +19,17 @@ const DefaultInitialBackoffDelayMs = 100
 const DefaultMaxRetries = 2
 
 type WriteManager struct {
-	tableName string
-	keySchema KeySchema
+	tableName   string
+	keySchema   KeySchema
+	client      *client.Wrapper
+	retryPolicy retry.Policy
+}
+
+func (m *WriteManager) getClient() (*client.Wrapper, error) {
+	if m.client != nil {
+		return m.client, nil
+	}
+	return client.GetClient()
 }
 
 func (m *WriteManager) Write(ctx context.Context, items []interface{}, inputOptions InputOptions) (WriteReport, error) {
@@ -36,77 +42,155 @@ func (m *WriteManager) Write(ctx context.Context, items []interface{}, inputOpti
 
 	log.Debugf("[%s] Bulk write item count: %d", m.tableName, len(items))
 
-	if len(report.Errors) > 0 {
-		return report, errors.New("bulk write failed")
+	wrapper, clientErr := m.getClient()
+	if clientErr != nil {
+		report.Status = dynamodb.Err
+		report.Errors = append(report.Errors, clientErr)
+		return report, clientErr
 	}
 
-	maxThreads, initBackoffDelay, maxRetries := m.parseInputOptions(inputOptions)
+	maxThreads, initBackoffDelay, maxRetries, err := parseBatchOptions(inputOptions)
+	if err != nil {
+		err = dberrors.NewKind(dberrors.ErrValidation, "BatchWriteItem", m.tableName, "", err)
+		report.Status = dynamodb.Err
+		report.Errors = append(report.Errors, err)
+		return report, err
+	}
+	progress := newProgressTracker(inputOptions, len(items))
 
-	ch := make(chan *WriteReport)
-	start := 0
-	threadCount := 0
+	ch := make(chan batchOutcome[WriteReport])
 
-	for true {
-		if start >= len(items) {
-			break
-		}
+	launched := dispatchBatches(ctx, len(items), MaxItemsPerBatch, maxThreads, func(start, end int) {
+		log.Debugf("Lauching thread to write batch of %d items.", end-start)
+		go writeBatch(ctx, wrapper, m.retryPolicy, items[start:end], m.tableName, m.keySchema, ch, initBackoffDelay, maxRetries)
+	}, func() {
+		outcome := <-ch
+		log.Debug("Finished writting a batch.")
 
-		end := start + MaxItemsPerBatch
-		if end > len(items) {
-			end = len(items)
+		report.Errors = append(report.Errors, outcome.report.Errors...)
+		report.UnwrittenItems = append(report.UnwrittenItems, outcome.report.UnwrittenItems...)
+		report.UnattemptedItems = append(report.UnattemptedItems, outcome.report.UnattemptedItems...)
+		progress.batchCompleted(outcome.written, outcome.retries)
+	})
+
+	if launched < len(items) || len(report.UnattemptedItems) > 0 {
+		report.UnattemptedItems = append(report.UnattemptedItems, items[launched:]...)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			report.Errors = append(report.Errors, dberrors.New("BatchWriteItem", m.tableName, "", ctxErr))
 		}
+	}
 
-		log.Debugf("Lauching thread to write batch of %d items.", end-start)
-		go writeBatch(ctx, items[start:end], m.tableName, m.keySchema, ch, initBackoffDelay, maxRetries)
-		start += MaxItemsPerBatch
+	switch {
+	case len(report.Errors) > 0:
+		report.Status = dynamodb.Err
+	case len(report.UnwrittenItems) > 0 || len(report.UnattemptedItems) > 0:
+		report.Status = dynamodb.Throttled
+	default:
+		report.Status = dynamodb.Success
+	}
 
-		threadCount++
-		if threadCount >= maxThreads {
-			break
-		}
+	if report.Status != dynamodb.Success {
+		err = createBuildWriteError(m.tableName, &report, len(items))
 	}
 
-	for batchReport := range ch {
-		log.Debug("Finished writting a batch.")
-		threadCount--
+	return report, err
+}
 
-		report.Errors = append(report.Errors, batchReport.Errors...)
-		report.UnwrittenItems = append(report.UnwrittenItems, batchReport.UnwrittenItems...)
+func (m *WriteManager) Delete(ctx context.Context, keys []PrimaryKey, inputOptions InputOptions) (DeleteReport, error) {
+	report := DeleteReport{}
 
-		if start < len(items) {
-			end := start + MaxItemsPerBatch
-			if end < len(items) {
-				end = len(items)
-			}
-			log.Debugf("Lauching thread to write batch of %d items.", end-start)
+	if len(keys) == 0 {
+		report.Status = dynamodb.Success
+		return report, nil
+	}
 
-			go writeBatch(ctx, items[start:end], m.tableName, m.keySchema, ch, initBackoffDelay, maxRetries)
-			threadCount++
-			start += MaxItemsPerBatch
-		}
+	log.Debugf("[%s] Bulk delete key count: %d", m.tableName, len(keys))
 
-		if threadCount == 0 {
-			break
+	wrapper, clientErr := m.getClient()
+	if clientErr != nil {
+		report.Status = dynamodb.Err
+		report.Errors = append(report.Errors, clientErr)
+		return report, clientErr
+	}
+
+	maxThreads, initBackoffDelay, maxRetries, err := parseBatchOptions(inputOptions)
+	if err != nil {
+		err = dberrors.NewKind(dberrors.ErrValidation, "BatchWriteItem", m.tableName, "", err)
+		report.Status = dynamodb.Err
+		report.Errors = append(report.Errors, err)
+		return report, err
+	}
+	progress := newProgressTracker(inputOptions, len(keys))
+
+	ch := make(chan batchOutcome[DeleteReport])
+
+	launched := dispatchBatches(ctx, len(keys), MaxItemsPerBatch, maxThreads, func(start, end int) {
+		log.Debugf("Lauching thread to delete batch of %d keys.", end-start)
+		go deleteBatch(ctx, wrapper, m.retryPolicy, keys[start:end], m.tableName, m.keySchema, ch, initBackoffDelay, maxRetries)
+	}, func() {
+		outcome := <-ch
+		log.Debug("Finished deleting a batch.")
+
+		report.Errors = append(report.Errors, outcome.report.Errors...)
+		report.UndeletedKeys = append(report.UndeletedKeys, outcome.report.UndeletedKeys...)
+		report.UnattemptedKeys = append(report.UnattemptedKeys, outcome.report.UnattemptedKeys...)
+		progress.batchCompleted(outcome.written, outcome.retries)
+	})
+
+	if launched < len(keys) || len(report.UnattemptedKeys) > 0 {
+		report.UnattemptedKeys = append(report.UnattemptedKeys, keys[launched:]...)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			report.Errors = append(report.Errors, dberrors.New("BatchWriteItem", m.tableName, "", ctxErr))
 		}
 	}
 
 	switch {
 	case len(report.Errors) > 0:
 		report.Status = dynamodb.Err
-	case len(report.UnwrittenItems) > 0:
+	case len(report.UndeletedKeys) > 0 || len(report.UnattemptedKeys) > 0:
 		report.Status = dynamodb.Throttled
 	default:
 		report.Status = dynamodb.Success
 	}
 
-	var err error
 	if report.Status != dynamodb.Success {
-		err = createBuildWriteError(&report, len(items))
+		err = createBulkDeleteError(m.tableName, &report, len(keys))
 	}
 
 	return report, err
 }
 
+func dispatchBatches(ctx context.Context, total, batchSize, maxThreads int, launch func(start, end int), collect func()) int {
+	start := 0
+	threadCount := 0
+
+	next := func() {
+		end := start + batchSize
+		if end > total {
+			end = total
+		}
+
+		launch(start, end)
+		start = end
+		threadCount++
+	}
+
+	for start < total && threadCount < maxThreads && ctx.Err() == nil {
+		next()
+	}
+
+	for threadCount > 0 {
+		collect()
+		threadCount--
+
+		if start < total && ctx.Err() == nil {
+			next()
+		}
+	}
+
+	return start
+}
+
 func (m *WriteManager) Execute(ctx context.Context, stmts []PartiQLCommand, inputOptions InputOptions) (ExecutionReport, error) {
 	report := ExecutionReport{}
 
@@ -117,193 +201,246 @@ func (m *WriteManager) Execute(ctx context.Context, stmts []PartiQLCommand, inpu
 
 	log.Debugf("[%s] Bulk update statement count: %d", m.tableName, len(stmts))
 
-	if len(report.Errors) > 0 {
+	wrapper, clientErr := m.getClient()
+	if clientErr != nil {
 		report.Status = dynamodb.Err
-		return report, errors.New("bulk update failed")
+		report.Errors = append(report.Errors, clientErr)
+		return report, clientErr
 	}
 
-	maxThreads, _, _ := m.parseInputOptions(inputOptions)
+	maxThreads, initBackoffDelay, maxRetries, err := parseBatchOptions(inputOptions)
+	if err != nil {
+		err = dberrors.NewKind(dberrors.ErrValidation, "BatchExecuteStatement", m.tableName, "", err)
+		report.Status = dynamodb.Err
+		report.Errors = append(report.Errors, err)
+		return report, err
+	}
+	optFns := encoderOptions(inputOptions)
 
 	ch := make(chan *ExecutionReport)
-	start := 0
-	threadCount := 0
-
-	for true {
-		if start >= len(stmts) {
-			break
-		}
-
-		end := start + MaxItemsPerBatch
-		if end > len(stmts) {
-			end = len(stmts)
-		}
-
-		log.Debugf("Lauching thread to write batch of %d items.", end-start)
-		go updateBatch(ctx, stmts[start:end], m.tableName, m.keySchema, ch)
-		start += MaxItemsPerBatch
 
-		threadCount++
-		if threadCount > maxThreads {
-			break
-		}
-	}
-
-	for batchReport := range ch {
+	launched := dispatchBatches(ctx, len(stmts), MaxItemsPerBatch, maxThreads, func(start, end int) {
+		log.Debugf("Lauching thread to execute batch of %d statements.", end-start)
+		go updateBatch(ctx, wrapper, m.retryPolicy, stmts[start:end], m.tableName, ch, initBackoffDelay, maxRetries, optFns)
+	}, func() {
+		batchReport := <-ch
 		log.Debug("Finished executing a batch.")
 
 		report.Errors = append(report.Errors, batchReport.Errors...)
 		report.FailedStatements = append(report.FailedStatements, batchReport.FailedStatements...)
+		report.ExhaustedStatements = append(report.ExhaustedStatements, batchReport.ExhaustedStatements...)
+		report.UnattemptedStatements = append(report.UnattemptedStatements, batchReport.UnattemptedStatements...)
+	})
 
-		if start < len(stmts) {
-			end := start + MaxItemsPerBatch
-			if end > len(stmts) {
-				end = len(stmts)
-			}
-			log.Debugf("Lauching thread to write batch of %d items.", end-start)
-
-			go updateBatch(ctx, stmts[start:end], m.tableName, m.keySchema, ch)
-			threadCount++
-			start += MaxItemsPerBatch
-		}
-
-		if threadCount == 0 {
-			break
+	if launched < len(stmts) || len(report.UnattemptedStatements) > 0 {
+		report.UnattemptedStatements = append(report.UnattemptedStatements, stmts[launched:]...)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			report.Errors = append(report.Errors, dberrors.New("BatchExecuteStatement", m.tableName, "", ctxErr))
 		}
 	}
 
-	var err error
-	if len(report.Errors) > 0 {
+	switch {
+	case len(report.FailedStatements) > 0:
 		report.Status = dynamodb.Err
-		err = createBulkExecutionError(&report, len(stmts))
-	} else {
+	case len(report.ExhaustedStatements) > 0 || len(report.UnattemptedStatements) > 0:
+		report.Status = dynamodb.Throttled
+	case len(report.Errors) > 0:
+		report.Status = dynamodb.Err
+	default:
 		report.Status = dynamodb.Success
 	}
 
+	if report.Status != dynamodb.Success {
+		err = createBulkExecutionError(m.tableName, &report, len(stmts))
+	}
+
 	return report, err
 }
 
-func createBulkExecutionError(report *ExecutionReport, totalStmts int) error {
-	var arr []string
-	arr = append(arr, "{")
-	arr = append(arr, "bulk execution had errors: ")
-	arr = append(arr, fmt.Sprintf("failed statements: %d/%d ", len(report.FailedStatements), totalStmts))
-	arr = append(arr, "errors: [")
-
-	for i, err := range report.Errors {
-		arr = append(arr, err.Error())
-		if i < len(report.Errors)-1 {
-			arr = append(arr, ", ")
-		}
+func createBulkExecutionError(tableName string, report *ExecutionReport, totalStmts int) error {
+	var kind error
+	if len(report.ExhaustedStatements) > 0 || len(report.UnattemptedStatements) > 0 {
+		kind = dberrors.ErrUnprocessed
 	}
 
-	arr = append(arr, "]}")
-	return errors.New(strings.Join(arr, ""))
+	summary := fmt.Sprintf(
+		"bulk execution had errors: failed statements: %d/%d, retries exhausted: %d/%d, unattempted statements: %d/%d",
+		len(report.FailedStatements), totalStmts,
+		len(report.ExhaustedStatements), totalStmts,
+		len(report.UnattemptedStatements), totalStmts,
+	)
+	return newBulkError("BatchExecuteStatement", tableName, summary, kind, report.Errors)
 }
 
-func updateBatch(ctx context.Context, stmts []PartiQLCommand, tableName string, ks KeySchema, outCh chan *ExecutionReport) {
-	var cancelFns []context.CancelFunc
-	defer func() {
-		for _, cancel := range cancelFns {
-			cancel()
-		}
-	}()
-
+func updateBatch(
+	ctx context.Context,
+	client *client.Wrapper,
+	policy retry.Policy,
+	stmts []PartiQLCommand,
+	tableName string,
+	outCh chan *ExecutionReport,
+	initBackoffDelay int,
+	maxRetries int,
+	optFns []func(*attributevalue.EncoderOptions),
+) {
 	report := &ExecutionReport{}
-
-	client, err := client.GetClient()
-	if err != nil {
-		report.Errors = append(report.Errors, err)
+	if ctx.Err() != nil {
+		report.UnattemptedStatements = stmts
 		outCh <- report
 		return
 	}
 
-	var requests []types.BatchStatementRequest
-	for _, stmt := range stmts {
-		req, err := createBatchStatementRequest(stmt)
+	var pending []int
+	requests := make(map[int]types.BatchStatementRequest, len(stmts))
+	for i, stmt := range stmts {
+		req, err := createBatchStatementRequest(stmt, optFns)
+		if err != nil {
+			report.FailedStatements = append(report.FailedStatements, stmt)
+			report.Errors = append(report.Errors, dberrors.NewKind(dberrors.ErrMarshal, "BatchExecuteStatement", tableName, "", err))
+			continue
+		}
+
+		requests[i] = *req
+		pending = append(pending, i)
+	}
+
+	lastErrors := make(map[int]error)
+	backoff := unprocessedBackoff(initBackoffDelay, maxRetries)
+	begin := time.Now()
+
+	for attempt := 1; len(pending) > 0; attempt++ {
+		batch := make([]types.BatchStatementRequest, 0, len(pending))
+		for _, i := range pending {
+			batch = append(batch, requests[i])
+		}
+
+		var out *ddb.BatchExecuteStatementOutput
+		err := callWithWriteRetry(ctx, policy, client, func(ctx context.Context) error {
+			var err error
+			out, err = client.AWSClient.BatchExecuteStatement(ctx, &ddb.BatchExecuteStatementInput{Statements: batch})
+			return err
+		})
 		if err != nil {
+			err = dberrors.New("BatchExecuteStatement", tableName, "", err)
+			for _, i := range pending {
+				if retry.IsRetryable(err) {
+					report.ExhaustedStatements = append(report.ExhaustedStatements, stmts[i])
+				} else {
+					report.FailedStatements = append(report.FailedStatements, stmts[i])
+				}
+			}
 			report.Errors = append(report.Errors, err)
 			outCh <- report
 			return
 		}
 
-		requests = append(requests, *req)
-	}
+		var retryable []int
+		for j, response := range out.Responses {
+			if response.Error == nil || j >= len(pending) {
+				continue
+			}
 
-	out, err := client.AWSClient.BatchExecuteStatement(ctx, &ddb.BatchExecuteStatementInput{Statements: requests})
-	if err != nil {
-		report.Errors = append(report.Errors, errors.New("bulk update table failed"))
-		outCh <- report
-		return
-	}
+			i := pending[j]
+			stmtErr := statementError(tableName, stmts[i], response.Error)
+			if retry.IsRetryable(stmtErr) {
+				retryable = append(retryable, i)
+				lastErrors[i] = stmtErr
+				continue
+			}
 
-	for i, response := range out.Responses {
-		if response.Error != nil {
 			report.FailedStatements = append(report.FailedStatements, stmts[i])
+			report.Errors = append(report.Errors, stmtErr)
+		}
 
-			var msg string
-			if response.Error.Message != nil {
-				msg = *(response.Error.Message)
-			} else {
-				msg = "execution failed"
-			}
+		pending = retryable
+		if len(pending) == 0 {
+			break
+		}
 
-			msg = fmt.Sprintf("%s; parti-QL: %s", msg, stmts[i])
-			report.Errors = append(report.Errors, errors.New(msg))
+		delay, ok := backoff.NextDelay(attempt, time.Since(begin), nil)
+		if !ok {
+			break
+		}
+		if err := retry.Sleep(ctx, delay); err != nil {
+			for _, i := range pending {
+				report.UnattemptedStatements = append(report.UnattemptedStatements, stmts[i])
+			}
+			outCh <- report
+			return
 		}
 	}
 
+	for _, i := range pending {
+		report.ExhaustedStatements = append(report.ExhaustedStatements, stmts[i])
+		report.Errors = append(report.Errors, lastErrors[i])
+	}
+
 	outCh <- report
 }
 
-func createBatchStatementRequest(partiQL PartiQLCommand) (*types.BatchStatementRequest, error) {
-	req := &types.BatchStatementRequest{
-		Statement: &partiQL.Statement,
+func statementError(tableName string, stmt PartiQLCommand, batchErr *types.BatchStatementError) error {
+	msg := "execution failed"
+	if batchErr.Message != nil {
+		msg = *batchErr.Message
 	}
 
-	for _, token := range partiQL.Tokens {
-		if token == nil {
-			req.Parameters = append(req.Parameters, &types.AttributeValueMemberNULL{Value: true})
-			continue
-		}
+	return &dberrors.Error{
+		Op:    "BatchExecuteStatement",
+		Table: tableName,
+		Code:  string(batchErr.Code),
+		Err:   fmt.Errorf("%s; parti-QL: %s", msg, stmt),
+	}
+}
 
-		switch reflect.TypeOf(token).String() {
-		case "string":
-			req.Parameters = append(req.Parameters, &types.AttributeValueMemberS{Value: token.(string)})
-		case "int":
-			req.Parameters = append(req.Parameters, &types.AttributeValueMemberN{Value: strconv.Itoa(token.(int))})
-		case "bool":
-			req.Parameters = append(req.Parameters, &types.AttributeValueMemberBOOL{Value: token.(bool)})
-		default:
-			return nil, errors.New("unsupported type for token `{}`; supported types are: string, int bool and nil")
-		}
+func createBatchStatementRequest(partiQL PartiQLCommand, optFns []func(*attributevalue.EncoderOptions)) (*types.BatchStatementRequest, error) {
+	parameters, err := statementParameters(partiQL, optFns)
+	if err != nil {
+		return nil, err
 	}
 
-	return req, nil
+	return &types.BatchStatementRequest{
+		Statement:  &partiQL.Statement,
+		Parameters: parameters,
+	}, nil
 }
 
-func createBuildWriteError(report *WriteReport, totalItems int) error {
-	var arr []string
-	arr = append(arr, "{")
-	arr = append(arr, "bulk write partially succeeded or had error(s): ")
-	arr = append(arr, fmt.Sprintf("unwritten items: %d/%d", len(report.UnwrittenItems), totalItems))
-	arr = append(arr, "errors: [")
-
-	for i, err := range report.Errors {
-		arr = append(arr, err.Error())
-		if i < len(report.Errors)-1 {
-			arr = append(arr, ", ")
-		}
+func createBuildWriteError(tableName string, report *WriteReport, totalItems int) error {
+	var kind error
+	if len(report.UnwrittenItems) > 0 || len(report.UnattemptedItems) > 0 {
+		kind = dberrors.ErrUnprocessed
 	}
 
-	arr = append(arr, "]}")
-	return errors.New(strings.Join(arr, ""))
+	summary := fmt.Sprintf("bulk write partially succeeded or had error(s): unwritten items: %d/%d, unattempted items: %d/%d", len(report.UnwrittenItems), totalItems, len(report.UnattemptedItems), totalItems)
+	return newBulkError("BatchWriteItem", tableName, summary, kind, report.Errors)
 }
 
-func (m *WriteManager) parseInputOptions(inputOptions InputOptions) (int, int, int) {
+func createBulkDeleteError(tableName string, report *DeleteReport, totalKeys int) error {
+	var kind error
+	if len(report.UndeletedKeys) > 0 || len(report.UnattemptedKeys) > 0 {
+		kind = dberrors.ErrUnprocessed
+	}
+
+	summary := fmt.Sprintf("bulk delete partially succeeded or had error(s): undeleted keys: %d/%d, unattempted keys: %d/%d", len(report.UndeletedKeys), totalKeys, len(report.UnattemptedKeys), totalKeys)
+	return newBulkError("BatchWriteItem", tableName, summary, kind, report.Errors)
+}
+
+func newBulkError(op, tableName, summary string, kind error, errs []error) error {
+	return &dberrors.Error{
+		Op:    op,
+		Table: tableName,
+		Kind:  kind,
+		Err:   &dberrors.MultiError{Summary: summary, Errors: errs},
+	}
+}
+
+func parseBatchOptions(inputOptions InputOptions) (int, int, int, error) {
 	maxThreads := DefaultConcurrency
 	if concurrency, ok := inputOptions[writeConcurrency]; ok {
 		maxThreads = concurrency.(int)
 	}
+	if maxThreads < 1 {
+		return 0, 0, 0, fmt.Errorf("write concurrency must be at least 1, got %d", maxThreads)
+	}
 
 	initBackoffDelay := DefaultInitialBackoffDelayMs
 	if delay, ok := inputOptions[batchWriteInitialBackofficeDelayMs]; ok {
@@ -315,48 +452,43 @@ func (m *WriteManager) parseInputOptions(inputOptions InputOptions) (int, int, i
 		maxRetries = val.(int)
 	}
 
-	return maxThreads, initBackoffDelay, maxRetries
+	return maxThreads, initBackoffDelay, maxRetries, nil
 }
 
 func writeBatch(
 	ctx context.Context,
+	client *client.Wrapper,
+	policy retry.Policy,
 	items []interface{},
 	tableName string,
 	ks KeySchema,
-	outCh chan *WriteReport,
+	outCh chan batchOutcome[WriteReport],
 	initBackoffDelay int,
 	maxRetries int,
 ) {
-	var cancelFns []context.CancelFunc
-	defer func() {
-		for _, cancel := range cancelFns {
-			cancel()
-		}
-	}()
-
 	report := &WriteReport{}
-	keyToItem := make(map[string]interface{})
-
-	client, err := client.GetClient()
-	if err != nil {
-		report.Errors = append(report.Errors, err)
-		outCh <- report
+	if ctx.Err() != nil {
+		report.UnattemptedItems = items
+		outCh <- batchOutcome[WriteReport]{report: report}
 		return
 	}
 
+	keyToItem := make(map[string]interface{})
+
 	writeReqs := make([]types.WriteRequest, 0, len(items))
 	for _, item := range items {
 		avs, err := attributevalue.MarshalMap(item)
 		if err != nil {
-			report.Errors = append(report.Errors, err)
+			report.Errors = append(report.Errors, dberrors.NewKind(dberrors.ErrMarshal, "BatchWriteItem", tableName, "", err))
+			continue
 		}
 
-		key, err := GetPrimaryKeyFromAvMap(avs, ks)
+		identity, err := primaryKeyIdentity(avs, ks)
 		if err != nil {
-			report.Errors = append(report.Errors, err)
+			report.Errors = append(report.Errors, dberrors.NewKind(dberrors.ErrValidation, "BatchWriteItem", tableName, "", err))
 			continue
 		}
-		keyToItem[FormatPrimaryKey(key, nil)] = item
+		keyToItem[identity] = item
 
 		writeReqs = append(writeReqs, types.WriteRequest{
 			PutRequest: &types.PutRequest{Item: avs},
@@ -364,74 +496,124 @@ func writeBatch(
 	}
 
 	if len(writeReqs) == 0 {
-		outCh <- report
+		outCh <- batchOutcome[WriteReport]{report: report}
 		return
 	}
 
-	retryCount := 0
-	backoffDelay := initBackoffDelay
+	attempted := len(writeReqs)
+	writeReqs, errs, retries := batchWriteWithRetries(ctx, client, policy, tableName, writeReqs, initBackoffDelay, maxRetries)
+	report.Errors = append(report.Errors, errs...)
 
-	for true {
-		dbCtx, cancel := util.BuildDBContext(ctx, client.TimeoutsMs)
-		if cancel != nil {
-			cancelFns = append(cancelFns, cancel)
-		}
+	for _, writeReq := range writeReqs {
+		identity, _ := primaryKeyIdentity(writeReq.PutRequest.Item, ks)
+		report.UnwrittenItems = append(report.UnwrittenItems, keyToItem[identity])
+	}
+
+	outCh <- batchOutcome[WriteReport]{report: report, written: attempted - len(writeReqs), retries: retries}
+}
 
-		out, err := getBatchWriteFunc()(dbCtx, client.AWSClient, tableName, writeReqs)
+func deleteBatch(
+	ctx context.Context,
+	client *client.Wrapper,
+	policy retry.Policy,
+	keys []PrimaryKey,
+	tableName string,
+	ks KeySchema,
+	outCh chan batchOutcome[DeleteReport],
+	initBackoffDelay int,
+	maxRetries int,
+) {
+	report := &DeleteReport{}
+	if ctx.Err() != nil {
+		report.UnattemptedKeys = keys
+		outCh <- batchOutcome[DeleteReport]{report: report}
+		return
+	}
+
+	identityToKey := make(map[string]PrimaryKey)
+
+	writeReqs := make([]types.WriteRequest, 0, len(keys))
+	for _, primaryKey := range keys {
+		avs, err := attributevalue.MarshalMap(primaryKey)
 		if err != nil {
-			report.Errors = append(report.Errors, err)
-			if out == nil {
-				break
-			}
+			report.Errors = append(report.Errors, dberrors.NewKind(dberrors.ErrMarshal, "BatchWriteItem", tableName, FormatPrimaryKey(primaryKey, &ks), err))
+			continue
 		}
 
-		writeReqs = nil
-		if len(out.UnprocessedItems) > 0 {
-			writeReqs = append(writeReqs, out.UnprocessedItems[tableName]...)
-			if retryCount >= maxRetries {
-				break
-			}
-
-			retryCount++
-			time.Sleep(time.Duration(backoffDelay) * time.Millisecond)
-			backoffDelay *= 2
+		identity, err := primaryKeyIdentity(avs, ks)
+		if err != nil {
+			report.Errors = append(report.Errors, dberrors.NewKind(dberrors.ErrValidation, "BatchWriteItem", tableName, FormatPrimaryKey(primaryKey, &ks), err))
 			continue
 		}
+		identityToKey[identity] = primaryKey
 
-		break
+		writeReqs = append(writeReqs, types.WriteRequest{
+			DeleteRequest: &types.DeleteRequest{Key: avs},
+		})
 	}
 
-	if len(writeReqs) > 0 {
-		for _, writeReq := range writeReqs {
-			avs := writeReq.PutRequest.Item
-			key, _ := GetPrimaryKeyFromAvMap(avs, ks)
-			item := keyToItem[FormatPrimaryKey(key, nil)]
-			report.UnwrittenItems = append(report.UnwrittenItems, item)
-		}
+	if len(writeReqs) == 0 {
+		outCh <- batchOutcome[DeleteReport]{report: report}
+		return
 	}
 
-	outCh <- report
+	attempted := len(writeReqs)
+	writeReqs, errs, retries := batchWriteWithRetries(ctx, client, policy, tableName, writeReqs, initBackoffDelay, maxRetries)
+	report.Errors = append(report.Errors, errs...)
+
+	for _, writeReq := range writeReqs {
+		identity, _ := primaryKeyIdentity(writeReq.DeleteRequest.Key, ks)
+		report.UndeletedKeys = append(report.UndeletedKeys, identityToKey[identity])
+	}
+
+	outCh <- batchOutcome[DeleteReport]{report: report, written: attempted - len(writeReqs), retries: retries}
 }
 
-type batchWriteFunc func(context.Context, *ddb.Client, string, []types.WriteRequest) (*ddb.BatchWriteItemOutput, error)
+func batchWriteWithRetries(
+	ctx context.Context,
+	client *client.Wrapper,
+	policy retry.Policy,
+	tableName string,
+	writeReqs []types.WriteRequest,
+	initBackoffDelay int,
+	maxRetries int,
+) ([]types.WriteRequest, []error, int) {
+	var errs []error
+	counter := &retryCounter{policy: resolveRetryPolicy(policy, client)}
+	backoff := unprocessedBackoff(initBackoffDelay, maxRetries)
+	begin := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		var out *ddb.BatchWriteItemOutput
+		err := callWithRetry(ctx, counter, client, func(ctx context.Context) error {
+			var err error
+			out, err = client.AWSClient.BatchWriteItem(ctx, &ddb.BatchWriteItemInput{
+				RequestItems: map[string][]types.WriteRequest{
+					tableName: writeReqs,
+				},
+			})
+			return err
+		})
+		if err != nil {
+			errs = append(errs, dberrors.New("BatchWriteItem", tableName, "", err))
+			break
+		}
 
-var batchWriter batchWriteFunc
+		writeReqs = out.UnprocessedItems[tableName]
+		if len(writeReqs) == 0 {
+			break
+		}
 
-func getBatchWriteFunc() batchWriteFunc {
-	if batchWriter == nil {
-		batchWriter = makeDynamoDBBatchWriteCall
+		delay, ok := backoff.NextDelay(attempt, time.Since(begin), nil)
+		if !ok {
+			break
+		}
+		if err := retry.Sleep(ctx, delay); err != nil {
+			errs = append(errs, dberrors.New("BatchWriteItem", tableName, "", err))
+			break
+		}
+		counter.retries++
 	}
-	return batchWriter
-}
 
-func setBatchWriteFunc(fn batchWriteFunc) {
-	batchWriter = fn
-}
-
-func makeDynamoDBBatchWriteCall(ctx context.Context, client *ddb.Client, tableName string, writeReqs []types.WriteRequest) (*ddb.BatchWriteItemOutput, error) {
-	return client.BatchWriteItem(ctx, &ddb.BatchWriteItemInput{
-		RequestItems: map[string][]types.WriteRequest{
-			tableName: writeReqs,
-		},
-	})
+	return writeReqs, errs, counter.retries
 }
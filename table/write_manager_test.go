@@ -0,0 +1,211 @@
+package table_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jhmachado/dynamodb/dberrors"
+	"github.com/jhmachado/dynamodb/dynamodbtest"
+	"github.com/jhmachado/dynamodb/memdb"
+	"github.com/jhmachado/dynamodb/table"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBulkOperationsRejectInvalidConcurrency(t *testing.T) {
+	operations := []struct {
+		name string
+		call func(tbl table.Table, opts table.InputOptions) error
+	}{
+		{
+			name: "Write",
+			call: func(tbl table.Table, opts table.InputOptions) error {
+				_, err := table.Write(context.Background(), tbl, []interface{}{map[string]string{"pk": "b"}}, opts)
+				return err
+			},
+		},
+		{
+			name: "Delete",
+			call: func(tbl table.Table, opts table.InputOptions) error {
+				_, err := table.Delete(context.Background(), tbl, []table.PrimaryKey{stringKey{Pk: "a"}}, opts)
+				return err
+			},
+		},
+		{
+			name: "Execute",
+			call: func(tbl table.Table, opts table.InputOptions) error {
+				_, err := table.Execute(context.Background(), tbl, []table.PartiQLCommand{{Statement: `DELETE FROM "concurrency" WHERE pk = 'a'`}}, opts)
+				return err
+			},
+		},
+		{
+			name: "GetMany",
+			call: func(tbl table.Table, opts table.InputOptions) error {
+				_, err := tbl.GetMany(context.Background(), []table.PrimaryKey{stringKey{Pk: "a"}}, opts)
+				return err
+			},
+		},
+	}
+
+	for _, op := range operations {
+		for _, concurrency := range []int{0, -1} {
+			t.Run(fmt.Sprintf("%s with concurrency %d", op.name, concurrency), func(t *testing.T) {
+				tbl := dynamodbtest.NewTable(t, table.Table{TableName: "concurrency", KeySchema: table.KeySchema{PkName: "pk"}})
+				dynamodbtest.Seed(t, tbl, map[string]string{"pk": "a"})
+
+				err := op.call(tbl, table.InputOptions{"WriteConcurrency": concurrency})
+				if !errors.Is(err, dberrors.ErrValidation) {
+					t.Fatalf("error = %v, want %v", err, dberrors.ErrValidation)
+				}
+
+				dynamodbtest.AssertTableContains(t, tbl, map[string]string{"pk": "a"})
+			})
+		}
+	}
+}
+
+func TestBulkWriteCancelledBeforeDispatch(t *testing.T) {
+	tbl := dynamodbtest.NewTable(t, table.Table{TableName: "cancelled", KeySchema: table.KeySchema{PkName: "pk"}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := table.Write(ctx, tbl, []interface{}{map[string]string{"pk": "a"}}, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want %v", err, context.Canceled)
+	}
+	if len(report.UnattemptedItems) != 1 {
+		t.Errorf("unattempted items = %d, want 1", len(report.UnattemptedItems))
+	}
+}
+
+func TestExecuteCancelledDuringBackoff(t *testing.T) {
+	throttleStatements := memdb.WithThrottle(func(operation string) bool { return operation == "BatchExecuteStatement" })
+	tbl := dynamodbtest.NewTable(t, table.Table{TableName: "backoff", KeySchema: table.KeySchema{PkName: "pk"}}, dynamodbtest.WithMemDB(memdb.New(throttleStatements)))
+
+	stmts := []table.PartiQLCommand{
+		{Statement: `INSERT INTO "` + tbl.TableName + `" VALUE {'pk': ?}`, Tokens: []interface{}{"a"}},
+		{Statement: `INSERT INTO "` + tbl.TableName + `" VALUE {'pk': ?}`, Tokens: []interface{}{"b"}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	report, err := table.Execute(ctx, tbl, stmts, table.InputOptions{"BatchWriteInitialBackofficeDelayMs": 60000})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if len(report.ExhaustedStatements) != 0 {
+		t.Errorf("exhausted statements = %d, want 0", len(report.ExhaustedStatements))
+	}
+	if len(report.UnattemptedStatements) != 1 || report.UnattemptedStatements[0].Tokens[0] != "b" {
+		t.Errorf("unattempted statements = %v, want the throttled statement", report.UnattemptedStatements)
+	}
+}
+
+func TestBulkReportsUnprocessedKeysThatFormatAlike(t *testing.T) {
+	sk := "sk"
+	schema := table.KeySchema{PkName: "pk", SkName: &sk}
+	always := memdb.WithThrottle(func(operation string) bool { return operation == "BatchWriteItem" })
+	opts := table.InputOptions{"BatchWriteMaxRetries": 0}
+
+	processed := []compositeKey{{Pk: "x", Sk: "1"}, {Pk: "y", Sk: "1"}}
+	colliding := []compositeKey{{Pk: "a, b", Sk: "c"}, {Pk: "a", Sk: "b, c"}}
+
+	t.Run("Write", func(t *testing.T) {
+		tbl := dynamodbtest.NewTable(t, table.Table{TableName: "unwritten", KeySchema: schema}, dynamodbtest.WithMemDB(memdb.New(always)))
+
+		items := []interface{}{processed[0], processed[1], colliding[0], colliding[1]}
+		report, err := table.Write(context.Background(), tbl, items, opts)
+		if !errors.Is(err, dberrors.ErrUnprocessed) {
+			t.Fatalf("error = %v, want %v", err, dberrors.ErrUnprocessed)
+		}
+
+		if want := []interface{}{colliding[0], colliding[1]}; !reflect.DeepEqual(report.UnwrittenItems, want) {
+			t.Errorf("unwritten items = %v, want %v", report.UnwrittenItems, want)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		tbl := dynamodbtest.NewTable(t, table.Table{TableName: "undeleted", KeySchema: schema}, dynamodbtest.WithMemDB(memdb.New(always)))
+
+		keys := []table.PrimaryKey{processed[0], processed[1], colliding[0], colliding[1]}
+		report, err := table.Delete(context.Background(), tbl, keys, opts)
+		if !errors.Is(err, dberrors.ErrUnprocessed) {
+			t.Fatalf("error = %v, want %v", err, dberrors.ErrUnprocessed)
+		}
+
+		if want := []table.PrimaryKey{colliding[0], colliding[1]}; !reflect.DeepEqual(report.UndeletedKeys, want) {
+			t.Errorf("undeleted keys = %v, want %v", report.UndeletedKeys, want)
+		}
+	})
+}
+
+func TestBulkReportsProgressPerBatch(t *testing.T) {
+	throttleBatchWrites := func(n int64) memdb.Option {
+		var calls int64
+		return memdb.WithThrottle(func(operation string) bool {
+			return operation == "BatchWriteItem" && (n < 0 || atomic.AddInt64(&calls, 1) <= n)
+		})
+	}
+	progressOptions := func(maxRetries int, progress *[]table.WriteProgress) table.InputOptions {
+		opts := table.InputOptions{"WriteConcurrency": 1, "BatchWriteMaxRetries": maxRetries, "BatchWriteInitialBackofficeDelayMs": 1}
+		if err := table.WithProgress(func(p table.WriteProgress) { *progress = append(*progress, p) })(opts); err != nil {
+			t.Fatalf("WithProgress() error = %v", err)
+		}
+		return opts
+	}
+
+	t.Run("Write", func(t *testing.T) {
+		tbl := dynamodbtest.NewTable(t, table.Table{TableName: "progress", KeySchema: table.KeySchema{PkName: "pk"}}, dynamodbtest.WithMemDB(memdb.New(throttleBatchWrites(2))))
+
+		items := make([]interface{}, 0, 30)
+		for i := 0; i < 29; i++ {
+			items = append(items, stringKey{Pk: fmt.Sprintf("item-%02d", i)})
+		}
+		items = append(items, map[string]string{"name": "missing key"})
+
+		var progress []table.WriteProgress
+		report, err := table.Write(context.Background(), tbl, items, progressOptions(3, &progress))
+		if !errors.Is(err, dberrors.ErrValidation) {
+			t.Fatalf("error = %v, want %v", err, dberrors.ErrValidation)
+		}
+		if len(report.UnwrittenItems) != 0 {
+			t.Errorf("unwritten items = %v, want none", report.UnwrittenItems)
+		}
+
+		want := []table.WriteProgress{
+			{TotalItems: 30, BatchesCompleted: 1, ItemsWritten: 25, Retries: 2},
+			{TotalItems: 30, BatchesCompleted: 2, ItemsWritten: 29, Retries: 2},
+		}
+		if !reflect.DeepEqual(progress, want) {
+			t.Errorf("progress = %+v, want %+v", progress, want)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		tbl := dynamodbtest.NewTable(t, table.Table{TableName: "progress", KeySchema: table.KeySchema{PkName: "pk"}}, dynamodbtest.WithMemDB(memdb.New(throttleBatchWrites(-1))))
+
+		keys := make([]table.PrimaryKey, 0, 10)
+		for i := 0; i < 10; i++ {
+			keys = append(keys, stringKey{Pk: fmt.Sprintf("item-%02d", i)})
+		}
+
+		var progress []table.WriteProgress
+		report, err := table.Delete(context.Background(), tbl, keys, progressOptions(1, &progress))
+		if !errors.Is(err, dberrors.ErrUnprocessed) {
+			t.Fatalf("error = %v, want %v", err, dberrors.ErrUnprocessed)
+		}
+		if len(report.UndeletedKeys) != 3 {
+			t.Errorf("undeleted keys = %v, want 3 keys", report.UndeletedKeys)
+		}
+
+		want := []table.WriteProgress{{TotalItems: 10, BatchesCompleted: 1, ItemsWritten: 7, Retries: 1}}
+		if !reflect.DeepEqual(progress, want) {
+			t.Errorf("progress = %+v, want %+v", progress, want)
+		}
+	})
+}
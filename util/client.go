@@ -3,34 +3,16 @@ package util
 import (
 	"context"
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/jhmachado/dynamodb/dbconfig"
 	"time"
 )
 
 func BuildClientConfig() (aws.Config, error) {
-	region, url := GetDynamoDBServiceEnvs()
-	var options []func(options *config.LoadOptions) error
-	options = append(options, config.WithRegion(region))
-
-	if IsLocalSetup() {
-		endpointResolver := aws.EndpointResolverWithOptionsFunc(func(service string, region string, options ...interface{}) (aws.Endpoint, error) {
-			return aws.Endpoint{
-				URL: url,
-			}, nil
-		})
-		options = append(options, config.WithEndpointResolverWithOptions(endpointResolver))
-
-		credentialsProvider := credentials.StaticCredentialsProvider{
-			Value: aws.Credentials{
-				AccessKeyID:     "TestKey",
-				SecretAccessKey: "TestSecretKey",
-			},
-		}
-		options = append(options, config.WithCredentialsProvider(credentialsProvider))
+	cfg, err := dbconfig.FromEnv()
+	if err != nil {
+		return aws.Config{}, err
 	}
-
-	return config.LoadDefaultConfig(context.TODO(), options...)
+	return cfg.AWSConfig(context.TODO())
 }
 
 func BuildDBContext(ctx context.Context, timeoutMs *int) (context.Context, context.CancelFunc) {
@@ -1,20 +1,20 @@
 package util
 
 import (
+	"github.com/jhmachado/dynamodb/dbconfig"
 	"os"
-	"strings"
 )
 
 func SetDynamoDBEnvDefaults() {
-	os.Setenv("DYNAMODB_REGION", "")
-	os.Setenv("DYNAMODB_URL", "http://localhost:8000")
+	os.Setenv(dbconfig.EnvRegion, "")
+	os.Setenv(dbconfig.EnvEndpoint, "http://localhost:8000")
 	os.Setenv("DYNAMODB_TABLE", "messages")
 }
 
 func GetDynamoDBServiceEnvs() (string, string) {
-	return os.Getenv("DYNAMODB_REGION"), os.Getenv("DYNAMODB_URL")
+	return os.Getenv(dbconfig.EnvRegion), os.Getenv(dbconfig.EnvEndpoint)
 }
 
 func IsLocalSetup() bool {
-	return strings.ToUpper(os.Getenv("IS_CLOUD_DEPLOY")) == "TRUE"
+	return !dbconfig.IsCloudDeploy()
 }
@@ -1,11 +1,11 @@
 package util
 
 type MemoryOnlyPrimaryKey struct {
-	Pk string
+	Pk interface{}
 	Sk interface{}
 }
 
-func (p MemoryOnlyPrimaryKey) PK() string {
+func (p MemoryOnlyPrimaryKey) PK() interface{} {
 	return p.Pk
 }
 